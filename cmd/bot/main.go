@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -9,25 +10,33 @@ import (
 	"syscall"
 	"time"
 
+	"telegram-bot/internal/adapter/cache"
 	"telegram-bot/internal/adapter/repository/mongodb"
 	"telegram-bot/internal/adapter/telegram"
 	"telegram-bot/internal/config"
+	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/handler"
 	"telegram-bot/internal/handlers/command"
 	"telegram-bot/internal/handlers/keyword"
 	"telegram-bot/internal/handlers/listener"
 	"telegram-bot/internal/handlers/pattern"
+	"telegram-bot/internal/health"
 	"telegram-bot/internal/middleware"
 	"telegram-bot/internal/scheduler"
+	"telegram-bot/pkg/lock"
 	"telegram-bot/pkg/logger"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// version 机器人版本（启动日志与 /version 命令）
+const version = "2.0.0"
+
 func main() {
 	// 记录启动时间
 	startTime := time.Now()
@@ -48,11 +57,11 @@ func main() {
 		Level:  logger.ParseLevel(cfg.LogLevel),
 		Format: cfg.LogFormat,
 	})
-	appLogger.Info("🚀 Bot starting...", "version", "2.0.0")
+	appLogger.Info("🚀 Bot starting...", "version", version)
 	appLogger.Info("Logger initialized", "level", cfg.LogLevel, "format", cfg.LogFormat)
 
 	// 3. 初始化 MongoDB
-	mongoClient, err := initMongoDB(cfg.MongoURI)
+	mongoClient, err := initMongoDB(cfg, appLogger)
 	if err != nil {
 		appLogger.Error("Failed to connect to MongoDB", "error", err)
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
@@ -74,47 +83,121 @@ func main() {
 	}
 
 	// 4. 初始化仓储
-	userRepo := mongodb.NewUserRepository(db)
-	groupRepo := mongodb.NewGroupRepository(db)
+	// 群组配置每条消息都会读取、用户每条命令都会读取，经缓存包装后再使用
+	appCache, dedupCache, err := initCache(cfg)
+	if err != nil {
+		appLogger.Error("Failed to initialize cache", "backend", cfg.CacheBackend, "error", err)
+		log.Fatalf("Failed to initialize cache: %v", err)
+	}
+	appLogger.Info("✅ Cache initialized", "backend", cfg.CacheBackend)
+	userRepo := cache.NewUserCache(mongodb.NewUserRepository(db), appCache, cfg.UserCacheTTL)
+	groupRepo := cache.NewGroupCache(mongodb.NewGroupRepository(db), appCache, cfg.GroupCacheTTL)
+	groupRepo.SetReadRepairRate(float64(cfg.GroupCacheRepairPercent) / 100)
+	scheduleRepo := mongodb.NewScheduleRepository(db)
+	restrictionRepo := mongodb.NewRestrictionRepository(db)
+	activityRepo := mongodb.NewActivityRepository(db)
+	permissionChangeRepo := mongodb.NewPermissionChangeRepository(db)
+	feedbackRepo := mongodb.NewFeedbackRepository(db)
+
+	// 4.1. 消息计数先缓冲在内存中，定时或达到阈值后批量写入
+	messageCounter := listener.NewBufferedCounter(activityRepo, cfg.MessageCountFlushInterval, cfg.MessageCountFlushThreshold, appLogger)
+	messageCounter.Start()
 
 	// 5. 创建路由器
 	router := handler.NewRouter()
+	router.SetTimeout(cfg.UpdateTimeout)
+	router.SetCommandLimits(cfg.CommandMaxArgs, cfg.CommandMaxLength)
+	maintenance := handler.NewMaintenance(cfg.OwnerUserIDs, cfg.MaintenancePauseListeners)
+	router.SetMaintenance(maintenance)
+	handler.SetCommandPrefixes(cfg.CommandPrefixes)
+
+	// 消息文本写入日志前脱敏
+	redactor, err := newMessageRedactor(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create log redactor: %v", err)
+	}
 
 	// 6. 注册全局中间件（按执行顺序）
 	router.Use(middleware.NewRecoveryMiddleware(appLogger).Middleware())
-	router.Use(middleware.NewLoggingMiddleware(appLogger).Middleware())
-	router.Use(middleware.NewPermissionMiddleware(userRepo, cfg.OwnerUserIDs, appLogger).Middleware())
+	loggingMiddleware := middleware.NewLoggingMiddleware(appLogger)
+	loggingMiddleware.SetRedactor(redactor)
+	router.Use(loggingMiddleware.Middleware())
 	router.Use(middleware.NewGroupMiddleware(groupRepo, appLogger).Middleware())
+	permissionMiddleware := middleware.NewPermissionMiddleware(userRepo, cfg.OwnerUserIDs, appLogger)
+	permissionMiddleware.SetSeenInterval(cfg.UserSeenInterval)
+	router.Use(permissionMiddleware.Middleware())
+	// 处理器记录的管理操作（封禁、影子封禁、全群禁言等）写入审计日志
+	router.Use(middleware.NewAuditMiddleware(appLogger).Middleware())
 	// 可选：添加限流中间件
 	// rateLimiter := middleware.NewSimpleRateLimiter(time.Second, 5)
 	// router.Use(middleware.NewRateLimitMiddleware(rateLimiter).Middleware())
 
 	appLogger.Info("✅ Middlewares registered")
 
-	// 7. 注册处理器
-	registerHandlers(router, groupRepo, userRepo, appLogger)
-	appLogger.Info("✅ Handlers registered", "count", router.Count())
-
-	// 8. 初始化 WaitGroup 用于追踪正在处理的消息
+	// 7. 初始化 WaitGroup 用于追踪正在处理的消息
 	var wg sync.WaitGroup
 
-	// 9. 初始化 Telegram Bot
+	// 8. 初始化 Telegram Bot
+	// 所有出站消息经过发送队列，遵守 Telegram 的单聊天/全局速率限制
+	sendQueue := telegram.NewSendQueue(cfg.SendChatInterval, cfg.SendGlobalPerSecond)
+	sentMessages := telegram.NewSentMessages(100) // 每个聊天保留最近 100 条机器人消息，供 /cleanup 使用
+	// 丢弃重复投递的更新（同一 update_id 只处理一次）
+	deduplicator := telegram.NewUpdateDeduplicator(dedupCache, cfg.UpdateDedupTTL)
+	// 内联查询（@botname 查询）路由器；需在 BotFather 中开启 Inline Mode
+	inlineRouter := handler.NewInlineRouter()
+	// 内联键盘按钮回调路由器
+	callbackRouter := handler.NewCallbackRouter()
+	var telegramAPI *telegram.API
+	var autoDeleter *telegram.AutoDeleter
 	opts := []bot.Option{
 		bot.WithDefaultHandler(func(ctx context.Context, b *bot.Bot, update *models.Update) {
 			// 增加计数器
 			wg.Add(1)
 			defer wg.Done()
 
+			// 内联查询单独路由，不经过消息中间件
+			if q := telegram.ConvertInlineQuery(update); q != nil {
+				results, err := inlineRouter.Route(ctx, q)
+				if err != nil {
+					appLogger.Error("inline_route_error", "query_id", q.ID, "error", err)
+				}
+				if err := telegramAPI.AnswerInlineQuery(ctx, q.ID, results, 60); err != nil {
+					appLogger.Error("answer_inline_query_failed", "query_id", q.ID, "error", err)
+				}
+				return
+			}
+
+			// 按钮回调单独路由，不经过消息中间件；无论处理结果如何都要应答，结束客户端的加载状态
+			if q := telegram.ConvertCallbackQuery(update); q != nil {
+				answer, err := callbackRouter.Route(ctx, q)
+				if err != nil {
+					appLogger.Error("callback_route_error", "query_id", q.ID, "data", q.Data, "error", err)
+				}
+				if err := telegramAPI.AnswerCallbackQuery(ctx, q.ID, answer.Text, answer.ShowAlert); err != nil {
+					appLogger.Error("answer_callback_query_failed", "query_id", q.ID, "error", err)
+				}
+				return
+			}
+
 			// 转换为 Handler Context
 			handlerCtx := telegram.ConvertUpdate(ctx, b, update)
 			if handlerCtx == nil {
 				return // 不是消息更新，忽略
 			}
+			if deduplicator.IsDuplicate(ctx, update.ID) {
+				appLogger.Debug("duplicate_update_dropped", "update_id", update.ID)
+				return
+			}
+			handlerCtx.Throttle = sendQueue
+			handlerCtx.Sent = sentMessages
+			handlerCtx.AutoDelete = autoDeleter
 
 			// 路由消息
 			if err := router.Route(handlerCtx); err != nil {
-				appLogger.Error("route_error", "error", err)
-				handlerCtx.Reply("❌ 处理消息时出错，请稍后再试")
+				appLogger.Error("route_error", "request_id", handlerCtx.RequestID, "error", err)
+				// 超过处理期限时 ctx 已取消，错误提示不受处理期限限制
+				handlerCtx.Ctx = context.WithoutCancel(handlerCtx.RequestContext())
+				handlerCtx.Reply(handler.UserMessage(err))
 			}
 		}),
 	}
@@ -127,12 +210,86 @@ func main() {
 
 	appLogger.Info("✅ Telegram Bot initialized successfully")
 
+	// 获取机器人用户名，群组中 /cmd@otherbot 形式的命令不再误处理
+	if me, err := telegramBot.GetMe(context.Background()); err != nil {
+		appLogger.Warn("Failed to get bot username, commands addressed to other bots will not be filtered", "error", err)
+	} else {
+		handler.SetBotUsername(me.Username)
+		appLogger.Info("✅ Bot username loaded", "username", me.Username)
+	}
+	telegramAPI = telegram.NewAPI(telegramBot, sendQueue, sentMessages)
+	// 群组开启 /autodelete 后，机器人回复到期删除（最晚延迟 5 秒）
+	autoDeleter = telegram.NewAutoDeleter(telegramAPI, sentMessages, func(chatID int64, messageID int, err error) {
+		appLogger.Warn("Failed to auto-delete message", "chat_id", chatID, "message_id", messageID, "error", err)
+	})
+	autoDeleter.StartSweeper(5 * time.Second)
+	// 要求成员资格的命令（BaseCommand.RequireMembership）拒绝非群组成员，需要 Telegram API，因此在此注册
+	membershipMiddleware := middleware.NewMembershipMiddleware(telegramAPI, cfg.MembershipCacheTTL, appLogger)
+	router.Use(membershipMiddleware.Middleware())
+	// 每用户命令冷却，与全局限流互相独立：动作命令和 /feedback 有默认冷却，COMMAND_COOLDOWNS 覆盖或补充
+	// 趣味动作集合（ACTIONS_FILE），冷却时间和处理器使用同一份集合
+	actions, err := command.LoadActions(cfg.ActionsFile)
+	if err != nil {
+		appLogger.Error("Failed to load actions", "file", cfg.ActionsFile, "error", err)
+		log.Fatalf("Failed to load actions: %v", err)
+	}
+	cooldowns := command.DefaultCooldowns(actions, cfg.ActionCooldown, cfg.FeedbackCooldown)
+	for name, d := range cfg.CommandCooldowns {
+		cooldowns[name] = d
+	}
+	cooldownMiddleware := middleware.NewCooldownMiddleware(cooldowns)
+	router.Use(cooldownMiddleware.Middleware())
+	// 按命令配置的每群组每日配额（COMMAND_DAILY_QUOTAS），计数保存在 MongoDB
+	if len(cfg.CommandDailyQuotas) > 0 {
+		router.Use(middleware.NewQuotaMiddleware(cfg.CommandDailyQuotas, mongodb.NewCommandQuotaRepository(db)).Middleware())
+		appLogger.Info("✅ Command daily quotas enabled", "commands", len(cfg.CommandDailyQuotas))
+	}
+
+	// 8.1. 防突袭：入群突增时进入防突袭模式，静默期后自动解除并通知群组
+	raidDetector := listener.NewRaidDetector(cfg.AntiRaidJoinThreshold, cfg.AntiRaidWindow, cfg.AntiRaidQuietPeriod)
+	raidDetector.StartSweeper(30*time.Second, func(groupID int64) {
+		appLogger.Info("anti_raid_lifted", "group_id", groupID)
+		if err := telegram.IgnoreMessageID(telegramAPI.SendMessage(context.Background(), groupID, "✅ 一段时间内没有新成员加入，防突袭模式已解除")); err != nil {
+			appLogger.Error("Failed to announce anti-raid lift", "group_id", groupID, "error", err)
+		}
+	})
+
+	// 8.2. /restart 通过此通道触发与退出信号相同的优雅关闭流程
+	restartChan := make(chan struct{}, 1)
+	requestRestart := func() {
+		select {
+		case restartChan <- struct{}{}:
+		default: // 已在关闭中
+		}
+	}
+
+	// 9. 注册处理器（部分处理器依赖 Telegram API；Bot 启动前不会分发消息）
+	registerHandlers(router, callbackRouter, maintenance, requestRestart, redactor, groupRepo, userRepo, scheduleRepo, restrictionRepo, activityRepo, permissionChangeRepo, feedbackRepo, telegramAPI, sentMessages, messageCounter, raidDetector, actions, cfg, appLogger)
+	if err := router.Validate(); err != nil {
+		appLogger.Error("Invalid handler registration", "error", err)
+		log.Fatalf("Invalid handler registration: %v", err)
+	}
+	appLogger.Info("✅ Handlers registered", "count", router.Count())
+
+	inlineRouter.Register(pattern.NewInlineCalculator())
+	appLogger.Info("✅ Inline handlers registered", "count", inlineRouter.Count())
+	appLogger.Info("✅ Callback handlers registered", "count", callbackRouter.Count())
+
 	// 10. 初始化定时任务调度器
+	owner := instanceID()
 	taskScheduler := scheduler.NewScheduler(appLogger)
+	if cfg.SchedulerLockEnabled {
+		// 多实例部署：同一任务只在获取到锁的实例上执行
+		taskScheduler.SetLocker(lock.NewMongoLocker(db, owner))
+		appLogger.Info("✅ Scheduler lock enabled", "owner", owner)
+	}
 
 	// 添加定时任务
 	taskScheduler.AddJob(scheduler.NewCleanupExpiredDataJob(db, appLogger))
 	taskScheduler.AddJob(scheduler.NewStatisticsReportJob(userRepo, groupRepo, appLogger))
+	taskScheduler.AddJob(scheduler.NewScheduledMessageJob(scheduleRepo, telegramAPI, appLogger))
+	taskScheduler.AddJob(scheduler.NewTempBanReconcileJob(restrictionRepo, telegramAPI, appLogger))
+	taskScheduler.AddJob(scheduler.NewSilenceRestoreJob(restrictionRepo, telegramAPI, appLogger))
 
 	appLogger.Info("✅ Scheduler initialized", "jobs", len(taskScheduler.GetJobs()))
 
@@ -140,36 +297,103 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	// 12. 启动 Bot
+	// 12. 启动健康检查服务（可选）
+	var healthServer *health.Server
+	if cfg.HealthServerEnabled {
+		healthServer = health.NewServer(fmt.Sprintf(":%d", cfg.Port))
+		// 依赖检查结果缓存一段时间，频繁的探针不会每次都访问 MongoDB 和 Telegram
+		healthServer.AddChecker(health.NewCachedChecker(health.NewSimpleChecker("mongodb", func(ctx context.Context) error {
+			return mongoClient.Ping(ctx, nil)
+		}), cfg.HealthCheckCacheTTL))
+		healthServer.AddChecker(health.NewCachedChecker(health.NewSimpleChecker("telegram", func(ctx context.Context) error {
+			_, err := telegramBot.GetMe(ctx)
+			return err
+		}), cfg.HealthCheckCacheTTL))
+		healthServer.AddInfo("instance", func() interface{} { return owner })
+		healthServer.AddInfo("telegram_retries", func() interface{} { return telegramAPI.RetryStats() })
+		if cfg.DebugEndpointsEnabled {
+			healthServer.AddDebug("commands", func() interface{} { return router.Describe() })
+		}
+		if cfg.HealthCheckInterval > 0 {
+			healthServer.StartBackground(cfg.HealthCheckInterval)
+		}
+		healthServer.Start(func(err error) {
+			appLogger.Error("Health server failed", "error", err)
+		})
+		appLogger.Info("✅ Health server started", "port", cfg.Port)
+	}
+
+	// 13. 启动 Bot
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 在 goroutine 中启动 bot
-	go func() {
-		appLogger.Info("✅ Bot is running", "uptime", time.Since(startTime))
-		telegramBot.Start(ctx)
-	}()
+	// 其他实例修改群组或用户后立即删除本实例的缓存（MongoDB 不支持 change stream 时只依赖 TTL）
+	go mongodb.NewGroupChangeWatcher(db, groupRepo, appLogger).Run(ctx)
+	go mongodb.NewUserChangeWatcher(db, userRepo, appLogger).Run(ctx)
+
+	if cfg.LeaderElectionEnabled {
+		// 主备部署：只有主节点拉取和处理更新，备用节点等待租约过期后接管
+		elector := lock.NewElector(lock.NewMongoLocker(db, owner), "leader:updates", cfg.LeaderLease)
+		if healthServer != nil {
+			healthServer.AddInfo("leader", func() interface{} { return elector.IsLeader() })
+		}
+		appLogger.Info("⏳ Waiting for leadership", "owner", owner, "lease", cfg.LeaderLease)
+		go elector.Run(ctx, func(leadCtx context.Context) {
+			appLogger.Info("👑 Elected as leader, bot is running", "owner", owner)
+			// 只有主节点检查群组中已移除命令的配置，避免多个实例同时写入
+			reconcileCommandConfigs(leadCtx, groupRepo, router.Commands(), cfg, appLogger)
+			telegramBot.Start(leadCtx)
+			appLogger.Warn("Lost leadership, stopped processing updates", "owner", owner)
+		})
+	} else {
+		// 在 goroutine 中启动 bot
+		go func() {
+			reconcileCommandConfigs(ctx, groupRepo, router.Commands(), cfg, appLogger)
+			appLogger.Info("✅ Bot is running", "uptime", time.Since(startTime))
+			telegramBot.Start(ctx)
+		}()
+	}
 
-	// 13. 启动定时任务调度器
+	// 14. 启动定时任务调度器
 	taskScheduler.Start()
 	appLogger.Info("✅ Scheduler started")
 
-	// 14. 等待退出信号
-	sig := <-sigChan
-	appLogger.Info("📥 Received shutdown signal", "signal", sig.String())
+	// 15. 等待退出信号
+	select {
+	case sig := <-sigChan:
+		appLogger.Info("📥 Received shutdown signal", "signal", sig.String())
+	case <-restartChan:
+		appLogger.Info("📥 Restart requested via /restart")
+	}
 
-	// 15. 开始优雅关闭
-	shutdown(appLogger, mongoClient, taskScheduler, &wg, cancel, startTime)
+	// 16. 开始优雅关闭
+	shutdown(appLogger, mongoClient, taskScheduler, messageCounter, raidDetector, permissionMiddleware, membershipMiddleware, cooldownMiddleware, healthServer, &wg, cancel, startTime)
 }
 
-// initMongoDB 初始化 MongoDB 连接（优化连接池配置）
-func initMongoDB(uri string) (*mongo.Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// reconcileCommandConfigs 检查群组中已移除命令的启用/禁用配置并记录日志
+// PRUNE_STALE_COMMAND_CONFIGS=true 时删除这些配置，默认只记录（回滚到旧版本时配置仍然有效）
+func reconcileCommandConfigs(ctx context.Context, groupRepo command.CommandConfigRepository, commands []handler.CommandInfo, cfg *config.Config, appLogger logger.Logger) {
+	reconcileCtx, reconcileCancel := context.WithTimeout(ctx, cfg.MongoTimeout)
+	defer reconcileCancel()
+
+	staleConfigs, err := command.ReconcileCommandConfigs(reconcileCtx, groupRepo, commands, cfg.PruneStaleCommandConfigs)
+	for _, stale := range staleConfigs {
+		if stale.Pruned {
+			appLogger.Info("Pruned stale command configs", "group_id", stale.GroupID, "commands", stale.Commands)
+		} else {
+			appLogger.Warn("Group has configs for unknown commands", "group_id", stale.GroupID, "commands", stale.Commands)
+		}
+	}
+	if err != nil {
+		appLogger.Error("Failed to reconcile command configs", "error", err)
+	}
+}
 
+// initMongoDB 初始化 MongoDB 连接（优化连接池配置），启动时 MongoDB 不可用会按指数退避重试
+func initMongoDB(cfg *config.Config, appLogger logger.Logger) (*mongo.Client, error) {
 	// 优化的连接池配置
 	clientOpts := options.Client().
-		ApplyURI(uri).
+		ApplyURI(cfg.MongoURI).
 		SetMaxPoolSize(100).                                        // 最大连接数
 		SetMinPoolSize(10).                                         // 最小连接数
 		SetMaxConnIdleTime(30 * time.Second).                       // 空闲连接超时
@@ -181,21 +405,65 @@ func initMongoDB(uri string) (*mongo.Client, error) {
 		SetRetryWrites(true).                                       // 自动重试写入
 		SetRetryReads(true)                                         // 自动重试读取
 
-	client, err := mongo.Connect(ctx, clientOpts)
-	if err != nil {
-		return nil, err
+	// 连接并 Ping 验证
+	return mongodb.Connect(context.Background(), clientOpts, mongodb.ConnectConfig{
+		MaxAttempts:    cfg.MongoConnectAttempts,
+		AttemptTimeout: cfg.MongoTimeout,
+		TotalTimeout:   cfg.MongoConnectTotalTimeout,
+		InitialDelay:   time.Second,
+		MaxDelay:       15 * time.Second,
+	}, appLogger)
+}
+
+// newMessageRedactor 创建记录消息文本用的脱敏器，LOG_REDACT_MESSAGES=false 时返回 nil
+func newMessageRedactor(cfg *config.Config) (middleware.Redactor, error) {
+	if !cfg.LogRedactMessages {
+		return nil, nil
 	}
+	return logger.NewSanitizerWithPatterns(cfg.LogRedactPatterns)
+}
 
-	// 测试连接
-	if err := client.Ping(ctx, nil); err != nil {
-		return nil, err
+// initCache 根据配置创建缓存（默认内存缓存）
+// 返回通用缓存和更新去重缓存；内存模式下两者分开，避免去重记录挤掉群组缓存
+func initCache(cfg *config.Config) (cache.Cache, cache.Cache, error) {
+	if cfg.CacheBackend != "redis" {
+		// 清理任务随进程退出，无需单独停止
+		memoryCache := cache.NewMemoryCache(cfg.CacheMaxEntries)
+		memoryCache.StartSweeper(time.Minute)
+		dedupCache := cache.NewMemoryCache(cfg.CacheMaxEntries)
+		dedupCache.StartSweeper(time.Minute)
+		return memoryCache, dedupCache, nil
 	}
 
-	return client, nil
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+
+	redisCache := cache.NewRedisCache(client, "telegram-bot:")
+	return redisCache, redisCache, nil
+}
+
+// instanceID 生成当前实例的唯一标识（主机名-进程号）
+func instanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
 }
 
 // shutdown 优雅关闭
-func shutdown(appLogger logger.Logger, mongoClient *mongo.Client, taskScheduler *scheduler.Scheduler, wg *sync.WaitGroup, cancel context.CancelFunc, startTime time.Time) {
+func shutdown(appLogger logger.Logger, mongoClient *mongo.Client, taskScheduler *scheduler.Scheduler, messageCounter *listener.BufferedCounter, raidDetector *listener.RaidDetector, permissionMiddleware *middleware.PermissionMiddleware, membershipMiddleware *middleware.MembershipMiddleware, cooldownMiddleware *middleware.CooldownMiddleware, healthServer *health.Server, wg *sync.WaitGroup, cancel context.CancelFunc, startTime time.Time) {
 	appLogger.Info("🛑 Starting graceful shutdown...")
 
 	// 1. 停止接收新的更新
@@ -207,6 +475,12 @@ func shutdown(appLogger logger.Logger, mongoClient *mongo.Client, taskScheduler
 	taskScheduler.Stop()
 	appLogger.Info("✅ Scheduler stopped")
 
+	// 2.1. 停止防突袭模式、活跃时间节流、成员资格缓存和命令冷却记录的后台清理任务
+	raidDetector.Stop()
+	permissionMiddleware.Stop()
+	membershipMiddleware.Stop()
+	cooldownMiddleware.Stop()
+
 	// 2.5. 停止 RateLimiter（如果启用）
 	// 注意：如果启用了 RateLimiter，需要在此处调用 rateLimiter.Stop()
 	// 否则会导致 goroutine 泄漏
@@ -230,11 +504,26 @@ func shutdown(appLogger logger.Logger, mongoClient *mongo.Client, taskScheduler
 		appLogger.Warn("⚠️ Shutdown timeout: some messages may not have completed")
 	}
 
-	// 4. 关闭数据库连接
-	appLogger.Info("Closing database connection...")
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
+	// 3.4. 关闭健康检查服务
+	if healthServer != nil {
+		if err := healthServer.Shutdown(shutdownCtx); err != nil {
+			appLogger.Error("Failed to stop health server", "error", err)
+		}
+	}
+
+	// 3.5. 写入缓冲中的消息计数（必须在关闭数据库之前）
+	if err := messageCounter.Stop(shutdownCtx); err != nil {
+		appLogger.Error("Failed to flush message counts", "error", err)
+	} else {
+		appLogger.Info("✅ Message counts flushed")
+	}
+
+	// 4. 关闭数据库连接
+	appLogger.Info("Closing database connection...")
+
 	if err := mongoClient.Disconnect(shutdownCtx); err != nil {
 		appLogger.Error("Failed to close database connection", "error", err)
 	} else {
@@ -255,24 +544,102 @@ func shutdown(appLogger logger.Logger, mongoClient *mongo.Client, taskScheduler
 // registerHandlers 注册所有处理器
 func registerHandlers(
 	router *handler.Router,
-	groupRepo *mongodb.GroupRepository,
-	userRepo *mongodb.UserRepository,
+	callbackRouter *handler.CallbackRouter,
+	maintenance *handler.Maintenance,
+	requestRestart func(),
+	redactor middleware.Redactor,
+	groupRepo group.Repository,
+	userRepo *cache.UserCache,
+	scheduleRepo *mongodb.ScheduleRepository,
+	restrictionRepo *mongodb.RestrictionRepository,
+	activityRepo *mongodb.ActivityRepository,
+	permissionChangeRepo *mongodb.PermissionChangeRepository,
+	feedbackRepo *mongodb.FeedbackRepository,
+	telegramAPI *telegram.API,
+	sentMessages *telegram.SentMessages,
+	messageCounter *listener.BufferedCounter,
+	raidDetector *listener.RaidDetector,
+	actions []command.Action,
+	cfg *config.Config,
 	appLogger logger.Logger,
 ) {
+	// 0. 影子封禁（优先级 50，在命令之前静默删除消息）
+	router.Register(listener.NewShadowBanHandler(groupRepo, userRepo, telegramAPI, appLogger))
+	// 防突袭（优先级 60，入群消息）
+	router.Register(listener.NewAntiRaidHandler(groupRepo, raidDetector, telegramAPI, telegramAPI, cfg.AntiRaidRestrictDuration))
+	// 新账号入群限制（优先级 61，入群消息，默认不开启）
+	router.Register(listener.NewAccountAgeHandler(groupRepo, telegramAPI, telegramAPI))
+	// 新成员同意群规（优先级 62，入群消息，默认不开启）
+	rulesGate := listener.NewRulesGateHandler(groupRepo, telegramAPI, raidDetector, cfg.AntiRaidRestrictDuration)
+	router.Register(rulesGate)
+	callbackRouter.Register(rulesGate)
+
 	// 1. 命令处理器（优先级 100）
 	router.Register(command.NewPingHandler(groupRepo))
 	router.Register(command.NewHelpHandler(groupRepo, router))
-	router.Register(command.NewStatsHandler(groupRepo, userRepo))
+	router.Register(command.NewVersionHandler(groupRepo, version))
+	feedbackHandler := command.NewFeedbackHandler(groupRepo, feedbackRepo, cfg.OwnerUserIDs)
+	feedbackHandler.RequireMembership() // 关联频道讨论组中的非成员也能发言，避免被用来刷反馈
+	router.Register(feedbackHandler)
+	router.Register(command.NewStatsHandler(groupRepo, userRepo, activityRepo, telegramAPI, messageCounter))
+	router.Register(command.NewGlobalStatsHandler(groupRepo, userRepo, activityRepo, cfg.OwnerUserIDs, func() command.RetryStats {
+		stats := telegramAPI.RetryStats()
+		return command.RetryStats{
+			Attempts:            stats.Attempts,
+			SucceededAfterRetry: stats.SucceededAfterRetry,
+			Exhausted:           stats.Exhausted,
+		}
+	}))
+	router.Register(command.NewDebugHandler(groupRepo, cfg.OwnerUserIDs))
+	router.Register(command.NewMaintenanceHandler(groupRepo, maintenance, cfg.OwnerUserIDs))
+	router.Register(command.NewRestartHandler(groupRepo, cfg.OwnerUserIDs, requestRestart))
+	router.Register(command.NewLogLevelHandler(groupRepo, appLogger, cfg.OwnerUserIDs, logger.ParseLevel(cfg.LogLevel), cfg.LogLevelRevertAfter))
 
 	// 权限管理命令
-	router.Register(command.NewPromoteHandler(groupRepo, userRepo))
-	router.Register(command.NewDemoteHandler(groupRepo, userRepo))
-	router.Register(command.NewSetPermHandler(groupRepo, userRepo))
+	router.Register(command.NewPromoteHandler(groupRepo, userRepo, permissionChangeRepo))
+	router.Register(command.NewDemoteHandler(groupRepo, userRepo, permissionChangeRepo))
+	router.Register(command.NewSetPermHandler(groupRepo, userRepo, permissionChangeRepo))
+	router.Register(command.NewPermLogHandler(groupRepo, userRepo, permissionChangeRepo))
 	router.Register(command.NewListAdminsHandler(groupRepo, userRepo))
+	router.Register(command.NewSyncAdminsHandler(groupRepo, userRepo, permissionChangeRepo, telegramAPI, cfg.OwnerUserIDs))
+	router.Register(command.NewInactiveHandler(groupRepo, userRepo))
 	router.Register(command.NewMyPermHandler(groupRepo))
 
+	// 群组管理命令
+	router.Register(command.NewTempBanHandler(groupRepo, userRepo, restrictionRepo, telegramAPI, messageCounter, telegramAPI))
+	router.Register(command.NewReasonsHandler(groupRepo))
+	router.Register(command.NewSilenceHandler(groupRepo, restrictionRepo, telegramAPI))
+	router.Register(command.NewUnsilenceHandler(groupRepo, restrictionRepo, telegramAPI))
+	router.Register(command.NewShadowBanHandler(groupRepo, userRepo))
+	router.Register(command.NewUnshadowBanHandler(groupRepo, userRepo))
+	router.Register(command.NewProtectHandler(groupRepo, userRepo))
+	router.Register(command.NewUnprotectHandler(groupRepo, userRepo))
+	router.Register(command.NewAntiRaidHandler(groupRepo, raidDetector))
+	router.Register(command.NewMinAccountAgeHandler(groupRepo))
+	router.Register(command.NewCleanupHandler(groupRepo, sentMessages, telegramAPI))
+
 	// 功能管理命令
 	router.Register(command.NewToggleCalcHandler(groupRepo, userRepo))
+	router.Register(command.NewToggleAnonHandler(groupRepo))
+	router.Register(command.NewDefaultPermHandler(groupRepo))
+	router.Register(command.NewSetTimezoneHandler(groupRepo))
+	router.Register(command.NewTimeFormatHandler(groupRepo))
+	router.Register(command.NewAutoDeleteHandler(groupRepo))
+	router.Register(command.NewScheduleHandler(groupRepo, scheduleRepo))
+	router.Register(command.NewRulesHandler(groupRepo))
+	router.Register(command.NewRulesGateHandler(groupRepo))
+
+	// 趣味命令
+	actionHandlers := command.NewActionHandlers(groupRepo, userRepo, actions)
+	for _, h := range actionHandlers {
+		router.Register(h)
+	}
+	voteHandler := command.NewVoteHandler(groupRepo, telegramAPI)
+	router.Register(voteHandler)
+	callbackRouter.Register(voteHandler)
+
+	// 未知命令提示（优先级 150，仅处理未被命令处理器匹配的命令）
+	router.Register(command.NewSuggestHandler(router))
 
 	// 2. 关键词处理器（优先级 200）
 	router.Register(keyword.NewGreetingHandler())
@@ -282,12 +649,13 @@ func registerHandlers(
 	router.Register(pattern.NewCalculatorHandler(groupRepo))
 
 	// 4. 监听器（优先级 900+）
-	router.Register(listener.NewMessageLoggerHandler(appLogger))
+	router.Register(listener.NewMessageLoggerHandler(appLogger, redactor))
+	router.Register(listener.NewMessageCounterHandler(messageCounter))
 
 	appLogger.Info("Registered handlers breakdown",
-		"commands", 9,
+		"commands", 39+len(actionHandlers),
 		"keywords", 1,
 		"patterns", 2,
-		"listeners", 1,
+		"listeners", 6,
 	)
 }
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss 缓存中不存在该键（或已过期）
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache 键值缓存接口
+// 值统一为字节切片，序列化由使用方负责
+type Cache interface {
+	// Get 获取缓存值，不存在时返回 ErrCacheMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set 设置缓存值，ttl <= 0 表示不过期
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX 仅在键不存在（或已过期）时设置缓存值，返回是否设置成功；检查和设置是原子的
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Delete 删除缓存值，键不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
+
+// IsCacheMiss 判断错误是否为缓存未命中
+func IsCacheMiss(err error) bool {
+	return errors.Is(err, ErrCacheMiss)
+}
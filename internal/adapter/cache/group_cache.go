@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strconv"
+	"telegram-bot/internal/domain/group"
+	"time"
+)
+
+// GroupCache 带缓存的群组仓储
+// 包装 group.Repository：FindByID 优先读取缓存，写操作后删除缓存。
+// 每条消息都会经过 GroupMiddleware 读取群组配置，缓存可显著减少数据库查询。
+// 其他实例直接写入数据库时本实例的缓存会过时，按比例抽样的缓存命中会回源校验（读修复），
+// 数据库中的记录更新时刷新缓存，使过时数据在 ttl 之前就能被纠正。
+type GroupCache struct {
+	repo  group.Repository
+	cache Cache
+	ttl   time.Duration
+
+	repairRate float64        // 缓存命中时回源校验的比例（0 表示不校验）
+	sample     func() float64 // 返回 [0, 1) 的随机数
+}
+
+// NewGroupCache 创建带缓存的群组仓储
+func NewGroupCache(repo group.Repository, cache Cache, ttl time.Duration) *GroupCache {
+	return &GroupCache{
+		repo:   repo,
+		cache:  cache,
+		ttl:    ttl,
+		sample: rand.Float64,
+	}
+}
+
+// SetReadRepairRate 设置缓存命中时回源校验的比例（0~1），0 表示不校验
+func (c *GroupCache) SetReadRepairRate(rate float64) {
+	c.repairRate = rate
+}
+
+// groupCacheKey 群组缓存键
+func groupCacheKey(id int64) string {
+	return "group:" + strconv.FormatInt(id, 10)
+}
+
+// FindByID 根据ID查找群组
+// 缓存读写失败不影响结果，直接回退到仓储
+func (c *GroupCache) FindByID(ctx context.Context, id int64) (*group.Group, error) {
+	key := groupCacheKey(id)
+
+	if data, err := c.cache.Get(ctx, key); err == nil {
+		var g group.Group
+		if err := json.Unmarshal(data, &g); err == nil {
+			if c.repairRate > 0 && c.sample() < c.repairRate {
+				return c.repair(ctx, &g)
+			}
+			return &g, nil
+		}
+	}
+
+	g, err := c.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(ctx, g)
+	return g, nil
+}
+
+// repair 用数据库中的记录校验缓存的群组，数据库中的记录更新时刷新缓存
+// 数据库查询失败时返回缓存的群组；群组已被删除时删除缓存
+func (c *GroupCache) repair(ctx context.Context, cached *group.Group) (*group.Group, error) {
+	g, err := c.repo.FindByID(ctx, cached.ID)
+	if errors.Is(err, group.ErrGroupNotFound) {
+		c.invalidate(ctx, cached.ID)
+		return nil, err
+	}
+	if err != nil {
+		return cached, nil
+	}
+
+	if g.UpdatedAt.After(cached.UpdatedAt) {
+		c.store(ctx, g)
+	}
+	return g, nil
+}
+
+// store 写入群组缓存
+func (c *GroupCache) store(ctx context.Context, g *group.Group) {
+	if data, err := json.Marshal(g); err == nil {
+		_ = c.cache.Set(ctx, groupCacheKey(g.ID), data, c.ttl)
+	}
+}
+
+// Save 保存群组并删除缓存
+func (c *GroupCache) Save(ctx context.Context, g *group.Group) error {
+	if err := c.repo.Save(ctx, g); err != nil {
+		return err
+	}
+	c.invalidate(ctx, g.ID)
+	return nil
+}
+
+// Update 更新群组并删除缓存
+func (c *GroupCache) Update(ctx context.Context, g *group.Group) error {
+	if err := c.repo.Update(ctx, g); err != nil {
+		return err
+	}
+	c.invalidate(ctx, g.ID)
+	return nil
+}
+
+// UnsetCommands 删除群组中指定命令的配置并删除缓存
+func (c *GroupCache) UnsetCommands(ctx context.Context, id int64, names []string) error {
+	if err := c.repo.UnsetCommands(ctx, id, names); err != nil {
+		return err
+	}
+	c.invalidate(ctx, id)
+	return nil
+}
+
+// Delete 删除群组并删除缓存
+func (c *GroupCache) Delete(ctx context.Context, id int64) error {
+	if err := c.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.invalidate(ctx, id)
+	return nil
+}
+
+// FindAll 查找所有群组（不缓存）
+func (c *GroupCache) FindAll(ctx context.Context) ([]*group.Group, error) {
+	return c.repo.FindAll(ctx)
+}
+
+// Invalidate 删除群组缓存，用于其他实例修改群组后使本实例的缓存失效（见 mongodb.ChangeWatcher）
+func (c *GroupCache) Invalidate(ctx context.Context, id int64) {
+	c.invalidate(ctx, id)
+}
+
+// invalidate 删除群组缓存
+// 数据库已写入成功，删除失败时旧数据最多保留 ttl，不视为操作失败
+func (c *GroupCache) invalidate(ctx context.Context, id int64) {
+	_ = c.cache.Delete(ctx, groupCacheKey(id))
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGroupCache_FindByIDUsesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+	g.DisableCommand("ping", 1)
+	g.EnableFeature("calculator")
+
+	// 仓储只查询一次
+	repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(g, nil).Times(1)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+
+	first, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+	second, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.False(t, second.IsCommandEnabled("ping"))
+	assert.True(t, second.IsFeatureEnabled("calculator"))
+}
+
+func TestGroupCache_UpdateInvalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+	repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(g, nil).Times(2)
+	repo.EXPECT().Update(gomock.Any(), g).Return(nil)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+
+	_, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+
+	require.NoError(t, groupCache.Update(ctx, g))
+
+	// 更新后重新从仓储读取
+	_, err = groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+}
+
+func TestGroupCache_UnsetCommandsInvalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+	repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(g, nil).Times(2)
+	repo.EXPECT().UnsetCommands(gomock.Any(), int64(-100), []string{"oldcmd"}).Return(nil)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+
+	_, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+
+	require.NoError(t, groupCache.UnsetCommands(ctx, -100, []string{"oldcmd"}))
+
+	// 删除配置后重新从仓储读取
+	_, err = groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+}
+
+func TestGroupCache_NotFoundNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(nil, group.ErrGroupNotFound).Times(2)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+
+	_, err := groupCache.FindByID(ctx, -100)
+	assert.ErrorIs(t, err, group.ErrGroupNotFound)
+	_, err = groupCache.FindByID(ctx, -100)
+	assert.ErrorIs(t, err, group.ErrGroupNotFound)
+}
+
+func TestGroupCache_ReadRepairRefreshesStaleEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	stale := group.NewGroup(-100, "Test Group", "supergroup")
+	stale.UpdatedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 另一个实例直接写入数据库
+	fresh := group.NewGroup(-100, "Test Group", "supergroup")
+	fresh.DisableCommand("ping", 1)
+	fresh.UpdatedAt = stale.UpdatedAt.Add(time.Minute)
+
+	gomock.InOrder(
+		repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(stale, nil),
+		repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(fresh, nil),
+	)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+	groupCache.SetReadRepairRate(0.5)
+	samples := []float64{0.1, 0.9}
+	groupCache.sample = func() float64 {
+		v := samples[0]
+		samples = samples[1:]
+		return v
+	}
+
+	_, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+
+	// 抽中校验：数据库中的记录更新，返回并缓存新记录
+	repaired, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+	assert.False(t, repaired.IsCommandEnabled("ping"))
+
+	// 未抽中：直接使用刷新后的缓存
+	cached, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+	assert.False(t, cached.IsCommandEnabled("ping"))
+	assert.True(t, cached.UpdatedAt.Equal(fresh.UpdatedAt))
+}
+
+func TestGroupCache_ReadRepairDeletedGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockGroupRepository(ctrl)
+	ctx := context.Background()
+
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+	gomock.InOrder(
+		repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(g, nil),
+		repo.EXPECT().FindByID(gomock.Any(), int64(-100)).Return(nil, group.ErrGroupNotFound).Times(2),
+	)
+
+	groupCache := NewGroupCache(repo, NewMemoryCache(0), time.Minute)
+	groupCache.SetReadRepairRate(1)
+
+	_, err := groupCache.FindByID(ctx, -100)
+	require.NoError(t, err)
+
+	// 已被其他实例删除：删除缓存
+	_, err = groupCache.FindByID(ctx, -100)
+	assert.ErrorIs(t, err, group.ErrGroupNotFound)
+	_, err = groupCache.FindByID(ctx, -100)
+	assert.ErrorIs(t, err, group.ErrGroupNotFound)
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry 内存缓存条目
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // 零值表示不过期
+}
+
+// expired 条目是否已过期
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCache 进程内缓存（默认实现）
+// 过期条目在 Get 时立即失效，并由后台清理任务定期删除；
+// 超过容量上限时淘汰最近最少使用的条目，避免群组很多时内存无限增长。
+// 适用于单实例部署；多实例部署请使用 RedisCache 以共享缓存
+type MemoryCache struct {
+	maxEntries int // 容量上限，<= 0 表示不限制
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // 队首为最近使用
+	now     func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewMemoryCache 创建内存缓存
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get 获取缓存值
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if entry.expired(c.now()) {
+		c.removeElement(elem)
+		return nil, ErrCacheMiss
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.value, nil
+}
+
+// Set 设置缓存值
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, ttl)
+	return nil
+}
+
+// SetNX 仅在键不存在或已过期时设置缓存值
+func (c *MemoryCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok && !elem.Value.(*memoryEntry).expired(c.now()) {
+		return false, nil
+	}
+
+	c.set(key, value, ttl)
+	return true, nil
+}
+
+// set 写入条目并按容量淘汰（调用方需持有锁）
+func (c *MemoryCache) set(key string, value []byte, ttl time.Duration) {
+	entry := &memoryEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+
+	// 超出容量时淘汰最久未使用的条目
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// Delete 删除缓存值
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+
+	return nil
+}
+
+// Len 当前条目数（包含尚未清理的过期条目）
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Sweep 删除所有已过期条目，返回删除数量
+func (c *MemoryCache) Sweep() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	removed := 0
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*memoryEntry).expired(now) {
+			c.removeElement(elem)
+			removed++
+		}
+		elem = next
+	}
+
+	return removed
+}
+
+// StartSweeper 启动后台清理任务，每隔 interval 删除过期条目
+func (c *MemoryCache) StartSweeper(interval time.Duration) {
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.Sweep()
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台清理任务
+func (c *MemoryCache) Stop() {
+	if c.stop == nil {
+		return
+	}
+	close(c.stop)
+	<-c.done
+	c.stop = nil
+}
+
+// removeElement 删除条目（调用方需持有锁）
+func (c *MemoryCache) removeElement(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemoryCache 创建使用可控时钟的内存缓存
+func newTestMemoryCache(maxEntries int) (*MemoryCache, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewMemoryCache(maxEntries)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestMemoryCache_GetSetDelete(t *testing.T) {
+	c := NewMemoryCache(0)
+	ctx := context.Background()
+
+	_, err := c.Get(ctx, "key")
+	assert.True(t, IsCacheMiss(err))
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), 0))
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, c.Delete(ctx, "key"))
+	_, err = c.Get(ctx, "key")
+	assert.True(t, IsCacheMiss(err))
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	c, now := newTestMemoryCache(0)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Minute))
+
+	*now = now.Add(59 * time.Second)
+	_, err := c.Get(ctx, "key")
+	assert.NoError(t, err)
+
+	*now = now.Add(time.Second)
+	_, err = c.Get(ctx, "key")
+	assert.True(t, IsCacheMiss(err))
+
+	// 过期条目在读取时被删除
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	c, now := newTestMemoryCache(0)
+	ctx := context.Background()
+
+	stored, err := c.SetNX(ctx, "key", []byte("first"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	// 已存在时不覆盖
+	stored, err = c.SetNX(ctx, "key", []byte("second"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored)
+	value, _ := c.Get(ctx, "key")
+	assert.Equal(t, []byte("first"), value)
+
+	// 过期后可以重新设置
+	*now = now.Add(time.Minute)
+	stored, err = c.SetNX(ctx, "key", []byte("third"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+}
+
+func TestMemoryCache_Sweep(t *testing.T) {
+	c, now := newTestMemoryCache(0)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "short", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "long", []byte("2"), time.Hour))
+	require.NoError(t, c.Set(ctx, "forever", []byte("3"), 0))
+
+	*now = now.Add(2 * time.Minute)
+	assert.Equal(t, 1, c.Sweep())
+	assert.Equal(t, 2, c.Len())
+
+	_, err := c.Get(ctx, "long")
+	assert.NoError(t, err)
+	_, err = c.Get(ctx, "forever")
+	assert.NoError(t, err)
+}
+
+func TestMemoryCache_BackgroundSweeper(t *testing.T) {
+	c := NewMemoryCache(0)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Millisecond))
+
+	c.StartSweeper(5 * time.Millisecond)
+	defer c.Stop()
+
+	assert.Eventually(t, func() bool {
+		return c.Len() == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	c := NewMemoryCache(3)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, c.Set(ctx, fmt.Sprintf("k%d", i), []byte("v"), 0))
+	}
+
+	// 访问 k1，使 k2 成为最久未使用
+	_, err := c.Get(ctx, "k1")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, "k4", []byte("v"), 0))
+	assert.Equal(t, 3, c.Len())
+
+	_, err = c.Get(ctx, "k2")
+	assert.True(t, IsCacheMiss(err), "least recently used entry should be evicted")
+	for _, key := range []string{"k1", "k3", "k4"} {
+		_, err := c.Get(ctx, key)
+		assert.NoError(t, err, key)
+	}
+}
+
+func TestMemoryCache_OverwriteDoesNotGrow(t *testing.T) {
+	c := NewMemoryCache(2)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+	require.NoError(t, c.Set(ctx, "a", []byte("3"), 0))
+
+	assert.Equal(t, 2, c.Len())
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache 基于 Redis 的缓存
+// 多个 Bot 实例共享同一个 Redis 时，缓存失效对所有实例生效
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string // 键前缀，避免与其他应用冲突
+}
+
+// NewRedisCache 创建 Redis 缓存
+func NewRedisCache(client redis.UniversalClient, prefix string) *RedisCache {
+	return &RedisCache{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get 获取缓存值，redis.Nil 转换为 ErrCacheMiss
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set 设置缓存值
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0 // go-redis 中 0 表示不过期
+	}
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+// SetNX 使用 SET NX 原子地设置缓存值，多个实例同时设置时只有一个成功
+func (c *RedisCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return c.client.SetNX(ctx, c.prefix+key, value, ttl).Result()
+}
+
+// Delete 删除缓存值
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.prefix+key).Err()
+}
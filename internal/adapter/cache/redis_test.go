@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisCache(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisCache(client, "bot:"), server
+}
+
+func TestRedisCache_GetSetDelete(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Minute))
+
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// 键带有前缀
+	assert.True(t, server.Exists("bot:key"))
+
+	require.NoError(t, c.Delete(ctx, "key"))
+	_, err = c.Get(ctx, "key")
+	assert.True(t, IsCacheMiss(err))
+
+	// 删除不存在的键不报错
+	assert.NoError(t, c.Delete(ctx, "missing"))
+}
+
+func TestRedisCache_MissTranslatesRedisNil(t *testing.T) {
+	c, _ := newTestRedisCache(t)
+
+	_, err := c.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrCacheMiss)
+	assert.NotErrorIs(t, err, redis.Nil)
+}
+
+func TestRedisCache_TTL(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Minute))
+	assert.Equal(t, time.Minute, server.TTL("bot:key"))
+
+	server.FastForward(2 * time.Minute)
+	_, err := c.Get(ctx, "key")
+	assert.True(t, IsCacheMiss(err))
+}
+
+func TestRedisCache_SetNX(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	ctx := context.Background()
+
+	stored, err := c.SetNX(ctx, "key", []byte("first"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+	assert.Equal(t, time.Minute, server.TTL("bot:key"))
+
+	stored, err = c.SetNX(ctx, "key", []byte("second"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+}
+
+func TestRedisCache_ServerError(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	server.Close()
+
+	_, err := c.Get(context.Background(), "key")
+	assert.Error(t, err)
+	assert.False(t, IsCacheMiss(err))
+}
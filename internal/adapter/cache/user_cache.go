@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"telegram-bot/internal/domain/user"
+	"time"
+)
+
+// UserCache 带缓存的用户仓储
+// 包装 user.Repository：FindByID 优先读取缓存，写操作后删除缓存。
+// 权限中间件每条命令都会读取用户，缓存可减少数据库查询。
+// 其他实例写入后由 mongodb.ChangeWatcher 删除本实例的缓存，不支持 change stream 时最多过时 ttl
+type UserCache struct {
+	user.Repository
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewUserCache 创建带缓存的用户仓储
+func NewUserCache(repo user.Repository, cache Cache, ttl time.Duration) *UserCache {
+	return &UserCache{
+		Repository: repo,
+		cache:      cache,
+		ttl:        ttl,
+	}
+}
+
+// userCacheKey 用户缓存键
+func userCacheKey(id int64) string {
+	return "user:" + strconv.FormatInt(id, 10)
+}
+
+// FindByID 根据ID查找用户
+// 缓存读写失败不影响结果，直接回退到仓储
+func (c *UserCache) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	key := userCacheKey(id)
+
+	if data, err := c.cache.Get(ctx, key); err == nil {
+		var u user.User
+		if err := json.Unmarshal(data, &u); err == nil {
+			return &u, nil
+		}
+	}
+
+	u, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(u); err == nil {
+		_ = c.cache.Set(ctx, key, data, c.ttl)
+	}
+	return u, nil
+}
+
+// Save 保存用户并删除缓存
+func (c *UserCache) Save(ctx context.Context, u *user.User) error {
+	if err := c.Repository.Save(ctx, u); err != nil {
+		return err
+	}
+	c.Invalidate(ctx, u.ID)
+	return nil
+}
+
+// Update 更新用户并删除缓存
+func (c *UserCache) Update(ctx context.Context, u *user.User) error {
+	if err := c.Repository.Update(ctx, u); err != nil {
+		return err
+	}
+	c.Invalidate(ctx, u.ID)
+	return nil
+}
+
+// UpdatePermission 更新用户在群组的权限并删除缓存
+func (c *UserCache) UpdatePermission(ctx context.Context, userID int64, groupID int64, perm user.Permission) error {
+	if err := c.Repository.UpdatePermission(ctx, userID, groupID, perm); err != nil {
+		return err
+	}
+	c.Invalidate(ctx, userID)
+	return nil
+}
+
+// RecordSeen 记录用户最近活跃并删除缓存（用户名和显示名可能已变化）
+func (c *UserCache) RecordSeen(ctx context.Context, u *user.User) error {
+	if err := c.Repository.RecordSeen(ctx, u); err != nil {
+		return err
+	}
+	c.Invalidate(ctx, u.ID)
+	return nil
+}
+
+// Delete 删除用户并删除缓存
+func (c *UserCache) Delete(ctx context.Context, id int64) error {
+	if err := c.Repository.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.Invalidate(ctx, id)
+	return nil
+}
+
+// Invalidate 删除用户缓存，也用于其他实例修改用户后使本实例的缓存失效（见 mongodb.ChangeWatcher）
+// 数据库已写入成功，删除失败时旧数据最多保留 ttl，不视为操作失败
+func (c *UserCache) Invalidate(ctx context.Context, id int64) {
+	_ = c.cache.Delete(ctx, userCacheKey(id))
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserCache_FindByIDUsesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockUserRepository(ctrl)
+	ctx := context.Background()
+
+	u := user.NewUser(42, "alice", "Alice", "")
+	u.SetPermission(-100, user.PermissionAdmin)
+
+	// 仓储只查询一次
+	repo.EXPECT().FindByID(gomock.Any(), int64(42)).Return(u, nil).Times(1)
+
+	userCache := NewUserCache(repo, NewMemoryCache(0), time.Minute)
+
+	_, err := userCache.FindByID(ctx, 42)
+	require.NoError(t, err)
+	cached, err := userCache.FindByID(ctx, 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", cached.Username)
+	assert.True(t, cached.IsAdmin(-100))
+}
+
+func TestUserCache_WritesInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockUserRepository(ctrl)
+	ctx := context.Background()
+
+	u := user.NewUser(42, "alice", "Alice", "")
+	repo.EXPECT().FindByID(gomock.Any(), int64(42)).Return(u, nil).Times(3)
+	repo.EXPECT().UpdatePermission(gomock.Any(), int64(42), int64(-100), user.PermissionAdmin).Return(nil)
+	repo.EXPECT().RecordSeen(gomock.Any(), u).Return(nil)
+
+	userCache := NewUserCache(repo, NewMemoryCache(0), time.Minute)
+
+	_, err := userCache.FindByID(ctx, 42)
+	require.NoError(t, err)
+
+	// 每次写入后重新从仓储读取
+	require.NoError(t, userCache.UpdatePermission(ctx, 42, -100, user.PermissionAdmin))
+	_, err = userCache.FindByID(ctx, 42)
+	require.NoError(t, err)
+
+	require.NoError(t, userCache.RecordSeen(ctx, u))
+	_, err = userCache.FindByID(ctx, 42)
+	require.NoError(t, err)
+}
@@ -0,0 +1,220 @@
+package mongodb
+
+import (
+	"context"
+	"telegram-bot/internal/domain/activity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ActivityRepository MongoDB 消息计数仓储实现
+// 用户累计消息数保存在 message_counts，群组按天统计保存在 daily_stats，
+// 命令使用次数保存在 command_usage
+type ActivityRepository struct {
+	collection        *mongo.Collection
+	dailyCollection   *mongo.Collection
+	commandCollection *mongo.Collection
+	timeout           time.Duration
+}
+
+// NewActivityRepository 创建 MongoDB 消息计数仓储
+func NewActivityRepository(db *mongo.Database) *ActivityRepository {
+	return &ActivityRepository{
+		collection:        db.Collection("message_counts"),
+		dailyCollection:   db.Collection("daily_stats"),
+		commandCollection: db.Collection("command_usage"),
+		timeout:           10 * time.Second,
+	}
+}
+
+// dailyStatsDocument 按天统计文档结构
+type dailyStatsDocument struct {
+	GroupID           int64  `bson:"group_id"`
+	Day               string `bson:"day"`
+	Messages          int64  `bson:"messages"`
+	Commands          int64  `bson:"commands"`
+	ModerationActions int64  `bson:"moderation_actions"`
+}
+
+// toBucket 将文档转换为领域对象
+func (d *dailyStatsDocument) toBucket() *activity.Bucket {
+	return &activity.Bucket{
+		GroupID:           d.GroupID,
+		Day:               d.Day,
+		Messages:          d.Messages,
+		Commands:          d.Commands,
+		ModerationActions: d.ModerationActions,
+	}
+}
+
+// IncrementMessageCounts 批量累加消息数（按群组、用户 upsert）
+func (r *ActivityRepository) IncrementMessageCounts(ctx context.Context, counts map[activity.Key]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.collection.BulkWrite(ctx, r.incrementModels(counts, time.Now()), options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// incrementModels 构建批量累加的写操作
+func (r *ActivityRepository) incrementModels(counts map[activity.Key]int64, now time.Time) []mongo.WriteModel {
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for key, count := range counts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"group_id": key.GroupID, "user_id": key.UserID}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"message_count": count},
+				"$set": bson.M{"updated_at": now},
+			}).
+			SetUpsert(true))
+	}
+	return models
+}
+
+// IncrementBuckets 批量累加按天统计（按群组、日期 upsert，指标名即字段名）
+func (r *ActivityRepository) IncrementBuckets(ctx context.Context, counts map[activity.BucketKey]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.dailyCollection.BulkWrite(ctx, r.bucketModels(counts), options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// bucketModels 构建按天统计的写操作
+func (r *ActivityRepository) bucketModels(counts map[activity.BucketKey]int64) []mongo.WriteModel {
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for key, count := range counts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"group_id": key.GroupID, "day": key.Day}).
+			SetUpdate(bson.M{"$inc": bson.M{string(key.Metric): count}}).
+			SetUpsert(true))
+	}
+	return models
+}
+
+// FindBuckets 查询群组在日期范围内的按天统计
+func (r *ActivityRepository) FindBuckets(ctx context.Context, groupID int64, from, to string) ([]*activity.Bucket, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// 日期格式为 YYYY-MM-DD，字符串比较即日期比较
+	filter := bson.M{
+		"group_id": groupID,
+		"day":      bson.M{"$gte": from, "$lte": to},
+	}
+	cursor, err := r.dailyCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "day", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []*activity.Bucket
+	for cursor.Next(ctx) {
+		var doc dailyStatsDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, doc.toBucket())
+	}
+
+	return buckets, cursor.Err()
+}
+
+// TopGroupsByMessages 汇总日期范围内各群组的消息数，返回最多的 n 个群组
+func (r *ActivityRepository) TopGroupsByMessages(ctx context.Context, from, to string, n int) ([]*activity.GroupMessages, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": bson.M{"$gte": from, "$lte": to}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$group_id", "messages": bson.M{"$sum": "$messages"}}}},
+		{{Key: "$match", Value: bson.M{"messages": bson.M{"$gt": 0}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "messages", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: n}},
+	}
+	cursor, err := r.dailyCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var top []*activity.GroupMessages
+	for cursor.Next(ctx) {
+		var doc struct {
+			GroupID  int64 `bson:"_id"`
+			Messages int64 `bson:"messages"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		top = append(top, &activity.GroupMessages{GroupID: doc.GroupID, Messages: doc.Messages})
+	}
+
+	return top, cursor.Err()
+}
+
+// commandUsageDocument 命令使用次数文档结构
+type commandUsageDocument struct {
+	GroupID int64  `bson:"group_id"`
+	Command string `bson:"command"`
+	Count   int64  `bson:"count"`
+}
+
+// IncrementCommandUsage 批量累加命令使用次数（按群组、命令 upsert）
+func (r *ActivityRepository) IncrementCommandUsage(ctx context.Context, counts map[activity.CommandKey]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.commandCollection.BulkWrite(ctx, r.commandModels(counts), options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// commandModels 构建命令使用次数的写操作
+func (r *ActivityRepository) commandModels(counts map[activity.CommandKey]int64) []mongo.WriteModel {
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for key, count := range counts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"group_id": key.GroupID, "command": key.Command}).
+			SetUpdate(bson.M{"$inc": bson.M{"count": count}}).
+			SetUpsert(true))
+	}
+	return models
+}
+
+// FindCommandUsage 查询群组内所有命令的使用次数
+func (r *ActivityRepository) FindCommandUsage(ctx context.Context, groupID int64) ([]*activity.CommandUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cursor, err := r.commandCollection.Find(ctx, bson.M{"group_id": groupID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var usage []*activity.CommandUsage
+	for cursor.Next(ctx) {
+		var doc commandUsageDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		usage = append(usage, &activity.CommandUsage{Command: doc.Command, Count: doc.Count})
+	}
+
+	return usage, cursor.Err()
+}
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"telegram-bot/internal/domain/activity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestActivityRepository_IncrementModels(t *testing.T) {
+	repo := &ActivityRepository{}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	models := repo.incrementModels(map[activity.Key]int64{
+		{GroupID: -100, UserID: 1}: 3,
+	}, now)
+	require.Len(t, models, 1)
+
+	model, ok := models[0].(*mongo.UpdateOneModel)
+	require.True(t, ok)
+	assert.Equal(t, bson.M{"group_id": int64(-100), "user_id": int64(1)}, model.Filter)
+	assert.Equal(t, bson.M{
+		"$inc": bson.M{"message_count": int64(3)},
+		"$set": bson.M{"updated_at": now},
+	}, model.Update)
+	require.NotNil(t, model.Upsert)
+	assert.True(t, *model.Upsert)
+}
+
+func TestActivityRepository_BucketModels(t *testing.T) {
+	repo := &ActivityRepository{}
+
+	models := repo.bucketModels(map[activity.BucketKey]int64{
+		{GroupID: -100, Day: "2025-01-02", Metric: activity.MetricCommands}: 4,
+	})
+	require.Len(t, models, 1)
+
+	model, ok := models[0].(*mongo.UpdateOneModel)
+	require.True(t, ok)
+	assert.Equal(t, bson.M{"group_id": int64(-100), "day": "2025-01-02"}, model.Filter)
+	assert.Equal(t, bson.M{"$inc": bson.M{"commands": int64(4)}}, model.Update)
+}
+
+func TestDailyStatsDocument_ToBucket(t *testing.T) {
+	doc := &dailyStatsDocument{GroupID: -100, Day: "2025-01-02", Messages: 10, Commands: 2, ModerationActions: 1}
+
+	assert.Equal(t, &activity.Bucket{
+		GroupID:           -100,
+		Day:               "2025-01-02",
+		Messages:          10,
+		Commands:          2,
+		ModerationActions: 1,
+	}, doc.toBucket())
+}
+
+func TestActivityRepository_CommandModels(t *testing.T) {
+	repo := &ActivityRepository{}
+
+	models := repo.commandModels(map[activity.CommandKey]int64{
+		{GroupID: -100, Command: "ping"}: 5,
+	})
+	require.Len(t, models, 1)
+
+	model, ok := models[0].(*mongo.UpdateOneModel)
+	require.True(t, ok)
+	assert.Equal(t, bson.M{"group_id": int64(-100), "command": "ping"}, model.Filter)
+	assert.Equal(t, bson.M{"$inc": bson.M{"count": int64(5)}}, model.Update)
+	require.NotNil(t, model.Upsert)
+	assert.True(t, *model.Upsert)
+}
@@ -0,0 +1,161 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"telegram-bot/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// codeChangeStreamUnsupported 独立部署（非副本集）的 MongoDB 不支持 change stream 时返回的错误码
+	codeChangeStreamUnsupported = 40573
+	// codeUnauthorized 数据库用户没有 changeStream 权限时返回的错误码
+	codeUnauthorized = 13
+)
+
+// CacheInvalidator 按 ID 删除缓存接口（cache.GroupCache 实现）
+type CacheInvalidator interface {
+	Invalidate(ctx context.Context, id int64)
+}
+
+// changeStream change stream 游标（*mongo.ChangeStream 实现）
+type changeStream interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// changeEvent change stream 事件中用到的字段
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID int64 `bson:"_id"`
+	} `bson:"documentKey"`
+}
+
+// ChangeWatcher 监听集合的修改和删除，删除对应的缓存
+// 多实例部署时，其他实例写入后本实例的缓存立即失效，不必等待 TTL 过期。
+// change stream 中断时按指数退避重新打开；MongoDB 不支持 change stream（独立部署）
+// 或没有权限等不可重试的错误时记录日志并退出，缓存只依赖 TTL 和读修复
+type ChangeWatcher struct {
+	name          string
+	open          func(ctx context.Context) (changeStream, error)
+	invalidator   CacheInvalidator
+	logger        logger.Logger
+	retryDelay    time.Duration // 首次重新打开前的等待时间
+	maxRetryDelay time.Duration // 指数退避的最大等待时间
+	sleep         func(ctx context.Context, d time.Duration) error
+}
+
+// NewGroupChangeWatcher 创建群组集合的监听器
+func NewGroupChangeWatcher(db *mongo.Database, invalidator CacheInvalidator, log logger.Logger) *ChangeWatcher {
+	return newChangeWatcher(db.Collection("groups"), invalidator, log)
+}
+
+// NewUserChangeWatcher 创建用户集合的监听器
+func NewUserChangeWatcher(db *mongo.Database, invalidator CacheInvalidator, log logger.Logger) *ChangeWatcher {
+	return newChangeWatcher(db.Collection("users"), invalidator, log)
+}
+
+// newChangeWatcher 创建集合监听器，只接收修改、替换和删除事件
+func newChangeWatcher(collection *mongo.Collection, invalidator CacheInvalidator, log logger.Logger) *ChangeWatcher {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{"update", "replace", "delete"}}}}},
+	}
+	return &ChangeWatcher{
+		name: collection.Name(),
+		open: func(ctx context.Context) (changeStream, error) {
+			return collection.Watch(ctx, pipeline)
+		},
+		invalidator:   invalidator,
+		logger:        log,
+		retryDelay:    time.Second,
+		maxRetryDelay: 5 * time.Minute,
+		sleep:         sleepContext,
+	}
+}
+
+// Run 持续监听直到 ctx 结束；change stream 中断时按指数退避重新打开，成功打开后重置等待时间
+func (w *ChangeWatcher) Run(ctx context.Context) {
+	delay := w.retryDelay
+	for {
+		opened, err := w.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if isChangeStreamUnsupported(err) {
+			w.logger.Warn("Change streams not supported, cache relies on TTL only", "collection", w.name)
+			return
+		}
+		if isChangeStreamFatal(err) {
+			w.logger.Error("Change stream failed with non-retryable error, cache relies on TTL only", "collection", w.name, "error", err)
+			return
+		}
+
+		if opened {
+			delay = w.retryDelay
+		}
+		w.logger.Warn("Change stream interrupted, reopening", "collection", w.name, "retry_in", delay, "error", err)
+		if w.sleep(ctx, delay) != nil {
+			return
+		}
+
+		delay *= 2
+		if delay > w.maxRetryDelay {
+			delay = w.maxRetryDelay
+		}
+	}
+}
+
+// watch 打开 change stream 并处理事件，直到出错或 ctx 结束；opened 表示 change stream 是否成功打开
+func (w *ChangeWatcher) watch(ctx context.Context) (opened bool, err error) {
+	stream, err := w.open(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer stream.Close(context.Background())
+
+	w.logger.Info("Watching collection changes", "collection", w.name)
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			w.logger.Warn("Failed to decode change event", "collection", w.name, "error", err)
+			continue
+		}
+		w.handle(ctx, event)
+	}
+	return true, stream.Err()
+}
+
+// handle 修改、替换和删除事件删除对应 ID 的缓存
+func (w *ChangeWatcher) handle(ctx context.Context, event changeEvent) {
+	switch event.OperationType {
+	case "update", "replace", "delete":
+		w.invalidator.Invalidate(ctx, event.DocumentKey.ID)
+	}
+}
+
+// isChangeStreamUnsupported 判断错误是否表示 MongoDB 不支持 change stream
+func isChangeStreamUnsupported(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(codeChangeStreamUnsupported)
+}
+
+// isChangeStreamFatal 判断错误是否重试也不会恢复（权限不足、客户端已断开等）
+// 网络错误和其他服务端错误（如主节点切换）视为可重试
+func isChangeStreamFatal(err error) bool {
+	if errors.Is(err, mongo.ErrClientDisconnected) {
+		return true
+	}
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) {
+		return false
+	}
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(codeUnauthorized)
+}
@@ -0,0 +1,163 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeChangeStream 依次返回预设的事件
+type fakeChangeStream struct {
+	events []bson.M
+	err    error
+	closed bool
+}
+
+func (s *fakeChangeStream) Next(ctx context.Context) bool {
+	return len(s.events) > 0
+}
+
+func (s *fakeChangeStream) Decode(val interface{}) error {
+	data, err := bson.Marshal(s.events[0])
+	s.events = s.events[1:]
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, val)
+}
+
+func (s *fakeChangeStream) Err() error {
+	return s.err
+}
+
+func (s *fakeChangeStream) Close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+// recordingInvalidator 记录被删除缓存的 ID
+type recordingInvalidator struct {
+	ids []int64
+}
+
+func (r *recordingInvalidator) Invalidate(ctx context.Context, id int64) {
+	r.ids = append(r.ids, id)
+}
+
+func newTestChangeWatcher(invalidator CacheInvalidator, streams ...*fakeChangeStream) *ChangeWatcher {
+	w := newOpenFuncWatcher(invalidator, func() (changeStream, error) {
+		if len(streams) == 0 {
+			return nil, mongo.CommandError{Code: codeChangeStreamUnsupported, Message: "only supported on replica sets"}
+		}
+		stream := streams[0]
+		streams = streams[1:]
+		return stream, nil
+	})
+	return w
+}
+
+func newOpenFuncWatcher(invalidator CacheInvalidator, open func() (changeStream, error)) *ChangeWatcher {
+	return &ChangeWatcher{
+		name: "groups",
+		open: func(ctx context.Context) (changeStream, error) {
+			return open()
+		},
+		invalidator:   invalidator,
+		logger:        logger.NewWithLevel(logger.LevelError),
+		retryDelay:    time.Millisecond,
+		maxRetryDelay: 4 * time.Millisecond,
+		sleep:         sleepContext,
+	}
+}
+
+// runWithTimeout 运行 Run 并等待其退出
+func runWithTimeout(t *testing.T, w *ChangeWatcher) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		w.Run(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return")
+	}
+}
+
+func TestChangeWatcher_InvalidatesChangedDocuments(t *testing.T) {
+	stream := &fakeChangeStream{events: []bson.M{
+		{"operationType": "update", "documentKey": bson.M{"_id": int64(-100)}},
+		{"operationType": "delete", "documentKey": bson.M{"_id": int64(-200)}},
+		{"operationType": "replace", "documentKey": bson.M{"_id": int64(-300)}},
+	}}
+	invalidator := &recordingInvalidator{}
+	w := newTestChangeWatcher(invalidator, stream)
+
+	opened, err := w.watch(context.Background())
+	require.NoError(t, err)
+	assert.True(t, opened)
+	assert.Equal(t, []int64{-100, -200, -300}, invalidator.ids)
+	assert.True(t, stream.closed)
+}
+
+func TestChangeWatcher_ReopensAfterErrorAndStopsWhenUnsupported(t *testing.T) {
+	interrupted := &fakeChangeStream{
+		events: []bson.M{{"operationType": "update", "documentKey": bson.M{"_id": int64(-100)}}},
+		err:    errors.New("connection reset"),
+	}
+	resumed := &fakeChangeStream{
+		events: []bson.M{{"operationType": "update", "documentKey": bson.M{"_id": int64(-200)}}},
+		err:    errors.New("connection reset"),
+	}
+	invalidator := &recordingInvalidator{}
+	w := newTestChangeWatcher(invalidator, interrupted, resumed)
+
+	// 第三次打开时返回不支持，Run 退出，缓存只依赖 TTL
+	runWithTimeout(t, w)
+	assert.Equal(t, []int64{-100, -200}, invalidator.ids)
+}
+
+func TestChangeWatcher_BacksOffAndStopsOnFatalError(t *testing.T) {
+	// 连续 4 次打开失败后返回权限错误
+	attempts := 0
+	w := newOpenFuncWatcher(&recordingInvalidator{}, func() (changeStream, error) {
+		attempts++
+		if attempts > 4 {
+			return nil, mongo.CommandError{Code: codeUnauthorized, Message: "not authorized"}
+		}
+		return nil, errors.New("server selection error")
+	})
+	var delays []time.Duration
+	w.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	runWithTimeout(t, w)
+	assert.Equal(t, 5, attempts)
+	// 指数退避，不超过上限
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}, delays)
+}
+
+func TestIsChangeStreamFatal(t *testing.T) {
+	assert.True(t, isChangeStreamFatal(mongo.CommandError{Code: codeUnauthorized}))
+	assert.True(t, isChangeStreamFatal(mongo.ErrClientDisconnected))
+	assert.False(t, isChangeStreamFatal(mongo.CommandError{Code: 10107, Message: "not primary"}))
+	assert.False(t, isChangeStreamFatal(errors.New("connection reset")))
+}
+
+func TestIsChangeStreamUnsupported(t *testing.T) {
+	assert.True(t, isChangeStreamUnsupported(mongo.CommandError{Code: codeChangeStreamUnsupported}))
+	assert.False(t, isChangeStreamUnsupported(mongo.CommandError{Code: 11000}))
+	assert.False(t, isChangeStreamUnsupported(errors.New("connection reset")))
+}
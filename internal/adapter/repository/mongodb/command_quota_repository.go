@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CommandQuotaRepository MongoDB 命令每日配额计数实现
+// 每个 (群组, 命令, 日期) 一条计数，过期后由 TTL 索引删除
+type CommandQuotaRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewCommandQuotaRepository 创建 MongoDB 命令每日配额计数仓储
+func NewCommandQuotaRepository(db *mongo.Database) *CommandQuotaRepository {
+	return &CommandQuotaRepository{
+		collection: db.Collection("command_quotas"),
+		timeout:    10 * time.Second,
+	}
+}
+
+// Add 累加群组命令当天的使用次数并返回累加后的值
+// 当天第一次使用时创建计数，expireAt 之后计数被删除
+func (r *CommandQuotaRepository) Add(ctx context.Context, groupID int64, command, day string, delta int64, expireAt time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"group_id": groupID, "command": command, "day": day}
+	update := bson.M{
+		"$inc":         bson.M{"count": delta},
+		"$setOnInsert": bson.M{"expire_at": expireAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc struct {
+		Count int64 `bson:"count"`
+	}
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc); err != nil {
+		return 0, err
+	}
+	return doc.Count, nil
+}
@@ -0,0 +1,100 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-bot/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConnectConfig 初始连接的重试配置
+type ConnectConfig struct {
+	MaxAttempts    int           // 最大尝试次数（包含首次）
+	AttemptTimeout time.Duration // 单次连接和 Ping 的超时
+	TotalTimeout   time.Duration // 所有尝试（含等待）的总时长上限，0 表示不限制
+	InitialDelay   time.Duration // 首次重试前的等待时间
+	MaxDelay       time.Duration // 指数退避的最大等待时间
+}
+
+// Connect 连接 MongoDB 并用 Ping 验证连接，失败时按指数退避重试
+// 启动时 MongoDB 短暂不可用（例如与机器人同时启动的容器）不会导致进程直接退出
+func Connect(ctx context.Context, clientOpts *options.ClientOptions, cfg ConnectConfig, log logger.Logger) (*mongo.Client, error) {
+	return connectWithRetry(ctx, cfg, log, sleepContext, func(ctx context.Context) (*mongo.Client, error) {
+		client, err := mongo.Connect(ctx, clientOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.Ping(ctx, nil); err != nil {
+			_ = client.Disconnect(context.Background())
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+// connectWithRetry 重试 connect 直到成功、用尽次数或超过总时长
+func connectWithRetry(
+	ctx context.Context,
+	cfg ConnectConfig,
+	log logger.Logger,
+	sleep func(ctx context.Context, d time.Duration) error,
+	connect func(ctx context.Context) (*mongo.Client, error),
+) (*mongo.Client, error) {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.TotalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.TotalTimeout)
+		defer cancel()
+	}
+
+	delay := cfg.InitialDelay
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.AttemptTimeout)
+		client, err := connect(attemptCtx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				log.Info("MongoDB connected after retry", "attempt", attempt)
+			}
+			return client, nil
+		}
+
+		if attempt >= cfg.MaxAttempts {
+			return nil, fmt.Errorf("connect to MongoDB failed after %d attempts: %w", attempt, err)
+		}
+		log.Warn("MongoDB connect failed, retrying",
+			"attempt", attempt,
+			"max_attempts", cfg.MaxAttempts,
+			"retry_in", delay,
+			"error", err,
+		)
+
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return nil, fmt.Errorf("connect to MongoDB timed out after %d attempts: %w", attempt, err)
+		}
+
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+}
+
+// sleepContext 等待 d，ctx 结束时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
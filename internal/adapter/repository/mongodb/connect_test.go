@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// flakyConnector 前 failures 次连接失败，之后成功
+type flakyConnector struct {
+	failures int
+	calls    int
+}
+
+func (c *flakyConnector) connect(ctx context.Context) (*mongo.Client, error) {
+	c.calls++
+	if c.calls <= c.failures {
+		return nil, errors.New("server selection timeout")
+	}
+	return &mongo.Client{}, nil
+}
+
+func TestConnectWithRetry(t *testing.T) {
+	cfg := ConnectConfig{
+		MaxAttempts:    5,
+		AttemptTimeout: time.Second,
+		InitialDelay:   time.Second,
+		MaxDelay:       3 * time.Second,
+	}
+	log := logger.NewWithLevel(logger.LevelError)
+
+	t.Run("succeeds after failures with exponential backoff", func(t *testing.T) {
+		var waits []time.Duration
+		sleep := func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}
+		connector := &flakyConnector{failures: 3}
+
+		client, err := connectWithRetry(context.Background(), cfg, log, sleep, connector.connect)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+		assert.Equal(t, 4, connector.calls)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, waits)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		sleep := func(ctx context.Context, d time.Duration) error { return nil }
+		connector := &flakyConnector{failures: 10}
+
+		_, err := connectWithRetry(context.Background(), cfg, log, sleep, connector.connect)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 5 attempts")
+		assert.Equal(t, 5, connector.calls)
+	})
+
+	t.Run("stops when total timeout expires", func(t *testing.T) {
+		timeoutCfg := cfg
+		timeoutCfg.TotalTimeout = time.Millisecond
+		connector := &flakyConnector{failures: 10}
+
+		_, err := connectWithRetry(context.Background(), timeoutCfg, log, sleepContext, connector.connect)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out after 1 attempts")
+		assert.Equal(t, 1, connector.calls)
+	})
+}
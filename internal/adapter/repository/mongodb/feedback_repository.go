@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"context"
+	"telegram-bot/internal/domain/feedback"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FeedbackRepository MongoDB 用户反馈仓储实现
+type FeedbackRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewFeedbackRepository 创建 MongoDB 用户反馈仓储
+func NewFeedbackRepository(db *mongo.Database) *FeedbackRepository {
+	return &FeedbackRepository{
+		collection: db.Collection("feedback"),
+		timeout:    10 * time.Second,
+	}
+}
+
+// feedbackDocument MongoDB 文档结构
+type feedbackDocument struct {
+	UserID    int64     `bson:"user_id"`
+	Username  string    `bson:"username,omitempty"`
+	GroupID   int64     `bson:"group_id"`
+	Text      string    `bson:"text"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// toDocument 将领域对象转换为文档
+func (r *FeedbackRepository) toDocument(f *feedback.Feedback) *feedbackDocument {
+	return &feedbackDocument{
+		UserID:    f.UserID,
+		Username:  f.Username,
+		GroupID:   f.GroupID,
+		Text:      f.Text,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// toDomain 将文档转换为领域对象
+func (r *FeedbackRepository) toDomain(doc *feedbackDocument) *feedback.Feedback {
+	return &feedback.Feedback{
+		UserID:    doc.UserID,
+		Username:  doc.Username,
+		GroupID:   doc.GroupID,
+		Text:      doc.Text,
+		CreatedAt: doc.CreatedAt,
+	}
+}
+
+// Create 保存反馈
+func (r *FeedbackRepository) Create(ctx context.Context, f *feedback.Feedback) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.collection.InsertOne(ctx, r.toDocument(f))
+	return err
+}
+
+// ListRecent 按时间倒序返回反馈，groupID 为 0 时返回所有群组和私聊的反馈
+// before 非零时只返回早于 before 的记录，用于分页
+func (r *FeedbackRepository) ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*feedback.Feedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter, opts := recentQuery(groupID, limit, before)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []*feedback.Feedback
+	for cursor.Next(ctx) {
+		var doc feedbackDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		items = append(items, r.toDomain(&doc))
+	}
+
+	return items, cursor.Err()
+}
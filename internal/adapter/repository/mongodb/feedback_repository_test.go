@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"telegram-bot/internal/domain/feedback"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackRepository_DocumentConversion(t *testing.T) {
+	repo := &FeedbackRepository{}
+
+	tests := []struct {
+		name     string
+		original *feedback.Feedback
+	}{
+		{
+			name: "from group",
+			original: &feedback.Feedback{
+				UserID:    123,
+				Username:  "alice",
+				GroupID:   -100,
+				Text:      "/stats 很慢",
+				CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "from private chat without username",
+			original: &feedback.Feedback{
+				UserID:    456,
+				Text:      "建议增加英文帮助",
+				CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := repo.toDocument(tt.original)
+			assert.Equal(t, tt.original.GroupID, doc.GroupID)
+			assert.Equal(t, tt.original, repo.toDomain(doc))
+		})
+	}
+}
@@ -136,6 +136,37 @@ func (r *GroupRepository) Update(ctx context.Context, g *group.Group) error {
 	return nil
 }
 
+// UnsetCommands 删除群组中指定命令的配置
+// 只 $unset 对应的 commands.<name> 字段，不会覆盖其他实例同时写入的配置
+func (r *GroupRepository) UnsetCommands(ctx context.Context, id int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	unset := bson.M{}
+	for _, name := range names {
+		unset["commands."+name] = ""
+	}
+	update := bson.M{
+		"$unset": unset,
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return group.ErrGroupNotFound
+	}
+
+	return nil
+}
+
 // Delete 删除群组
 func (r *GroupRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
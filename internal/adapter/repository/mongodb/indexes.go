@@ -34,6 +34,30 @@ func (im *IndexManager) EnsureIndexes(ctx context.Context) error {
 		return err
 	}
 
+	if err := im.ensureScheduledMessageIndexes(ctx); err != nil {
+		return err
+	}
+
+	if err := im.ensureRestrictionIndexes(ctx); err != nil {
+		return err
+	}
+
+	if err := im.ensureMessageCountIndexes(ctx); err != nil {
+		return err
+	}
+
+	if err := im.ensurePermissionChangeIndexes(ctx); err != nil {
+		return err
+	}
+
+	if err := im.ensureFeedbackIndexes(ctx); err != nil {
+		return err
+	}
+
+	if err := im.ensureCommandQuotaIndexes(ctx); err != nil {
+		return err
+	}
+
 	im.logger.Info("All indexes created successfully")
 	return nil
 }
@@ -65,6 +89,12 @@ func (im *IndexManager) ensureUserIndexes(ctx context.Context) error {
 			Options: options.Index().
 				SetName("idx_created_at"),
 		},
+		{
+			// 最近活跃时间索引（用于 /inactive 查询）
+			Keys: bson.D{{Key: "last_seen_at", Value: 1}},
+			Options: options.Index().
+				SetName("idx_last_seen_at"),
+		},
 	}
 
 	return im.createIndexes(ctx, collection, indexes, "users")
@@ -106,6 +136,204 @@ func (im *IndexManager) ensureGroupIndexes(ctx context.Context) error {
 	return im.createIndexes(ctx, collection, indexes, "groups")
 }
 
+// ensureScheduledMessageIndexes 创建定时消息集合索引
+func (im *IndexManager) ensureScheduledMessageIndexes(ctx context.Context) error {
+	collection := im.db.Collection("scheduled_messages")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 下次发送时间索引（用于查询到期消息）
+			Keys: bson.D{{Key: "next_run_at", Value: 1}},
+			Options: options.Index().
+				SetName("idx_next_run_at"),
+		},
+		{
+			// 群组索引（用于 /schedule list）
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_created_at"),
+		},
+	}
+
+	return im.createIndexes(ctx, collection, indexes, "scheduled_messages")
+}
+
+// ensureRestrictionIndexes 创建限制记录集合索引
+func (im *IndexManager) ensureRestrictionIndexes(ctx context.Context) error {
+	collection := im.db.Collection("restrictions")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 唯一索引：同一群组、用户、类型只保留一条记录
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "kind", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_user_kind").
+				SetUnique(true),
+		},
+		{
+			// 到期时间索引（用于查询到期记录）
+			Keys: bson.D{
+				{Key: "kind", Value: 1},
+				{Key: "until", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_kind_until"),
+		},
+	}
+
+	return im.createIndexes(ctx, collection, indexes, "restrictions")
+}
+
+// ensureMessageCountIndexes 创建消息计数、按天统计和命令使用次数集合索引
+func (im *IndexManager) ensureMessageCountIndexes(ctx context.Context) error {
+	collection := im.db.Collection("message_counts")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 唯一索引：同一群组、用户只保留一条计数
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "user_id", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_user").
+				SetUnique(true),
+		},
+	}
+
+	if err := im.createIndexes(ctx, collection, indexes, "message_counts"); err != nil {
+		return err
+	}
+
+	daily := im.db.Collection("daily_stats")
+	dailyIndexes := []mongo.IndexModel{
+		{
+			// 唯一索引：同一群组每天一条统计（同时用于按日期范围查询）
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_day").
+				SetUnique(true),
+		},
+		{
+			// 按日期范围汇总所有群组（/gstats 最活跃群组）
+			Keys:    bson.D{{Key: "day", Value: 1}},
+			Options: options.Index().SetName("idx_day"),
+		},
+	}
+
+	if err := im.createIndexes(ctx, daily, dailyIndexes, "daily_stats"); err != nil {
+		return err
+	}
+
+	commands := im.db.Collection("command_usage")
+	commandIndexes := []mongo.IndexModel{
+		{
+			// 唯一索引：同一群组每个命令一条计数
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "command", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_command").
+				SetUnique(true),
+		},
+	}
+
+	return im.createIndexes(ctx, commands, commandIndexes, "command_usage")
+}
+
+// ensurePermissionChangeIndexes 创建权限变更记录集合索引
+func (im *IndexManager) ensurePermissionChangeIndexes(ctx context.Context) error {
+	collection := im.db.Collection("permission_changes")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 按群组、用户查询最近的变更
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_group_user_created"),
+		},
+		{
+			// 按群组分页列出最近的变更
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_group_created"),
+		},
+	}
+
+	return im.createIndexes(ctx, collection, indexes, "permission_changes")
+}
+
+// ensureFeedbackIndexes 创建用户反馈集合索引
+func (im *IndexManager) ensureFeedbackIndexes(ctx context.Context) error {
+	collection := im.db.Collection("feedback")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 按时间倒序列出最近的反馈
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+			Options: options.Index().
+				SetName("idx_created"),
+		},
+		{
+			// 按群组分页列出反馈
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_group_created"),
+		},
+	}
+
+	return im.createIndexes(ctx, collection, indexes, "feedback")
+}
+
+// ensureCommandQuotaIndexes 创建命令每日配额集合索引
+func (im *IndexManager) ensureCommandQuotaIndexes(ctx context.Context) error {
+	collection := im.db.Collection("command_quotas")
+
+	indexes := []mongo.IndexModel{
+		{
+			// 每个群组每个命令每天一条计数
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: "command", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().
+				SetName("idx_group_command_day").
+				SetUnique(true),
+		},
+		{
+			// 过期的计数自动删除
+			Keys: bson.D{{Key: "expire_at", Value: 1}},
+			Options: options.Index().
+				SetName("idx_expire_at").
+				SetExpireAfterSeconds(0),
+		},
+	}
+
+	return im.createIndexes(ctx, collection, indexes, "command_quotas")
+}
+
 // createIndexes 创建索引的辅助方法
 func (im *IndexManager) createIndexes(ctx context.Context, collection *mongo.Collection, indexes []mongo.IndexModel, collectionName string) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -125,7 +353,7 @@ func (im *IndexManager) createIndexes(ctx context.Context, collection *mongo.Col
 
 // DropAllIndexes 删除所有索引（用于重建）
 func (im *IndexManager) DropAllIndexes(ctx context.Context) error {
-	collections := []string{"users", "groups"}
+	collections := []string{"users", "groups", "scheduled_messages", "restrictions"}
 
 	for _, collName := range collections {
 		collection := im.db.Collection(collName)
@@ -147,7 +375,7 @@ func (im *IndexManager) DropAllIndexes(ctx context.Context) error {
 
 // ListIndexes 列出所有索引
 func (im *IndexManager) ListIndexes(ctx context.Context) (map[string][]string, error) {
-	collections := []string{"users", "groups"}
+	collections := []string{"users", "groups", "scheduled_messages", "restrictions"}
 	result := make(map[string][]string)
 
 	for _, collName := range collections {
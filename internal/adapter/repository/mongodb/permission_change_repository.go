@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"telegram-bot/internal/domain/user"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PermissionChangeRepository MongoDB 权限变更记录仓储实现
+type PermissionChangeRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewPermissionChangeRepository 创建 MongoDB 权限变更记录仓储
+func NewPermissionChangeRepository(db *mongo.Database) *PermissionChangeRepository {
+	return &PermissionChangeRepository{
+		collection: db.Collection("permission_changes"),
+		timeout:    10 * time.Second,
+	}
+}
+
+// permissionChangeDocument MongoDB 文档结构
+type permissionChangeDocument struct {
+	GroupID   int64     `bson:"group_id"`
+	UserID    int64     `bson:"user_id"`
+	OldPerm   int       `bson:"old_perm"`
+	NewPerm   int       `bson:"new_perm"`
+	ActorID   int64     `bson:"actor_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// toDocument 将领域对象转换为文档
+func (r *PermissionChangeRepository) toDocument(c *user.PermissionChange) *permissionChangeDocument {
+	return &permissionChangeDocument{
+		GroupID:   c.GroupID,
+		UserID:    c.UserID,
+		OldPerm:   int(c.OldPerm),
+		NewPerm:   int(c.NewPerm),
+		ActorID:   c.ActorID,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// toDomain 将文档转换为领域对象
+func (r *PermissionChangeRepository) toDomain(doc *permissionChangeDocument) *user.PermissionChange {
+	return &user.PermissionChange{
+		GroupID:   doc.GroupID,
+		UserID:    doc.UserID,
+		OldPerm:   user.Permission(doc.OldPerm),
+		NewPerm:   user.Permission(doc.NewPerm),
+		ActorID:   doc.ActorID,
+		CreatedAt: doc.CreatedAt,
+	}
+}
+
+// Append 追加权限变更记录
+func (r *PermissionChangeRepository) Append(ctx context.Context, c *user.PermissionChange) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.collection.InsertOne(ctx, r.toDocument(c))
+	return err
+}
+
+// FindByUser 按时间倒序返回用户在群组内最近的权限变更
+func (r *PermissionChangeRepository) FindByUser(ctx context.Context, groupID, userID int64, limit int) ([]*user.PermissionChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"group_id": groupID,
+		"user_id":  userID,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	return r.decodeAll(ctx, cursor)
+}
+
+// ListRecent 按时间倒序返回群组内的权限变更，before 非零时只返回早于 before 的记录
+func (r *PermissionChangeRepository) ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*user.PermissionChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter, opts := recentQuery(groupID, limit, before)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	return r.decodeAll(ctx, cursor)
+}
+
+// decodeAll 解码游标中的所有权限变更
+func (r *PermissionChangeRepository) decodeAll(ctx context.Context, cursor *mongo.Cursor) ([]*user.PermissionChange, error) {
+	var changes []*user.PermissionChange
+	for cursor.Next(ctx) {
+		var doc permissionChangeDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		changes = append(changes, r.toDomain(&doc))
+	}
+
+	return changes, cursor.Err()
+}
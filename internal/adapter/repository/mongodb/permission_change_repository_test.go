@@ -0,0 +1,27 @@
+package mongodb
+
+import (
+	"telegram-bot/internal/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionChangeRepository_DocumentConversion(t *testing.T) {
+	repo := &PermissionChangeRepository{}
+
+	original := &user.PermissionChange{
+		GroupID:   -100,
+		UserID:    456,
+		OldPerm:   user.PermissionUser,
+		NewPerm:   user.PermissionAdmin,
+		ActorID:   123,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	doc := repo.toDocument(original)
+	assert.Equal(t, 1, doc.OldPerm)
+	assert.Equal(t, 2, doc.NewPerm)
+	assert.Equal(t, original, repo.toDomain(doc))
+}
@@ -0,0 +1,27 @@
+package mongodb
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// recentQuery 构造按 created_at 倒序的分页查询
+// groupID 为 0 时不按群组过滤；before 非零时只返回更早的记录（以上一页最后一条的时间作为游标）
+// 需要 (group_id, created_at) 或 created_at 索引支持
+func recentQuery(groupID int64, limit int, before time.Time) (bson.M, *options.FindOptions) {
+	filter := bson.M{}
+	if groupID != 0 {
+		filter["group_id"] = groupID
+	}
+	if !before.IsZero() {
+		filter["created_at"] = bson.M{"$lt": before}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	return filter, opts
+}
@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRecentQuery(t *testing.T) {
+	t.Run("first page of a group", func(t *testing.T) {
+		filter, opts := recentQuery(-100123, 10, time.Time{})
+
+		assert.Equal(t, bson.M{"group_id": int64(-100123)}, filter)
+		assert.Equal(t, bson.D{{Key: "created_at", Value: -1}}, opts.Sort)
+		require.NotNil(t, opts.Limit)
+		assert.Equal(t, int64(10), *opts.Limit)
+	})
+
+	t.Run("cursor without group filter", func(t *testing.T) {
+		before := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		filter, opts := recentQuery(0, 5, before)
+
+		assert.Equal(t, bson.M{"created_at": bson.M{"$lt": before}}, filter)
+		assert.Equal(t, int64(5), *opts.Limit)
+	})
+}
@@ -0,0 +1,185 @@
+package mongodb
+
+import (
+	"context"
+	"telegram-bot/internal/domain/restriction"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RestrictionRepository MongoDB 限制记录仓储实现
+type RestrictionRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewRestrictionRepository 创建 MongoDB 限制记录仓储
+func NewRestrictionRepository(db *mongo.Database) *RestrictionRepository {
+	return &RestrictionRepository{
+		collection: db.Collection("restrictions"),
+		timeout:    10 * time.Second,
+	}
+}
+
+// restrictionDocument MongoDB 文档结构
+type restrictionDocument struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	GroupID   int64              `bson:"group_id"`
+	UserID    int64              `bson:"user_id"`
+	Kind      string             `bson:"kind"`
+	Reason    string             `bson:"reason"`
+	State     string             `bson:"state,omitempty"`
+	Until     time.Time          `bson:"until"`
+	CreatedBy int64              `bson:"created_by"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// toDocument 将领域对象转换为文档
+// 无效或为空的 ID 会生成新的 ObjectID
+func (r *RestrictionRepository) toDocument(rec *restriction.Record) *restrictionDocument {
+	id, err := primitive.ObjectIDFromHex(rec.ID)
+	if err != nil {
+		id = primitive.NewObjectID()
+	}
+
+	return &restrictionDocument{
+		ID:        id,
+		GroupID:   rec.GroupID,
+		UserID:    rec.UserID,
+		Kind:      string(rec.Kind),
+		Reason:    rec.Reason,
+		State:     rec.State,
+		Until:     rec.Until,
+		CreatedBy: rec.CreatedBy,
+		CreatedAt: rec.CreatedAt,
+	}
+}
+
+// toDomain 将文档转换为领域对象
+func (r *RestrictionRepository) toDomain(doc *restrictionDocument) *restriction.Record {
+	return &restriction.Record{
+		ID:        doc.ID.Hex(),
+		GroupID:   doc.GroupID,
+		UserID:    doc.UserID,
+		Kind:      restriction.Kind(doc.Kind),
+		Reason:    doc.Reason,
+		State:     doc.State,
+		Until:     doc.Until,
+		CreatedBy: doc.CreatedBy,
+		CreatedAt: doc.CreatedAt,
+	}
+}
+
+// Save 保存限制记录（按群组、用户、类型 upsert）
+func (r *RestrictionRepository) Save(ctx context.Context, rec *restriction.Record) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	doc := r.toDocument(rec)
+	filter := bson.M{
+		"group_id": doc.GroupID,
+		"user_id":  doc.UserID,
+		"kind":     doc.Kind,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"reason":     doc.Reason,
+			"state":      doc.State,
+			"until":      doc.Until,
+			"created_by": doc.CreatedBy,
+			"created_at": doc.CreatedAt,
+		},
+		"$setOnInsert": bson.M{"_id": doc.ID},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		rec.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+// FindExpired 查找已到期的限制记录
+func (r *RestrictionRepository) FindExpired(ctx context.Context, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"kind":  string(kind),
+		"until": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*restriction.Record
+	for cursor.Next(ctx) {
+		var doc restrictionDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		records = append(records, r.toDomain(&doc))
+	}
+
+	return records, cursor.Err()
+}
+
+// FindActiveByGroup 查找群组内尚未到期的限制记录，按到期时间升序
+func (r *RestrictionRepository) FindActiveByGroup(ctx context.Context, groupID int64, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "until", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"group_id": groupID,
+		"kind":     string(kind),
+		"until":    bson.M{"$gt": now},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*restriction.Record
+	for cursor.Next(ctx) {
+		var doc restrictionDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		records = append(records, r.toDomain(&doc))
+	}
+
+	return records, cursor.Err()
+}
+
+// Delete 删除限制记录
+func (r *RestrictionRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return restriction.ErrRecordNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return restriction.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"telegram-bot/internal/domain/restriction"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRestrictionRepository_DocumentConversion(t *testing.T) {
+	repo := &RestrictionRepository{}
+
+	t.Run("new record gets an ObjectID", func(t *testing.T) {
+		rec := restriction.NewTempBan(-100, 456, time.Now().Add(time.Hour), "spam", 123)
+
+		doc := repo.toDocument(rec)
+
+		assert.False(t, doc.ID.IsZero())
+		assert.Equal(t, "ban", doc.Kind)
+	})
+
+	t.Run("round trip conversion", func(t *testing.T) {
+		original := &restriction.Record{
+			ID:        primitive.NewObjectID().Hex(),
+			GroupID:   -100,
+			UserID:    456,
+			Kind:      restriction.KindBan,
+			Reason:    "spam",
+			Until:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			CreatedBy: 123,
+			CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		restored := repo.toDomain(repo.toDocument(original))
+		assert.Equal(t, original, restored)
+	})
+
+	t.Run("silence keeps saved state", func(t *testing.T) {
+		rec := restriction.NewSilence(-100, time.Now().Add(time.Minute), `{"can_send_messages":true}`, 123)
+
+		restored := repo.toDomain(repo.toDocument(rec))
+		assert.Equal(t, restriction.KindSilence, restored.Kind)
+		assert.Equal(t, int64(0), restored.UserID)
+		assert.Equal(t, rec.State, restored.State)
+	})
+}
@@ -0,0 +1,164 @@
+package mongodb
+
+import (
+	"context"
+	"telegram-bot/internal/domain/schedule"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScheduleRepository MongoDB 定时消息仓储实现
+type ScheduleRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewScheduleRepository 创建 MongoDB 定时消息仓储
+func NewScheduleRepository(db *mongo.Database) *ScheduleRepository {
+	return &ScheduleRepository{
+		collection: db.Collection("scheduled_messages"),
+		timeout:    10 * time.Second,
+	}
+}
+
+// scheduledMessageDocument MongoDB 文档结构
+type scheduledMessageDocument struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	GroupID         int64              `bson:"group_id"`
+	Text            string             `bson:"text"`
+	IntervalSeconds int64              `bson:"interval_seconds"`
+	NextRunAt       time.Time          `bson:"next_run_at"`
+	CreatedBy       int64              `bson:"created_by"`
+	CreatedAt       time.Time          `bson:"created_at"`
+}
+
+// toDocument 将领域对象转换为文档
+// 无效或为空的 ID 会生成新的 ObjectID
+func (r *ScheduleRepository) toDocument(m *schedule.Message) *scheduledMessageDocument {
+	id, err := primitive.ObjectIDFromHex(m.ID)
+	if err != nil {
+		id = primitive.NewObjectID()
+	}
+
+	return &scheduledMessageDocument{
+		ID:              id,
+		GroupID:         m.GroupID,
+		Text:            m.Text,
+		IntervalSeconds: int64(m.Interval / time.Second),
+		NextRunAt:       m.NextRunAt,
+		CreatedBy:       m.CreatedBy,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// toDomain 将文档转换为领域对象
+func (r *ScheduleRepository) toDomain(doc *scheduledMessageDocument) *schedule.Message {
+	return &schedule.Message{
+		ID:        doc.ID.Hex(),
+		GroupID:   doc.GroupID,
+		Text:      doc.Text,
+		Interval:  time.Duration(doc.IntervalSeconds) * time.Second,
+		NextRunAt: doc.NextRunAt,
+		CreatedBy: doc.CreatedBy,
+		CreatedAt: doc.CreatedAt,
+	}
+}
+
+// Save 保存定时消息（新消息会被分配 ID）
+func (r *ScheduleRepository) Save(ctx context.Context, m *schedule.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	doc := r.toDocument(m)
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return err
+	}
+
+	m.ID = doc.ID.Hex()
+	return nil
+}
+
+// FindByGroup 查找群组的所有定时消息（按创建时间排序）
+func (r *ScheduleRepository) FindByGroup(ctx context.Context, groupID int64) ([]*schedule.Message, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return r.find(ctx, bson.M{"group_id": groupID}, opts)
+}
+
+// FindDue 查找所有到期的定时消息
+func (r *ScheduleRepository) FindDue(ctx context.Context, now time.Time) ([]*schedule.Message, error) {
+	return r.find(ctx, bson.M{"next_run_at": bson.M{"$lte": now}}, options.Find())
+}
+
+// UpdateNextRun 更新下一次发送时间
+func (r *ScheduleRepository) UpdateNextRun(ctx context.Context, id string, nextRunAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return schedule.ErrMessageNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"next_run_at": nextRunAt}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return schedule.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// Delete 删除群组中的定时消息（限定群组，防止跨群删除）
+func (r *ScheduleRepository) Delete(ctx context.Context, groupID int64, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return schedule.ErrMessageNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "group_id": groupID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return schedule.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// find 按条件查询定时消息
+func (r *ScheduleRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*schedule.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*schedule.Message
+	for cursor.Next(ctx) {
+		var doc scheduledMessageDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		messages = append(messages, r.toDomain(&doc))
+	}
+
+	return messages, cursor.Err()
+}
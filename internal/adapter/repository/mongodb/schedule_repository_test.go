@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"telegram-bot/internal/domain/schedule"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestScheduleRepository_DocumentConversion(t *testing.T) {
+	repo := &ScheduleRepository{}
+
+	t.Run("new message gets an ObjectID", func(t *testing.T) {
+		m := schedule.NewMessage(-100, "hello", time.Hour, 123)
+
+		doc := repo.toDocument(m)
+
+		assert.False(t, doc.ID.IsZero())
+		assert.Equal(t, int64(3600), doc.IntervalSeconds)
+	})
+
+	t.Run("round trip through BSON", func(t *testing.T) {
+		original := &schedule.Message{
+			ID:        primitive.NewObjectID().Hex(),
+			GroupID:   -100,
+			Text:      "📢 每日公告\n请遵守群规",
+			Interval:  24 * time.Hour,
+			NextRunAt: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+			CreatedBy: 123,
+			CreatedAt: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+		}
+
+		raw, err := bson.Marshal(repo.toDocument(original))
+		assert.NoError(t, err)
+
+		var doc scheduledMessageDocument
+		assert.NoError(t, bson.Unmarshal(raw, &doc))
+
+		restored := repo.toDomain(&doc)
+		assert.Equal(t, original.ID, restored.ID)
+		assert.Equal(t, original.GroupID, restored.GroupID)
+		assert.Equal(t, original.Text, restored.Text)
+		assert.Equal(t, original.Interval, restored.Interval)
+		assert.True(t, original.NextRunAt.Equal(restored.NextRunAt))
+		assert.Equal(t, original.CreatedBy, restored.CreatedBy)
+		assert.True(t, original.CreatedAt.Equal(restored.CreatedAt))
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // UserRepository MongoDB 用户仓储实现
@@ -31,6 +32,7 @@ type userDocument struct {
 	FirstName   string        `bson:"first_name"`
 	LastName    string        `bson:"last_name"`
 	Permissions map[int64]int `bson:"permissions"` // groupID -> permission level
+	LastSeenAt  time.Time     `bson:"last_seen_at,omitempty"`
 	CreatedAt   time.Time     `bson:"created_at"`
 	UpdatedAt   time.Time     `bson:"updated_at"`
 }
@@ -48,6 +50,7 @@ func (r *UserRepository) toDocument(u *user.User) *userDocument {
 		FirstName:   u.FirstName,
 		LastName:    u.LastName,
 		Permissions: perms,
+		LastSeenAt:  u.LastSeenAt,
 		CreatedAt:   u.CreatedAt,
 		UpdatedAt:   u.UpdatedAt,
 	}
@@ -66,6 +69,7 @@ func (r *UserRepository) toDomain(doc *userDocument) *user.User {
 		FirstName:   doc.FirstName,
 		LastName:    doc.LastName,
 		Permissions: perms,
+		LastSeenAt:  doc.LastSeenAt,
 		CreatedAt:   doc.CreatedAt,
 		UpdatedAt:   doc.UpdatedAt,
 	}
@@ -161,6 +165,32 @@ func (r *UserRepository) UpdatePermission(ctx context.Context, userID int64, gro
 	return nil
 }
 
+// RecordSeen upsert 用户名、显示名和最近活跃时间
+// 只修改这几个字段，不覆盖并发修改的权限；用户被删除后再次出现时重新创建（没有群组权限）
+func (r *UserRepository) RecordSeen(ctx context.Context, u *user.User) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": u.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"username":     u.Username,
+			"first_name":   u.FirstName,
+			"last_name":    u.LastName,
+			"last_seen_at": u.LastSeenAt,
+			"updated_at":   now,
+		},
+		"$setOnInsert": bson.M{
+			"permissions": bson.M{},
+			"created_at":  now,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
 // Delete 删除用户
 func (r *UserRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -204,3 +234,50 @@ func (r *UserRepository) FindAdminsByGroup(ctx context.Context, groupID int64) (
 
 	return admins, cursor.Err()
 }
+
+// FindInactive 查找在群组有权限记录、before 之后没有出现过的用户
+// 从未记录最近活跃时间的用户排在最前，最多返回 limit 个
+func (r *UserRepository) FindInactive(ctx context.Context, groupID int64, before time.Time, limit int) ([]*user.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_seen_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, inactiveFilter(groupID, before), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*user.User
+	for cursor.Next(ctx) {
+		var doc userDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		users = append(users, r.toDomain(&doc))
+	}
+
+	return users, cursor.Err()
+}
+
+// inactiveFilter 在群组有权限记录、最近活跃时间早于 before 或从未记录的用户
+func inactiveFilter(groupID int64, before time.Time) bson.M {
+	return bson.M{
+		fmt.Sprintf("permissions.%d", groupID): bson.M{"$exists": true},
+		"$or": []bson.M{
+			{"last_seen_at": bson.M{"$lt": before}},
+			{"last_seen_at": bson.M{"$exists": false}},
+		},
+	}
+}
+
+// Count 统计用户总数
+func (r *UserRepository) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestUserRepository_DocumentConversion(t *testing.T) {
@@ -17,6 +18,7 @@ func TestUserRepository_DocumentConversion(t *testing.T) {
 		u.SetPermission(-200, user.PermissionUser)
 		u.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 		u.UpdatedAt = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		u.LastSeenAt = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
 
 		doc := repo.toDocument(u)
 
@@ -29,6 +31,7 @@ func TestUserRepository_DocumentConversion(t *testing.T) {
 		assert.Equal(t, int(user.PermissionUser), doc.Permissions[-200])
 		assert.Equal(t, u.CreatedAt, doc.CreatedAt)
 		assert.Equal(t, u.UpdatedAt, doc.UpdatedAt)
+		assert.Equal(t, u.LastSeenAt, doc.LastSeenAt)
 	})
 
 	t.Run("toDocument with empty permissions", func(t *testing.T) {
@@ -87,6 +90,7 @@ func TestUserRepository_DocumentConversion(t *testing.T) {
 		original.SetPermission(-100, user.PermissionAdmin)
 		original.SetPermission(-200, user.PermissionUser)
 		original.SetPermission(-300, user.PermissionSuperAdmin)
+		original.LastSeenAt = time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)
 
 		doc := repo.toDocument(original)
 		converted := repo.toDomain(doc)
@@ -95,6 +99,7 @@ func TestUserRepository_DocumentConversion(t *testing.T) {
 		assert.Equal(t, original.Username, converted.Username)
 		assert.Equal(t, original.FirstName, converted.FirstName)
 		assert.Equal(t, original.LastName, converted.LastName)
+		assert.Equal(t, original.LastSeenAt, converted.LastSeenAt)
 		assert.Equal(t, len(original.Permissions), len(converted.Permissions))
 		for groupID, perm := range original.Permissions {
 			assert.Equal(t, perm, converted.Permissions[groupID])
@@ -275,3 +280,15 @@ func BenchmarkUserRepository_RoundTrip(b *testing.B) {
 		repo.toDomain(doc)
 	}
 }
+
+func TestInactiveFilter(t *testing.T) {
+	before := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	filter := inactiveFilter(-100, before)
+
+	assert.Equal(t, bson.M{"$exists": true}, filter["permissions.-100"])
+	assert.Equal(t, []bson.M{
+		{"last_seen_at": bson.M{"$lt": before}},
+		{"last_seen_at": bson.M{"$exists": false}},
+	}, filter["$or"])
+}
@@ -1,7 +1,11 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
 	"time"
 
 	"github.com/go-telegram/bot"
@@ -10,94 +14,395 @@ import (
 
 // API Telegram API 适配器
 // 提供常用的 Telegram Bot API 操作
+// 所有调用都经过 Retrier，自动处理 429 和临时性网络错误；
+// 发送消息前经过 SendQueue 排队（queue 为 nil 时不限速），
+// 发送成功后记录到 SentMessages（sent 为 nil 时不记录）
 type API struct {
-	bot *bot.Bot
+	bot     *bot.Bot
+	retrier *Retrier
+	queue   *SendQueue
+	sent    *SentMessages
 }
 
 // NewAPI 创建 Telegram API 适配器
-func NewAPI(b *bot.Bot) *API {
-	return &API{bot: b}
+func NewAPI(b *bot.Bot, queue *SendQueue, sent *SentMessages) *API {
+	return &API{
+		bot:     b,
+		retrier: NewRetrier(DefaultRetryConfig()),
+		queue:   queue,
+		sent:    sent,
+	}
+}
+
+// RetryStats 返回所有 API 调用的重试统计
+func (a *API) RetryStats() RetryStats {
+	return a.retrier.Stats()
 }
 
 // BanChatMember 永久封禁群组成员
 func (a *API) BanChatMember(ctx context.Context, chatID, userID int64) error {
-	_, err := a.bot.BanChatMember(ctx, &bot.BanChatMemberParams{
-		ChatID: chatID,
-		UserID: userID,
-	})
-	return err
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.BanChatMember(ctx, &bot.BanChatMemberParams{
+			ChatID: chatID,
+			UserID: userID,
+		})
+		return err
+	}))
 }
 
 // BanChatMemberWithDuration 临时封禁群组成员
 func (a *API) BanChatMemberWithDuration(ctx context.Context, chatID, userID int64, until time.Time) error {
-	_, err := a.bot.BanChatMember(ctx, &bot.BanChatMemberParams{
-		ChatID:    chatID,
-		UserID:    userID,
-		UntilDate: int(until.Unix()),
-	})
-	return err
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.BanChatMember(ctx, &bot.BanChatMemberParams{
+			ChatID:    chatID,
+			UserID:    userID,
+			UntilDate: int(until.Unix()),
+		})
+		return err
+	}))
+}
+
+// UnbanChatMember 解除群组成员封禁
+// 仅在用户处于封禁状态时生效，不会把群内成员移出群组
+func (a *API) UnbanChatMember(ctx context.Context, chatID, userID int64) error {
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.UnbanChatMember(ctx, &bot.UnbanChatMemberParams{
+			ChatID:       chatID,
+			UserID:       userID,
+			OnlyIfBanned: true,
+		})
+		return err
+	}))
 }
 
 // RestrictChatMember 限制群组成员权限（禁言等）
 func (a *API) RestrictChatMember(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions) error {
-	_, err := a.bot.RestrictChatMember(ctx, &bot.RestrictChatMemberParams{
-		ChatID:      chatID,
-		UserID:      userID,
-		Permissions: &permissions,
-	})
-	return err
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.RestrictChatMember(ctx, &bot.RestrictChatMemberParams{
+			ChatID:      chatID,
+			UserID:      userID,
+			Permissions: &permissions,
+		})
+		return err
+	}))
 }
 
 // RestrictChatMemberWithDuration 限制群组成员权限（禁言等）带时长
 func (a *API) RestrictChatMemberWithDuration(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions, until time.Time) error {
-	_, err := a.bot.RestrictChatMember(ctx, &bot.RestrictChatMemberParams{
-		ChatID:      chatID,
-		UserID:      userID,
-		Permissions: &permissions,
-		UntilDate:   int(until.Unix()),
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.RestrictChatMember(ctx, &bot.RestrictChatMemberParams{
+			ChatID:      chatID,
+			UserID:      userID,
+			Permissions: &permissions,
+			UntilDate:   int(until.Unix()),
+		})
+		return err
+	}))
+}
+
+// SetChatPermissions 设置群组所有成员的默认权限
+// 各项权限按原样设置，不由 Telegram 根据 can_send_messages 等推导
+func (a *API) SetChatPermissions(ctx context.Context, chatID int64, permissions models.ChatPermissions) error {
+	return wrapMemberError(a.retrier.Do(ctx, func() error {
+		_, err := a.bot.SetChatPermissions(ctx, &bot.SetChatPermissionsParams{
+			ChatID:                        chatID,
+			Permissions:                   permissions,
+			UseIndependentChatPermissions: true,
+		})
+		return err
+	}))
+}
+
+// GetChat 获取群组的完整信息（包括成员默认权限）
+func (a *API) GetChat(ctx context.Context, chatID int64) (*models.ChatFullInfo, error) {
+	var chat *models.ChatFullInfo
+	err := a.retrier.Do(ctx, func() error {
+		var err error
+		chat, err = a.bot.GetChat(ctx, &bot.GetChatParams{ChatID: chatID})
+		return err
 	})
-	return err
+	return chat, err
 }
 
-// SendMessage 发送消息
-func (a *API) SendMessage(ctx context.Context, chatID int64, text string) error {
-	_, err := a.bot.SendMessage(ctx, &bot.SendMessageParams{
+// SendMessage 发送消息，返回发送的消息 ID
+func (a *API) SendMessage(ctx context.Context, chatID int64, text string) (int, error) {
+	return a.send(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   text,
 	})
-	return err
 }
 
-// SendMessageWithReply 发送回复消息
-func (a *API) SendMessageWithReply(ctx context.Context, chatID int64, text string, replyToMessageID int) error {
-	_, err := a.bot.SendMessage(ctx, &bot.SendMessageParams{
+// SendMessageWithReply 发送回复消息，返回发送的消息 ID
+func (a *API) SendMessageWithReply(ctx context.Context, chatID int64, text string, replyToMessageID int) (int, error) {
+	return a.send(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   text,
 		ReplyParameters: &models.ReplyParameters{
 			MessageID: replyToMessageID,
 		},
 	})
-	return err
+}
+
+// SendMessageWithButtons 发送带内联键盘的 HTML 消息，返回发送的消息 ID
+func (a *API) SendMessageWithButtons(ctx context.Context, chatID int64, text string, buttons [][]handler.Button) (int, error) {
+	params := &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	}
+	// ReplyMarkup 是接口类型，不能直接赋值为 nil 指针
+	if markup := handler.InlineKeyboardMarkup(buttons); markup != nil {
+		params.ReplyMarkup = markup
+	}
+	return a.send(ctx, params)
+}
+
+// send 排队后发送消息，返回发送的消息 ID
+func (a *API) send(ctx context.Context, params *bot.SendMessageParams) (int, error) {
+	chatID := params.ChatID.(int64)
+	if err := a.waitSend(ctx, chatID); err != nil {
+		return 0, err
+	}
+
+	var msg *models.Message
+	err := a.retrier.Do(ctx, func() error {
+		var err error
+		msg, err = a.bot.SendMessage(ctx, params)
+		return err
+	})
+	return a.sentID(chatID, msg, err)
+}
+
+// SendDocument 发送文件，返回发送的消息 ID
+// 每次重试都重新创建 Reader，保证重试时从头上传
+func (a *API) SendDocument(ctx context.Context, chatID int64, filename string, data []byte, caption string) (int, error) {
+	if err := a.waitSend(ctx, chatID); err != nil {
+		return 0, err
+	}
+
+	var msg *models.Message
+	err := a.retrier.Do(ctx, func() error {
+		var err error
+		msg, err = a.bot.SendDocument(ctx, &bot.SendDocumentParams{
+			ChatID: chatID,
+			Document: &models.InputFileUpload{
+				Filename: filename,
+				Data:     bytes.NewReader(data),
+			},
+			Caption:   caption,
+			ParseMode: models.ParseModeHTML,
+		})
+		return err
+	})
+	return a.sentID(chatID, msg, err)
 }
 
 // DeleteMessage 删除消息
+// 消息已被删除时返回 CodeNotFound 错误
 func (a *API) DeleteMessage(ctx context.Context, chatID int64, messageID int) error {
-	_, err := a.bot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+	err := a.retrier.Do(ctx, func() error {
+		_, err := a.bot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+		})
+		return err
+	})
+	if IsMessageNotFoundError(err) {
+		return errors.WrapWithCode(err, errors.CodeNotFound, "message not found")
+	}
+	return err
+}
+
+// EditMessageWithButtons 修改机器人消息的 HTML 文本和内联键盘，buttons 为空时移除键盘
+// 内容没有变化时 Telegram 返回错误，这里视为成功
+func (a *API) EditMessageWithButtons(ctx context.Context, chatID int64, messageID int, text string, buttons [][]handler.Button) error {
+	params := &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: messageID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	}
+	if markup := handler.InlineKeyboardMarkup(buttons); markup != nil {
+		params.ReplyMarkup = markup
+	}
+
+	err := a.retrier.Do(ctx, func() error {
+		_, err := a.bot.EditMessageText(ctx, params)
+		return err
 	})
+	if IsMessageNotModifiedError(err) {
+		return nil
+	}
 	return err
 }
 
-// GetChatMember 获取群组成员信息
-func (a *API) GetChatMember(ctx context.Context, chatID, userID int64) (*models.ChatMember, error) {
-	member, err := a.bot.GetChatMember(ctx, &bot.GetChatMemberParams{
-		ChatID: chatID,
-		UserID: userID,
+// AnswerCallbackQuery 应答按钮回调，结束客户端的加载状态；text 非空时显示提示
+func (a *API) AnswerCallbackQuery(ctx context.Context, queryID string, text string, showAlert bool) error {
+	return a.retrier.Do(ctx, func() error {
+		_, err := a.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: queryID,
+			Text:            text,
+			ShowAlert:       showAlert,
+		})
+		return err
+	})
+}
+
+// AnswerInlineQuery 回答内联查询
+// 结果以文章形式返回，选中后以 HTML 格式发送结果文本
+func (a *API) AnswerInlineQuery(ctx context.Context, queryID string, results []handler.InlineResult, cacheTime int) error {
+	return a.retrier.Do(ctx, func() error {
+		_, err := a.bot.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+			InlineQueryID: queryID,
+			Results:       inlineQueryResults(results),
+			CacheTime:     cacheTime,
+		})
+		return err
+	})
+}
+
+// inlineQueryResults 将内联查询结果转换为 Telegram 文章结果
+func inlineQueryResults(results []handler.InlineResult) []models.InlineQueryResult {
+	items := make([]models.InlineQueryResult, 0, len(results))
+	for _, r := range results {
+		items = append(items, &models.InlineQueryResultArticle{
+			ID:          r.ID,
+			Title:       r.Title,
+			Description: r.Description,
+			InputMessageContent: &models.InputTextMessageContent{
+				MessageText: r.Text,
+				ParseMode:   models.ParseModeHTML,
+			},
+		})
+	}
+	return items
+}
+
+// GetChatMember 获取群组成员的实时信息（状态、管理员权限、当前用户名和名字）
+func (a *API) GetChatMember(ctx context.Context, chatID, userID int64) (*group.Member, error) {
+	var member *models.ChatMember
+	err := a.retrier.Do(ctx, func() error {
+		var err error
+		member, err = a.bot.GetChatMember(ctx, &bot.GetChatMemberParams{
+			ChatID: chatID,
+			UserID: userID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toMember(member), nil
+}
+
+// GetChatAdministrators 获取群组当前的管理员列表（包括群主和机器人管理员）
+func (a *API) GetChatAdministrators(ctx context.Context, chatID int64) ([]*group.Member, error) {
+	var admins []models.ChatMember
+	err := a.retrier.Do(ctx, func() error {
+		var err error
+		admins, err = a.bot.GetChatAdministrators(ctx, &bot.GetChatAdministratorsParams{
+			ChatID: chatID,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return member, nil
+	members := make([]*group.Member, 0, len(admins))
+	for i := range admins {
+		members = append(members, toMember(&admins[i]))
+	}
+	return members, nil
+}
+
+// toMember 从各状态的成员信息中提取常用字段
+func toMember(cm *models.ChatMember) *group.Member {
+	m := &group.Member{Status: group.MemberStatus(cm.Type)}
+
+	var u *models.User
+	switch cm.Type {
+	case models.ChatMemberTypeOwner:
+		if cm.Owner != nil {
+			u = cm.Owner.User
+			m.CustomTitle = cm.Owner.CustomTitle
+		}
+		m.IsMember = true
+		m.CanRestrictMembers, m.CanDeleteMessages, m.CanPromoteMembers = true, true, true
+	case models.ChatMemberTypeAdministrator:
+		if a := cm.Administrator; a != nil {
+			u = &a.User
+			m.CustomTitle = a.CustomTitle
+			m.CanRestrictMembers = a.CanRestrictMembers
+			m.CanDeleteMessages = a.CanDeleteMessages
+			m.CanPromoteMembers = a.CanPromoteMembers
+		}
+		m.IsMember = true
+	case models.ChatMemberTypeMember:
+		if cm.Member != nil {
+			u = cm.Member.User
+		}
+		m.IsMember = true
+	case models.ChatMemberTypeRestricted:
+		if cm.Restricted != nil {
+			u = cm.Restricted.User
+			m.IsMember = cm.Restricted.IsMember
+		}
+	case models.ChatMemberTypeLeft:
+		if cm.Left != nil {
+			u = cm.Left.User
+		}
+	case models.ChatMemberTypeBanned:
+		if cm.Banned != nil {
+			u = cm.Banned.User
+		}
+	}
+
+	if u != nil {
+		m.UserID = u.ID
+		m.Username = u.Username
+		m.FirstName = u.FirstName
+		m.LastName = u.LastName
+		m.IsBot = u.IsBot
+	}
+	return m
+}
+
+// sentID 返回发送成功的消息 ID，并记录到 SentMessages
+func (a *API) sentID(chatID int64, msg *models.Message, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	if msg == nil {
+		return 0, nil
+	}
+	if a.sent != nil {
+		a.sent.RecordSent(chatID, msg.ID)
+	}
+	return msg.ID, nil
+}
+
+// IgnoreMessageID 丢弃发送方法返回的消息 ID，供只关心是否出错的调用方使用
+//
+//	err := telegram.IgnoreMessageID(api.SendMessage(ctx, chatID, text))
+func IgnoreMessageID(_ int, err error) error {
+	return err
+}
+
+// waitSend 等待发送队列放行
+func (a *API) waitSend(ctx context.Context, chatID int64) error {
+	if a.queue == nil {
+		return nil
+	}
+	return a.queue.Wait(ctx, chatID)
+}
+
+// wrapMemberError 将机器人缺少管理员权限的错误标记为 CodeBotPermission，
+// 便于上层给出明确提示而不是笼统的失败信息
+func wrapMemberError(err error) error {
+	if IsPermissionError(err) {
+		return errors.WrapWithCode(err, errors.CodeBotPermission, "bot lacks admin rights")
+	}
+	return err
 }
@@ -0,0 +1,288 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineQueryResults(t *testing.T) {
+	items := inlineQueryResults([]handler.InlineResult{
+		{ID: "calc", Title: "= 3", Description: "1+2", Text: "<code>1+2</code> = <b>3</b>"},
+	})
+
+	require.Len(t, items, 1)
+	article, ok := items[0].(*models.InlineQueryResultArticle)
+	require.True(t, ok)
+	assert.Equal(t, "calc", article.ID)
+	assert.Equal(t, "= 3", article.Title)
+	assert.Equal(t, "1+2", article.Description)
+
+	content, ok := article.InputMessageContent.(*models.InputTextMessageContent)
+	require.True(t, ok)
+	assert.Equal(t, "<code>1+2</code> = <b>3</b>", content.MessageText)
+	assert.Equal(t, models.ParseModeHTML, content.ParseMode)
+}
+
+// newMessageServer 模拟 Telegram 服务端，发送类方法返回指定的消息 ID
+func newMessageServer(t *testing.T, messageID int) *bot.Bot {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":%d}}`, messageID)
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	return b
+}
+
+func TestAPI_SendReturnsMessageID(t *testing.T) {
+	sent := NewSentMessages(10)
+	api := NewAPI(newMessageServer(t, 42), nil, sent)
+	ctx := context.Background()
+
+	id, err := api.SendMessage(ctx, -100, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	id, err = api.SendMessageWithReply(ctx, -100, "reply", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	id, err = api.SendDocument(ctx, -200, "stats.csv", []byte("a,b"), "")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	// 发送的消息同时记录到 SentMessages
+	assert.Equal(t, []int{42, 42}, sent.Recent(-100, 10))
+	assert.Equal(t, []int{42}, sent.Recent(-200, 10))
+}
+
+func TestAPI_SendMessageWithButtons(t *testing.T) {
+	calls := 0
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		form = map[string]string{}
+		for key, values := range r.MultipartForm.Value {
+			form[key] = values[0]
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	api := NewAPI(b, nil, nil)
+	api.retrier.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	buttons := handler.NewKeyboard().
+		Row(handler.CallbackButton("Yes", "vote:yes"), handler.CallbackButton("No", "vote:no")).
+		Row(handler.URLButton("Rules", "https://t.me/example")).
+		Buttons()
+	id, err := api.SendMessageWithButtons(context.Background(), -100, "<b>Vote</b>", buttons)
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, "<b>Vote</b>", form["text"])
+	assert.Equal(t, "HTML", form["parse_mode"])
+	var markup models.InlineKeyboardMarkup
+	require.NoError(t, json.Unmarshal([]byte(form["reply_markup"]), &markup))
+	assert.Equal(t, *handler.InlineKeyboardMarkup(buttons), markup)
+}
+
+func TestIgnoreMessageID(t *testing.T) {
+	assert.NoError(t, IgnoreMessageID(42, nil))
+	assert.Equal(t, context.Canceled, IgnoreMessageID(0, context.Canceled))
+}
+
+func TestAPI_GetChatMember(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			// 第一次返回 429，由重试器重试
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"status":"administrator","custom_title":"Mod","can_restrict_members":true,` +
+			`"user":{"id":7,"is_bot":false,"first_name":"Alice","last_name":"Liddell","username":"alice"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	api := NewAPI(b, nil, nil)
+	api.retrier.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	member, err := api.GetChatMember(context.Background(), -100, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, &group.Member{
+		UserID:             7,
+		Username:           "alice",
+		FirstName:          "Alice",
+		LastName:           "Liddell",
+		Status:             group.MemberStatusAdministrator,
+		IsMember:           true,
+		CustomTitle:        "Mod",
+		CanRestrictMembers: true,
+	}, member)
+	assert.Equal(t, int64(1), api.RetryStats().SucceededAfterRetry)
+}
+
+func TestAPI_GetChatAdministrators(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":[` +
+			`{"status":"creator","custom_title":"Boss","is_anonymous":false,"user":{"id":1,"is_bot":false,"first_name":"Owner"}},` +
+			`{"status":"administrator","custom_title":"Mod","can_delete_messages":true,"user":{"id":7,"is_bot":false,"first_name":"Alice","username":"alice"}},` +
+			`{"status":"administrator","can_restrict_members":true,"user":{"id":99,"is_bot":true,"first_name":"Bot","username":"test_bot"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	api := NewAPI(b, nil, nil)
+	api.retrier.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	admins, err := api.GetChatAdministrators(context.Background(), -100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	require.Len(t, admins, 3)
+
+	assert.Equal(t, int64(1), admins[0].UserID)
+	assert.Equal(t, group.MemberStatusCreator, admins[0].Status)
+	assert.Equal(t, "Boss", admins[0].CustomTitle)
+
+	assert.Equal(t, &group.Member{
+		UserID:            7,
+		Username:          "alice",
+		FirstName:         "Alice",
+		Status:            group.MemberStatusAdministrator,
+		IsMember:          true,
+		CustomTitle:       "Mod",
+		CanDeleteMessages: true,
+	}, admins[1])
+
+	assert.True(t, admins[2].IsBot)
+	assert.True(t, admins[2].IsAdmin())
+	assert.Equal(t, int64(1), api.RetryStats().SucceededAfterRetry)
+}
+
+func TestAPI_SetChatPermissions(t *testing.T) {
+	calls := 0
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		form = map[string]string{}
+		for key, values := range r.MultipartForm.Value {
+			form[key] = values[0]
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	api := NewAPI(b, nil, nil)
+	api.retrier.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	err = api.SetChatPermissions(context.Background(), -100, models.ChatPermissions{CanSendMessages: true, CanInviteUsers: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// 参数原样传给 Telegram，各项权限独立设置
+	assert.Equal(t, "-100", form["chat_id"])
+	var sent models.ChatPermissions
+	require.NoError(t, json.Unmarshal([]byte(form["permissions"]), &sent))
+	assert.Equal(t, models.ChatPermissions{CanSendMessages: true, CanInviteUsers: true}, sent)
+	assert.Equal(t, "true", form["use_independent_chat_permissions"])
+}
+
+func TestAPI_GetChat(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"id":-100,"type":"supergroup","title":"Test",` +
+			`"permissions":{"can_send_messages":true,"can_send_polls":false}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	api := NewAPI(b, nil, nil)
+	api.retrier.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	chat, err := api.GetChat(context.Background(), -100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(-100), chat.ID)
+	require.NotNil(t, chat.Permissions)
+	assert.Equal(t, models.ChatPermissions{CanSendMessages: true}, *chat.Permissions)
+}
+
+func TestToMember(t *testing.T) {
+	restricted := toMember(&models.ChatMember{
+		Type:       models.ChatMemberTypeRestricted,
+		Restricted: &models.ChatMemberRestricted{User: &models.User{ID: 8, Username: "bob"}, IsMember: false},
+	})
+	assert.Equal(t, int64(8), restricted.UserID)
+	assert.False(t, restricted.IsMember)
+	assert.False(t, restricted.IsAdmin())
+
+	owner := toMember(&models.ChatMember{
+		Type:  models.ChatMemberTypeOwner,
+		Owner: &models.ChatMemberOwner{User: &models.User{ID: 1}},
+	})
+	assert.True(t, owner.IsMember)
+	assert.True(t, owner.IsAdmin())
+	assert.True(t, owner.CanRestrictMembers)
+
+	left := toMember(&models.ChatMember{Type: models.ChatMemberTypeLeft, Left: &models.ChatMemberLeft{User: &models.User{ID: 9}}})
+	assert.Equal(t, group.MemberStatusLeft, left.Status)
+	assert.False(t, left.IsMember)
+}
@@ -0,0 +1,79 @@
+package telegram
+
+import (
+	"context"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+)
+
+// MessageDeleter 删除消息接口（由 API 实现）
+type MessageDeleter interface {
+	DeleteMessage(ctx context.Context, chatID int64, messageID int) error
+}
+
+// pendingDelete 等待删除的消息
+type pendingDelete struct {
+	chatID    int64
+	messageID int
+}
+
+// AutoDeleter 延迟删除机器人自己发送的消息
+// 待删除的消息保存在内存中，到期后由后台清理任务删除；重启后未到期的消息不会再被删除
+type AutoDeleter struct {
+	deleter MessageDeleter
+	sent    *SentMessages // 可为 nil
+	onError func(chatID int64, messageID int, err error)
+	pending *ttlmap.Map[pendingDelete, struct{}]
+}
+
+// NewAutoDeleter 创建消息自动删除器
+// 到期的消息无论删除是否成功都从 sent 中移除记录（sent 可为 nil）；
+// 删除失败且不是消息已不存在时调用 onError（可为 nil）
+func NewAutoDeleter(deleter MessageDeleter, sent *SentMessages, onError func(chatID int64, messageID int, err error)) *AutoDeleter {
+	d := &AutoDeleter{
+		deleter: deleter,
+		sent:    sent,
+		onError: onError,
+	}
+	d.pending = ttlmap.New[pendingDelete, struct{}](func(key pendingDelete, _ struct{}) {
+		d.delete(key)
+	})
+	return d
+}
+
+// ScheduleDelete 在 after 之后删除聊天中的指定消息
+func (d *AutoDeleter) ScheduleDelete(chatID int64, messageID int, after time.Duration) {
+	d.pending.Set(pendingDelete{chatID: chatID, messageID: messageID}, struct{}{}, after)
+}
+
+// Pending 等待删除的消息数
+func (d *AutoDeleter) Pending() int {
+	return d.pending.Len()
+}
+
+// StartSweeper 启动后台删除任务，消息最晚在到期后 interval 内被删除
+func (d *AutoDeleter) StartSweeper(interval time.Duration) {
+	d.pending.StartSweeper(interval)
+}
+
+// Stop 停止后台删除任务
+func (d *AutoDeleter) Stop() {
+	d.pending.Stop()
+}
+
+// delete 删除到期的消息
+func (d *AutoDeleter) delete(key pendingDelete) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := d.deleter.DeleteMessage(ctx, key.chatID, key.messageID)
+	if err != nil && !errors.HasCode(err, errors.CodeNotFound) && d.onError != nil {
+		d.onError(key.chatID, key.messageID, err)
+	}
+
+	// 删除失败的消息（例如机器人没有删除权限）之后 /cleanup 也无法删除，一并移除记录
+	if d.sent != nil {
+		d.sent.Forget(key.chatID, []int{key.messageID})
+	}
+}
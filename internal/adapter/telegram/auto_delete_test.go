@@ -0,0 +1,79 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"telegram-bot/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingDeleter struct {
+	mu      sync.Mutex
+	deleted []int
+	err     error
+}
+
+func (d *recordingDeleter) DeleteMessage(ctx context.Context, chatID int64, messageID int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleted = append(d.deleted, messageID)
+	return d.err
+}
+
+func (d *recordingDeleter) Deleted() []int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]int(nil), d.deleted...)
+}
+
+func TestAutoDeleter_DeletesWhenDue(t *testing.T) {
+	deleter := &recordingDeleter{}
+	sent := NewSentMessages(10)
+	sent.RecordSent(-100, 1)
+	sent.RecordSent(-100, 2)
+
+	d := NewAutoDeleter(deleter, sent, nil)
+	d.ScheduleDelete(-100, 1, 0)
+	d.ScheduleDelete(-100, 2, time.Hour)
+
+	// 只删除到期的消息
+	assert.Equal(t, 1, d.pending.Sweep())
+	assert.Equal(t, []int{1}, deleter.Deleted())
+	assert.Equal(t, []int{2}, sent.Recent(-100, 10))
+	assert.Equal(t, 1, d.Pending())
+}
+
+func TestAutoDeleter_ReportsErrors(t *testing.T) {
+	var failed []int
+	onError := func(chatID int64, messageID int, err error) {
+		failed = append(failed, messageID)
+	}
+
+	deleter := &recordingDeleter{err: errors.New(errors.CodeNotFound, "message not found")}
+	d := NewAutoDeleter(deleter, nil, onError)
+	d.ScheduleDelete(-100, 1, 0)
+	d.pending.Sweep()
+	// 消息已被手动删除不算失败
+	assert.Empty(t, failed)
+
+	deleter.err = errors.New(errors.CodeBotPermission, "not enough rights")
+	d.ScheduleDelete(-100, 2, 0)
+	d.pending.Sweep()
+	assert.Equal(t, []int{2}, failed)
+}
+
+func TestAutoDeleter_StartSweeper(t *testing.T) {
+	deleter := &recordingDeleter{}
+	d := NewAutoDeleter(deleter, nil, nil)
+	d.StartSweeper(5 * time.Millisecond)
+	defer d.Stop()
+
+	d.ScheduleDelete(-100, 7, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		return len(deleter.Deleted()) == 1
+	}, time.Second, 5*time.Millisecond)
+}
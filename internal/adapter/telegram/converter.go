@@ -9,15 +9,19 @@ import (
 )
 
 // ConvertUpdate 将 Telegram Update 转换为 Handler Context
-// 如果不是消息更新，返回 nil
+// 支持新消息和编辑后的消息（edited_message），其他更新返回 nil
 func ConvertUpdate(ctx context.Context, b *bot.Bot, update *models.Update) *handler.Context {
-	// 只处理消息更新
-	if update.Message == nil {
+	// 只处理消息和编辑消息更新
+	msg := update.Message
+	isEdit := false
+	if msg == nil {
+		msg = update.EditedMessage
+		isEdit = true
+	}
+	if msg == nil {
 		return nil
 	}
 
-	msg := update.Message
-
 	// 某些消息（如频道消息）可能没有 From 字段，跳过处理
 	if msg.From == nil {
 		return nil
@@ -44,6 +48,13 @@ func ConvertUpdate(ctx context.Context, b *bot.Bot, update *models.Update) *hand
 		// 消息内容
 		Text:      msg.Text,
 		MessageID: msg.ID,
+		IsEdit:    isEdit,
+	}
+
+	// 匿名管理员和关联频道的消息带有 sender_chat
+	if msg.SenderChat != nil {
+		handlerCtx.SenderChatID = msg.SenderChat.ID
+		handlerCtx.SenderChatTitle = msg.SenderChat.Title
 	}
 
 	// 处理回复消息
@@ -58,3 +69,48 @@ func ConvertUpdate(ctx context.Context, b *bot.Bot, update *models.Update) *hand
 
 	return handlerCtx
 }
+
+// ConvertInlineQuery 将 inline_query 更新转换为内联查询，其他更新返回 nil
+func ConvertInlineQuery(update *models.Update) *handler.InlineQuery {
+	q := update.InlineQuery
+	if q == nil || q.From == nil {
+		return nil
+	}
+
+	return &handler.InlineQuery{
+		ID:       q.ID,
+		Query:    q.Query,
+		Offset:   q.Offset,
+		UserID:   q.From.ID,
+		Username: q.From.Username,
+	}
+}
+
+// ConvertCallbackQuery 将 callback_query 更新转换为按钮回调，其他更新（以及内联消息的回调）返回 nil
+func ConvertCallbackQuery(update *models.Update) *handler.CallbackQuery {
+	q := update.CallbackQuery
+	if q == nil {
+		return nil
+	}
+
+	cq := &handler.CallbackQuery{
+		ID:        q.ID,
+		Data:      q.Data,
+		UserID:    q.From.ID,
+		Username:  q.From.Username,
+		FirstName: q.From.FirstName,
+	}
+
+	// 消息较旧时 Telegram 只返回聊天和消息 ID
+	switch {
+	case q.Message.Message != nil:
+		cq.ChatID = q.Message.Message.Chat.ID
+		cq.MessageID = q.Message.Message.ID
+	case q.Message.InaccessibleMessage != nil:
+		cq.ChatID = q.Message.InaccessibleMessage.Chat.ID
+		cq.MessageID = q.Message.InaccessibleMessage.MessageID
+	default:
+		return nil
+	}
+	return cq
+}
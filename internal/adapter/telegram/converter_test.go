@@ -0,0 +1,129 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessage(text string) *models.Message {
+	return &models.Message{
+		ID:   10,
+		Text: text,
+		Chat: models.Chat{
+			ID:    -1001234567890,
+			Type:  models.ChatTypeSupergroup,
+			Title: "Test Group",
+		},
+		From: &models.User{
+			ID:        123,
+			Username:  "alice",
+			FirstName: "Alice",
+			LastName:  "Liddell",
+		},
+	}
+}
+
+func TestConvertUpdate_Message(t *testing.T) {
+	update := &models.Update{Message: newTestMessage("/ping")}
+
+	ctx := ConvertUpdate(context.Background(), nil, update)
+	require.NotNil(t, ctx)
+
+	assert.Equal(t, "/ping", ctx.Text)
+	assert.Equal(t, 10, ctx.MessageID)
+	assert.Equal(t, int64(123), ctx.UserID)
+	assert.Equal(t, "alice", ctx.Username)
+	assert.Equal(t, "Alice", ctx.FirstName)
+	assert.Equal(t, "Liddell", ctx.LastName)
+	assert.Equal(t, int64(-1001234567890), ctx.ChatID)
+	assert.Equal(t, "supergroup", ctx.ChatType)
+	assert.False(t, ctx.IsEdit)
+}
+
+func TestConvertUpdate_EditedMessage(t *testing.T) {
+	update := &models.Update{EditedMessage: newTestMessage("buy cheap stuff")}
+
+	ctx := ConvertUpdate(context.Background(), nil, update)
+	require.NotNil(t, ctx)
+
+	assert.True(t, ctx.IsEdit)
+	assert.Equal(t, "buy cheap stuff", ctx.Text)
+	assert.Equal(t, 10, ctx.MessageID)
+	assert.Same(t, update.EditedMessage, ctx.Message)
+}
+
+func TestConvertUpdate_Ignored(t *testing.T) {
+	t.Run("non-message update", func(t *testing.T) {
+		assert.Nil(t, ConvertUpdate(context.Background(), nil, &models.Update{}))
+	})
+
+	t.Run("message without sender", func(t *testing.T) {
+		msg := newTestMessage("hello")
+		msg.From = nil
+		assert.Nil(t, ConvertUpdate(context.Background(), nil, &models.Update{Message: msg}))
+	})
+}
+
+func TestConvertUpdate_SenderChat(t *testing.T) {
+	msg := newTestMessage("/ban")
+	msg.From = &models.User{ID: 1087968824, Username: "GroupAnonymousBot", FirstName: "Group"}
+	msg.SenderChat = &models.Chat{ID: msg.Chat.ID, Type: models.ChatTypeSupergroup, Title: "Test Group"}
+
+	ctx := ConvertUpdate(context.Background(), nil, &models.Update{Message: msg})
+	require.NotNil(t, ctx)
+
+	assert.Equal(t, int64(-1001234567890), ctx.SenderChatID)
+	assert.Equal(t, "Test Group", ctx.SenderChatTitle)
+	assert.True(t, ctx.IsSenderChat())
+	assert.True(t, ctx.IsAnonymousAdmin())
+}
+
+func TestConvertInlineQuery(t *testing.T) {
+	update := &models.Update{InlineQuery: &models.InlineQuery{
+		ID:     "q1",
+		Query:  "1+2",
+		Offset: "",
+		From:   &models.User{ID: 123, Username: "alice"},
+	}}
+
+	q := ConvertInlineQuery(update)
+	require.NotNil(t, q)
+	assert.Equal(t, "q1", q.ID)
+	assert.Equal(t, "1+2", q.Query)
+	assert.Equal(t, int64(123), q.UserID)
+	assert.Equal(t, "alice", q.Username)
+
+	// 内联查询不是消息，ConvertUpdate 应忽略
+	assert.Nil(t, ConvertUpdate(context.Background(), nil, update))
+	assert.Nil(t, ConvertInlineQuery(&models.Update{Message: newTestMessage("/ping")}))
+}
+
+func TestConvertCallbackQuery(t *testing.T) {
+	update := &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID:   "cb1",
+		Data: "vote:abc:1",
+		From: models.User{ID: 123, Username: "alice", FirstName: "Alice"},
+		Message: models.MaybeInaccessibleMessage{
+			Type:    models.MaybeInaccessibleMessageTypeMessage,
+			Message: newTestMessage("📊 poll"),
+		},
+	}}
+
+	q := ConvertCallbackQuery(update)
+	require.NotNil(t, q)
+	assert.Equal(t, "cb1", q.ID)
+	assert.Equal(t, "vote:abc:1", q.Data)
+	assert.Equal(t, int64(123), q.UserID)
+	assert.Equal(t, "alice", q.Username)
+	assert.Equal(t, int64(-1001234567890), q.ChatID)
+	assert.Equal(t, 10, q.MessageID)
+
+	// 内联消息的回调没有所在聊天，忽略
+	update.CallbackQuery.Message = models.MaybeInaccessibleMessage{}
+	assert.Nil(t, ConvertCallbackQuery(update))
+	assert.Nil(t, ConvertCallbackQuery(&models.Update{Message: newTestMessage("hi")}))
+}
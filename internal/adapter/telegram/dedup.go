@@ -0,0 +1,38 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+	"telegram-bot/internal/adapter/cache"
+	"time"
+)
+
+// UpdateDeduplicator 更新去重器
+// Telegram（尤其是 webhook 重试）可能把同一个 update_id 投递多次，
+// 重复处理会导致重复封禁等副作用。记录已处理的 update_id，在 ttl 内重复出现时丢弃。
+// 存储可插拔：单实例使用内存缓存，多实例共享 Redis 缓存。
+type UpdateDeduplicator struct {
+	store cache.Cache
+	ttl   time.Duration
+}
+
+// NewUpdateDeduplicator 创建更新去重器
+func NewUpdateDeduplicator(store cache.Cache, ttl time.Duration) *UpdateDeduplicator {
+	return &UpdateDeduplicator{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// IsDuplicate 判断更新是否已处理过，未处理过时记录下来
+// 检查和记录是原子的，多个实例同时收到同一更新时只有一个会处理；
+// 存储出错时按未处理对待，宁可重复处理也不丢消息
+func (d *UpdateDeduplicator) IsDuplicate(ctx context.Context, updateID int64) bool {
+	key := "update:" + strconv.FormatInt(updateID, 10)
+
+	stored, err := d.store.SetNX(ctx, key, []byte{1}, d.ttl)
+	if err != nil {
+		return false
+	}
+	return !stored
+}
@@ -0,0 +1,46 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/adapter/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDeduplicator(t *testing.T) {
+	d := NewUpdateDeduplicator(cache.NewMemoryCache(100), time.Minute)
+	ctx := context.Background()
+
+	assert.False(t, d.IsDuplicate(ctx, 1001), "first delivery proceeds")
+	assert.True(t, d.IsDuplicate(ctx, 1001), "repeated update_id is dropped")
+	assert.False(t, d.IsDuplicate(ctx, 1002), "new update_id proceeds")
+}
+
+// failingCache 总是返回错误的缓存
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, assert.AnError
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return assert.AnError
+}
+
+func (failingCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return false, assert.AnError
+}
+
+func (failingCache) Delete(ctx context.Context, key string) error {
+	return assert.AnError
+}
+
+func TestUpdateDeduplicator_StoreErrorLetsUpdateThrough(t *testing.T) {
+	d := NewUpdateDeduplicator(failingCache{}, time.Minute)
+
+	assert.False(t, d.IsDuplicate(context.Background(), 1001))
+	assert.False(t, d.IsDuplicate(context.Background(), 1001))
+}
@@ -0,0 +1,203 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-telegram/bot"
+)
+
+// RetryConfig 重试配置
+type RetryConfig struct {
+	MaxAttempts  int           // 最大尝试次数（包含首次）
+	InitialDelay time.Duration // 首次重试前的等待时间
+	MaxDelay     time.Duration // 指数退避的最大等待时间
+	Multiplier   float64       // 退避倍数
+}
+
+// DefaultRetryConfig 默认重试配置
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+	}
+}
+
+// RetryStats 重试统计（自进程启动以来的累计值）
+// 重试后成功或重试耗尽的数量上升通常意味着 Telegram 服务不稳定
+type RetryStats struct {
+	Attempts            int64 `json:"attempts"`              // 调用总次数（含重试）
+	SucceededAfterRetry int64 `json:"succeeded_after_retry"` // 经过重试后成功的操作数
+	Exhausted           int64 `json:"exhausted"`             // 用尽重试次数仍失败的操作数
+}
+
+// Retrier Telegram API 调用重试器
+// 对 429 优先使用 Telegram 返回的 retry_after，其他可重试错误使用指数退避
+type Retrier struct {
+	config RetryConfig
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	attempts            atomic.Int64
+	succeededAfterRetry atomic.Int64
+	exhausted           atomic.Int64
+}
+
+// NewRetrier 创建重试器
+func NewRetrier(config RetryConfig) *Retrier {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &Retrier{
+		config: config,
+		sleep:  sleepContext,
+	}
+}
+
+// Do 执行操作，遇到可重试错误时按策略重试
+func (r *Retrier) Do(ctx context.Context, fn func() error) error {
+	delay := r.config.InitialDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		r.attempts.Add(1)
+		err = fn()
+		if err == nil {
+			if attempt > 1 {
+				r.succeededAfterRetry.Add(1)
+			}
+			return nil
+		}
+		if !IsRetryableError(err) {
+			return err
+		}
+		if attempt >= r.config.MaxAttempts {
+			r.exhausted.Add(1)
+			return err
+		}
+
+		wait := delay
+		if retryAfter, ok := RetryAfter(err); ok {
+			wait = retryAfter
+		}
+
+		if sleepErr := r.sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+
+		delay = time.Duration(float64(delay) * r.config.Multiplier)
+		if delay > r.config.MaxDelay {
+			delay = r.config.MaxDelay
+		}
+	}
+}
+
+// Stats 返回重试统计（并发安全）
+func (r *Retrier) Stats() RetryStats {
+	return RetryStats{
+		Attempts:            r.attempts.Load(),
+		SucceededAfterRetry: r.succeededAfterRetry.Load(),
+		Exhausted:           r.exhausted.Load(),
+	}
+}
+
+// IsRetryableError 判断错误是否值得重试
+// 429、网络错误和 Telegram 5xx 可重试；400/401/403/404/409 等客户端错误重试无意义
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var tooMany *bot.TooManyRequestsError
+	if errors.As(err, &tooMany) {
+		return true
+	}
+
+	for _, nonRetryable := range []error{
+		bot.ErrorBadRequest,
+		bot.ErrorUnauthorized,
+		bot.ErrorForbidden,
+		bot.ErrorNotFound,
+		bot.ErrorConflict,
+	} {
+		if errors.Is(err, nonRetryable) {
+			return false
+		}
+	}
+
+	msg := err.Error()
+	for _, pattern := range []string{
+		"error do request", // 网络错误
+		"Too Many Requests",
+		" 500 ", " 502 ", " 503 ", " 504 ",
+	} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPermissionError 判断错误是否因为机器人缺少管理员权限
+// 例如 "not enough rights to restrict/ban chat members"、"CHAT_ADMIN_REQUIRED"
+func IsPermissionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{
+		"not enough rights",
+		"chat_admin_required",
+		"need administrator rights",
+		"have no rights",
+		"bot is not an administrator",
+	} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsMessageNotFoundError 判断错误是否因为消息不存在（例如已被删除）
+func IsMessageNotFoundError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "message to delete not found")
+}
+
+// IsMessageNotModifiedError 判断错误是否因为编辑后的内容与原消息相同
+func IsMessageNotModifiedError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "message is not modified")
+}
+
+// RetryAfter 从 429 错误中获取 Telegram 要求的等待时间
+func RetryAfter(err error) (time.Duration, bool) {
+	var tooMany *bot.TooManyRequestsError
+	if errors.As(err, &tooMany) && tooMany.RetryAfter > 0 {
+		return time.Duration(tooMany.RetryAfter) * time.Second, true
+	}
+	return 0, false
+}
+
+// sleepContext 等待指定时间，context 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apperrors "telegram-bot/pkg/errors"
+
+	"github.com/go-telegram/bot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRecordingRetrier 创建记录等待时间而不实际等待的重试器
+func newRecordingRetrier(config RetryConfig) (*Retrier, *[]time.Duration) {
+	var waits []time.Duration
+	r := NewRetrier(config)
+	r.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return r, &waits
+}
+
+func TestRetrier_HonorsRetryAfter(t *testing.T) {
+	r, waits := newRecordingRetrier(DefaultRetryConfig())
+
+	calls := 0
+	err := r.Do(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return &bot.TooManyRequestsError{Message: "too many requests", RetryAfter: 2}
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestRetrier_ExponentialBackoff(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 4, InitialDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond, Multiplier: 2}
+	r, waits := newRecordingRetrier(config)
+
+	networkErr := fmt.Errorf("error do request for method sendMessage, connection reset")
+	calls := 0
+	err := r.Do(context.Background(), func() error {
+		calls++
+		return networkErr
+	})
+
+	assert.Equal(t, networkErr, err)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, *waits)
+}
+
+func TestRetrier_TooManyRequestsWithoutRetryAfter(t *testing.T) {
+	r, waits := newRecordingRetrier(DefaultRetryConfig())
+
+	calls := 0
+	_ = r.Do(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return &bot.TooManyRequestsError{Message: "too many requests"}
+		}
+		return nil
+	})
+
+	assert.Equal(t, []time.Duration{DefaultRetryConfig().InitialDelay}, *waits)
+}
+
+func TestRetrier_DoesNotRetryClientErrors(t *testing.T) {
+	r, waits := newRecordingRetrier(DefaultRetryConfig())
+
+	calls := 0
+	err := r.Do(context.Background(), func() error {
+		calls++
+		return fmt.Errorf("%w, not enough rights", bot.ErrorForbidden)
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *waits)
+}
+
+func TestRetrier_StopsWhenContextCancelled(t *testing.T) {
+	r := NewRetrier(DefaultRetryConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := r.Do(ctx, func() error {
+		calls++
+		return &bot.TooManyRequestsError{RetryAfter: 30}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetrier_Stats(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 2}
+	r, _ := newRecordingRetrier(config)
+	networkErr := fmt.Errorf("error do request for method sendMessage, connection reset")
+
+	// 首次成功
+	require.NoError(t, r.Do(context.Background(), func() error { return nil }))
+	assert.Equal(t, RetryStats{Attempts: 1}, r.Stats())
+
+	// 重试一次后成功
+	calls := 0
+	require.NoError(t, r.Do(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return networkErr
+		}
+		return nil
+	}))
+	assert.Equal(t, RetryStats{Attempts: 3, SucceededAfterRetry: 1}, r.Stats())
+
+	// 重试耗尽
+	assert.Error(t, r.Do(context.Background(), func() error { return networkErr }))
+	assert.Equal(t, RetryStats{Attempts: 6, SucceededAfterRetry: 1, Exhausted: 1}, r.Stats())
+
+	// 不可重试的错误不计入耗尽
+	assert.Error(t, r.Do(context.Background(), func() error { return bot.ErrorForbidden }))
+	assert.Equal(t, RetryStats{Attempts: 7, SucceededAfterRetry: 1, Exhausted: 1}, r.Stats())
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"429", &bot.TooManyRequestsError{RetryAfter: 1}, true},
+		{"network", fmt.Errorf("error do request for method getMe, timeout"), true},
+		{"server error", fmt.Errorf("error response from telegram for method sendMessage, 502 Bad Gateway"), true},
+		{"bad request", fmt.Errorf("%w, chat not found", bot.ErrorBadRequest), false},
+		{"forbidden", fmt.Errorf("%w, bot was kicked", bot.ErrorForbidden), false},
+		{"context cancelled", context.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryableError(tt.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	d, ok := RetryAfter(fmt.Errorf("wrapped: %w", &bot.TooManyRequestsError{RetryAfter: 5}))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	_, ok = RetryAfter(fmt.Errorf("other"))
+	assert.False(t, ok)
+}
+
+func TestIsPermissionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"not enough rights to ban", fmt.Errorf("%w, Bad Request: not enough rights to restrict/ban chat members", bot.ErrorBadRequest), true},
+		{"not enough rights to restrict", fmt.Errorf("%w, Bad Request: not enough rights", bot.ErrorBadRequest), true},
+		{"chat admin required", fmt.Errorf("%w, Bad Request: CHAT_ADMIN_REQUIRED", bot.ErrorBadRequest), true},
+		{"need administrator rights", fmt.Errorf("%w, Bad Request: need administrator rights in the channel chat", bot.ErrorBadRequest), true},
+		{"user not found", fmt.Errorf("%w, Bad Request: user not found", bot.ErrorBadRequest), false},
+		{"kicked", fmt.Errorf("%w, Forbidden: bot was kicked from the supergroup chat", bot.ErrorForbidden), false},
+		{"network", fmt.Errorf("error do request for method banChatMember, timeout"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsPermissionError(tt.err))
+		})
+	}
+}
+
+func TestIsMessageNotFoundError(t *testing.T) {
+	assert.False(t, IsMessageNotFoundError(nil))
+	assert.True(t, IsMessageNotFoundError(fmt.Errorf("%w, Bad Request: message to delete not found", bot.ErrorBadRequest)))
+	assert.False(t, IsMessageNotFoundError(fmt.Errorf("%w, Bad Request: message can't be deleted", bot.ErrorBadRequest)))
+}
+
+func TestIsMessageNotModifiedError(t *testing.T) {
+	assert.False(t, IsMessageNotModifiedError(nil))
+	assert.True(t, IsMessageNotModifiedError(fmt.Errorf("%w, Bad Request: message is not modified: specified new message content and reply markup are exactly the same", bot.ErrorBadRequest)))
+	assert.False(t, IsMessageNotModifiedError(fmt.Errorf("%w, Bad Request: message to edit not found", bot.ErrorBadRequest)))
+}
+
+func TestWrapMemberError(t *testing.T) {
+	assert.NoError(t, wrapMemberError(nil))
+
+	rightsErr := fmt.Errorf("%w, Bad Request: not enough rights to restrict/ban chat members", bot.ErrorBadRequest)
+	wrapped := wrapMemberError(rightsErr)
+	assert.True(t, apperrors.HasCode(wrapped, apperrors.CodeBotPermission))
+	assert.Equal(t, rightsErr, apperrors.Unwrap(wrapped))
+
+	other := fmt.Errorf("%w, Bad Request: user not found", bot.ErrorBadRequest)
+	assert.Same(t, other, wrapMemberError(other))
+}
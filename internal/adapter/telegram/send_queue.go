@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sendQueueCleanupThreshold 记录的聊天数超过该值时清理过期条目
+const sendQueueCleanupThreshold = 1000
+
+// SendQueue 出站消息发送队列
+// Telegram 限制同一聊天约每秒 1 条、全局约每秒 30 条，超出会被限流甚至丢弃。
+// 每次发送前调用 Wait 预约发送时间：同一聊天的发送按最小间隔排队，
+// 到达时间后再占用全局配额，因此一个聊天排队不会阻塞其他聊天。
+type SendQueue struct {
+	chatInterval   time.Duration // 同一聊天两次发送的最小间隔
+	globalInterval time.Duration // 全局两次发送的最小间隔
+
+	mu         sync.Mutex
+	nextChat   map[int64]time.Time
+	nextGlobal time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewSendQueue 创建发送队列
+// globalPerSecond <= 0 表示不限制全局速率
+func NewSendQueue(chatInterval time.Duration, globalPerSecond int) *SendQueue {
+	var globalInterval time.Duration
+	if globalPerSecond > 0 {
+		globalInterval = time.Second / time.Duration(globalPerSecond)
+	}
+
+	return &SendQueue{
+		chatInterval:   chatInterval,
+		globalInterval: globalInterval,
+		nextChat:       make(map[int64]time.Time),
+		now:            time.Now,
+		sleep:          sleepContext,
+	}
+}
+
+// Wait 等待直到可以向指定聊天发送消息
+// context 取消时返回错误，此时不应继续发送
+func (q *SendQueue) Wait(ctx context.Context, chatID int64) error {
+	// 1. 预约该聊天的发送时间并等待
+	if err := q.wait(ctx, q.reserveChat(chatID)); err != nil {
+		return err
+	}
+
+	// 2. 到达后再占用全局配额
+	return q.wait(ctx, q.reserveGlobal())
+}
+
+// reserveChat 预约聊天的下一个发送时间
+func (q *SendQueue) reserveChat(chatID int64) time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	slot := now
+	if next, ok := q.nextChat[chatID]; ok && next.After(slot) {
+		slot = next
+	}
+	q.nextChat[chatID] = slot.Add(q.chatInterval)
+
+	if len(q.nextChat) > sendQueueCleanupThreshold {
+		for id, next := range q.nextChat {
+			if !next.After(now) {
+				delete(q.nextChat, id)
+			}
+		}
+	}
+
+	return slot
+}
+
+// reserveGlobal 预约全局的下一个发送时间
+func (q *SendQueue) reserveGlobal() time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	slot := q.now()
+	if q.nextGlobal.After(slot) {
+		slot = q.nextGlobal
+	}
+	q.nextGlobal = slot.Add(q.globalInterval)
+	return slot
+}
+
+// wait 等待到指定时间
+func (q *SendQueue) wait(ctx context.Context, until time.Time) error {
+	if d := until.Sub(q.now()); d > 0 {
+		return q.sleep(ctx, d)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,83 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock 可控时钟，sleep 会直接推进时间
+type fakeClock struct {
+	mu      sync.Mutex
+	current time.Time
+	sleeps  []time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.current = c.current.Add(d)
+	return nil
+}
+
+func newTestSendQueue(chatInterval time.Duration, globalPerSecond int) (*SendQueue, *fakeClock) {
+	clock := &fakeClock{current: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	q := NewSendQueue(chatInterval, globalPerSecond)
+	q.now = clock.now
+	q.sleep = clock.sleep
+	return q, clock
+}
+
+func TestSendQueue_SpacesSendsToSameChat(t *testing.T) {
+	q, clock := newTestSendQueue(time.Second, 0)
+	start := clock.now()
+
+	require.NoError(t, q.Wait(context.Background(), -100))
+	assert.Equal(t, start, clock.now())
+
+	require.NoError(t, q.Wait(context.Background(), -100))
+	assert.Equal(t, start.Add(time.Second), clock.now())
+}
+
+func TestSendQueue_DifferentChatsNotBlocked(t *testing.T) {
+	q, clock := newTestSendQueue(time.Second, 0)
+
+	require.NoError(t, q.Wait(context.Background(), -100))
+	require.NoError(t, q.Wait(context.Background(), -200))
+	require.NoError(t, q.Wait(context.Background(), -300))
+
+	assert.Empty(t, clock.sleeps)
+}
+
+func TestSendQueue_GlobalCap(t *testing.T) {
+	q, clock := newTestSendQueue(time.Second, 10)
+	start := clock.now()
+
+	for chatID := int64(1); chatID <= 3; chatID++ {
+		require.NoError(t, q.Wait(context.Background(), chatID))
+	}
+
+	// 每秒 10 条 => 第三条最早在 200ms 后发送
+	assert.Equal(t, start.Add(200*time.Millisecond), clock.now())
+}
+
+func TestSendQueue_CancelledContext(t *testing.T) {
+	q := NewSendQueue(time.Hour, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, q.Wait(ctx, -100))
+	cancel()
+
+	assert.ErrorIs(t, q.Wait(ctx, -100), context.Canceled)
+}
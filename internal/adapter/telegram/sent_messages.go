@@ -0,0 +1,73 @@
+package telegram
+
+import "sync"
+
+// SentMessages 记录机器人在各聊天中最近发送的消息 ID
+// 每个聊天最多保留 perChat 条，超出时丢弃最早的记录；仅保存在内存中，重启后清空
+type SentMessages struct {
+	perChat int
+
+	mu    sync.Mutex
+	chats map[int64][]int // 按发送顺序排列，末尾为最新
+}
+
+// NewSentMessages 创建已发送消息记录
+func NewSentMessages(perChat int) *SentMessages {
+	return &SentMessages{
+		perChat: perChat,
+		chats:   make(map[int64][]int),
+	}
+}
+
+// RecordSent 记录一条已发送的消息
+func (s *SentMessages) RecordSent(chatID int64, messageID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := append(s.chats[chatID], messageID)
+	if len(ids) > s.perChat {
+		ids = ids[len(ids)-s.perChat:]
+	}
+	s.chats[chatID] = ids
+}
+
+// Recent 返回聊天中最近发送的 n 条消息 ID（最新的在前）
+func (s *SentMessages) Recent(chatID int64, n int) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.chats[chatID]
+	if n > len(ids) {
+		n = len(ids)
+	}
+
+	recent := make([]int, 0, n)
+	for i := len(ids) - 1; i >= len(ids)-n; i-- {
+		recent = append(recent, ids[i])
+	}
+	return recent
+}
+
+// Forget 删除聊天中指定消息的记录（消息已删除或无法删除时调用）
+func (s *SentMessages) Forget(chatID int64, messageIDs []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	forget := make(map[int]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		forget[id] = true
+	}
+
+	kept := s.chats[chatID][:0]
+	for _, id := range s.chats[chatID] {
+		if !forget[id] {
+			kept = append(kept, id)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(s.chats, chatID)
+		return
+	}
+	s.chats[chatID] = kept
+}
@@ -0,0 +1,35 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentMessages_RecentAndCap(t *testing.T) {
+	s := NewSentMessages(3)
+
+	for id := 1; id <= 5; id++ {
+		s.RecordSent(-100, id)
+	}
+	s.RecordSent(-200, 42)
+
+	// 每个聊天只保留最近 3 条，最新的在前
+	assert.Equal(t, []int{5, 4, 3}, s.Recent(-100, 10))
+	assert.Equal(t, []int{5, 4}, s.Recent(-100, 2))
+	assert.Equal(t, []int{42}, s.Recent(-200, 10))
+	assert.Empty(t, s.Recent(-300, 10))
+}
+
+func TestSentMessages_Forget(t *testing.T) {
+	s := NewSentMessages(10)
+	s.RecordSent(-100, 1)
+	s.RecordSent(-100, 2)
+	s.RecordSent(-100, 3)
+
+	s.Forget(-100, []int{3, 1})
+	assert.Equal(t, []int{2}, s.Recent(-100, 10))
+
+	s.Forget(-100, []int{2})
+	assert.Empty(t, s.Recent(-100, 10))
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -17,44 +18,160 @@ type Config struct {
 	// MongoDB 配置
 	MongoURI     string
 	DatabaseName string
-	MongoTimeout time.Duration
+	MongoTimeout time.Duration // 单次连接（含 Ping）的超时
+
+	MongoConnectAttempts     int           // 启动时连接 MongoDB 的最大尝试次数
+	MongoConnectTotalTimeout time.Duration // 启动时连接 MongoDB 的总时长上限（含重试等待）
 
 	// 应用配置
-	Environment string
-	LogLevel    string
-	LogFormat   string // "text" 或 "json"
-	Port        int
+	Environment         string
+	LogLevel            string
+	LogLevelRevertAfter time.Duration // /loglevel 临时修改日志级别后自动恢复为 LogLevel 的时间
+	LogFormat           string        // "text" 或 "json"
+	LogRedactMessages   bool          // 记录消息文本前脱敏（Email、手机号、Token 等）
+	LogRedactPatterns   []string      // 额外的脱敏正则，匹配内容替换为 ***
+	Port                int
+
+	// 缓存配置
+	CacheBackend            string        // "memory"（默认）或 "redis"
+	GroupCacheTTL           time.Duration // 群组配置缓存时间
+	UserCacheTTL            time.Duration // 用户缓存时间
+	GroupCacheRepairPercent int           // 群组缓存命中时回源校验的百分比（0 表示不校验），用于纠正其他实例写入后的过时缓存
+	CacheMaxEntries         int           // 内存缓存最大条目数，超出时淘汰最久未使用的条目
+	UpdateDedupTTL          time.Duration // 已处理 update_id 的记录时间，期间重复投递的更新会被丢弃
+	UpdateTimeout           time.Duration // 单条更新的处理期限（含重试等待），超时后放弃处理；0 表示不限制
+	RedisAddr               string
+	RedisPassword           string
+	RedisDB                 int
 
 	// 限流配置
 	RateLimitEnabled bool
 	RateLimitPerMin  int
 
+	// 发送队列配置（Telegram 出站限速）
+	SendChatInterval    time.Duration // 同一聊天两次发送的最小间隔
+	SendGlobalPerSecond int           // 全局每秒最多发送条数
+
+	// 消息计数配置（缓冲后批量写入数据库）
+	MessageCountFlushInterval  time.Duration // 定时写入间隔
+	MessageCountFlushThreshold int           // 累计多少次后立即写入
+
+	// 多实例部署配置
+	SchedulerLockEnabled  bool          // 启用后定时任务通过 MongoDB 锁保证只在一个实例上执行
+	LeaderElectionEnabled bool          // 启用后只有主节点处理更新（主备部署）
+	LeaderLease           time.Duration // 主节点租约时长，主节点宕机后备用节点最多等待该时长接管
+
+	// 健康检查配置
+	HealthServerEnabled   bool          // 在 Port 上提供 /health 和 /health/live
+	DebugEndpointsEnabled bool          // 在健康检查服务上额外提供 /debug/commands（会暴露内部信息）
+	HealthCheckCacheTTL   time.Duration // MongoDB/Telegram 检查结果的缓存时间，期间的探针不再访问依赖
+	HealthCheckInterval   time.Duration // 后台依赖检查间隔，/health 直接返回最近一轮结果；0 表示在请求中检查
+
 	// 监控配置
 	MetricsEnabled bool
 	MetricsPort    int
 
 	// 权限配置
 	OwnerUserIDs []int64 // 初始Owner用户ID列表
+
+	// 维护模式配置
+	MaintenancePauseListeners bool // 维护模式下是否同时暂停关键词、正则和监听器（命令总是暂停）
+
+	// 命令配置
+	CommandPrefixes    []string                 // 命令前缀，如 "/"、"!"
+	MembershipCacheTTL time.Duration            // 要求群组成员资格的命令查询成员状态后的缓存时间
+	UserSeenInterval   time.Duration            // 同一用户的最近活跃时间最多每隔多久写入一次
+	CommandCooldowns   map[string]time.Duration // 按命令名配置的每用户冷却时间，如 report=5m
+	CommandDailyQuotas map[string]int           // 按命令名配置的每群组每日使用次数，如 cleanup=5
+	CommandMaxArgs     int                      // 命令参数个数上限，超过时拒绝（0 表示不限制）
+	CommandMaxLength   int                      // 命令文本长度上限（字符），超过时拒绝（0 表示不限制）
+
+	PruneStaleCommandConfigs bool // 是否删除群组中已移除命令的启用/禁用配置（默认 false，只记录警告）
+
+	// 防突袭配置
+	AntiRaidJoinThreshold    int           // 统计窗口内入群人数达到该值时触发防突袭模式
+	AntiRaidWindow           time.Duration // 入群统计窗口
+	AntiRaidQuietPeriod      time.Duration // 无人入群超过该时长后自动解除
+	AntiRaidRestrictDuration time.Duration // 防突袭模式下新成员的禁言时长
+
+	// 趣味命令配置
+	ActionCooldown time.Duration // /slap、/hug 等动作命令的冷却时间
+	ActionsFile    string        // 自定义动作集合的 JSON 文件（为空时使用内置的 /slap、/hug）
+
+	// 用户反馈配置
+	FeedbackCooldown time.Duration // 同一用户两次 /feedback 提交的最小间隔
 }
 
 // Load 加载配置
 func Load() (*Config, error) {
 	cfg := &Config{
-		TelegramToken:    getEnv("TELEGRAM_TOKEN", ""),
-		Debug:            getEnvBool("DEBUG", false),
-		MongoURI:         getEnv("MONGO_URI", ""),
-		DatabaseName:     getEnv("DATABASE_NAME", "telegram_bot"),
-		MongoTimeout:     getEnvDuration("MONGO_TIMEOUT", 10*time.Second),
-		Environment:      getEnv("ENVIRONMENT", "development"),
-		LogLevel:         getEnv("LOG_LEVEL", "info"),
-		LogFormat:        getEnv("LOG_FORMAT", "text"),
-		Port:             getEnvInt("PORT", 8080),
-		RateLimitEnabled: getEnvBool("RATE_LIMIT_ENABLED", true),
-		RateLimitPerMin:  getEnvInt("RATE_LIMIT_PER_MIN", 20),
-		MetricsEnabled:   getEnvBool("METRICS_ENABLED", true),
-		MetricsPort:      getEnvInt("METRICS_PORT", 9091),
-		OwnerUserIDs:     getEnvInt64Slice("BOT_OWNER_IDS", []int64{}),
+		TelegramToken:              getEnv("TELEGRAM_TOKEN", ""),
+		Debug:                      getEnvBool("DEBUG", false),
+		MongoURI:                   getEnv("MONGO_URI", ""),
+		DatabaseName:               getEnv("DATABASE_NAME", "telegram_bot"),
+		MongoTimeout:               getEnvDuration("MONGO_TIMEOUT", 10*time.Second),
+		MongoConnectAttempts:       getEnvInt("MONGO_CONNECT_ATTEMPTS", 5),
+		MongoConnectTotalTimeout:   getEnvDuration("MONGO_CONNECT_TOTAL_TIMEOUT", time.Minute),
+		Environment:                getEnv("ENVIRONMENT", "development"),
+		LogLevel:                   getEnv("LOG_LEVEL", "info"),
+		LogLevelRevertAfter:        getEnvDuration("LOG_LEVEL_REVERT_AFTER", 30*time.Minute),
+		LogFormat:                  getEnv("LOG_FORMAT", "text"),
+		LogRedactMessages:          getEnvBool("LOG_REDACT_MESSAGES", true),
+		LogRedactPatterns:          getEnvFields("LOG_REDACT_PATTERNS"),
+		Port:                       getEnvInt("PORT", 8080),
+		CacheBackend:               getEnv("CACHE_BACKEND", "memory"),
+		GroupCacheTTL:              getEnvDuration("GROUP_CACHE_TTL", 5*time.Minute),
+		UserCacheTTL:               getEnvDuration("USER_CACHE_TTL", 5*time.Minute),
+		GroupCacheRepairPercent:    getEnvInt("GROUP_CACHE_REPAIR_PERCENT", 1),
+		CacheMaxEntries:            getEnvInt("CACHE_MAX_ENTRIES", 10000),
+		UpdateDedupTTL:             getEnvDuration("UPDATE_DEDUP_TTL", 10*time.Minute),
+		UpdateTimeout:              getEnvDuration("UPDATE_TIMEOUT", 20*time.Second),
+		RedisAddr:                  getEnv("REDIS_ADDR", ""),
+		RedisPassword:              getEnv("REDIS_PASSWORD", ""),
+		RedisDB:                    getEnvInt("REDIS_DB", 0),
+		RateLimitEnabled:           getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitPerMin:            getEnvInt("RATE_LIMIT_PER_MIN", 20),
+		SendChatInterval:           getEnvDuration("SEND_CHAT_INTERVAL", time.Second),
+		SendGlobalPerSecond:        getEnvInt("SEND_GLOBAL_PER_SECOND", 30),
+		MessageCountFlushInterval:  getEnvDuration("MESSAGE_COUNT_FLUSH_INTERVAL", 30*time.Second),
+		MessageCountFlushThreshold: getEnvInt("MESSAGE_COUNT_FLUSH_THRESHOLD", 500),
+		SchedulerLockEnabled:       getEnvBool("SCHEDULER_LOCK_ENABLED", false),
+		LeaderElectionEnabled:      getEnvBool("LEADER_ELECTION_ENABLED", false),
+		LeaderLease:                getEnvDuration("LEADER_LEASE", 15*time.Second),
+		HealthServerEnabled:        getEnvBool("HEALTH_SERVER_ENABLED", false),
+		DebugEndpointsEnabled:      getEnvBool("DEBUG_ENDPOINTS_ENABLED", false),
+		HealthCheckCacheTTL:        getEnvDuration("HEALTH_CHECK_CACHE_TTL", 10*time.Second),
+		HealthCheckInterval:        getEnvDuration("HEALTH_CHECK_INTERVAL", 15*time.Second),
+		MetricsEnabled:             getEnvBool("METRICS_ENABLED", true),
+		MetricsPort:                getEnvInt("METRICS_PORT", 9091),
+		OwnerUserIDs:               getEnvInt64Slice("BOT_OWNER_IDS", []int64{}),
+		MaintenancePauseListeners:  getEnvBool("MAINTENANCE_PAUSE_LISTENERS", false),
+		CommandPrefixes:            getEnvStringSlice("COMMAND_PREFIXES", []string{"/"}),
+		CommandMaxArgs:             getEnvInt("COMMAND_MAX_ARGS", 1000),
+		CommandMaxLength:           getEnvInt("COMMAND_MAX_LENGTH", 0),
+		PruneStaleCommandConfigs:   getEnvBool("PRUNE_STALE_COMMAND_CONFIGS", false),
+		MembershipCacheTTL:         getEnvDuration("MEMBERSHIP_CACHE_TTL", 5*time.Minute),
+		UserSeenInterval:           getEnvDuration("USER_SEEN_INTERVAL", 10*time.Minute),
+		AntiRaidJoinThreshold:      getEnvInt("ANTI_RAID_JOIN_THRESHOLD", 10),
+		AntiRaidWindow:             getEnvDuration("ANTI_RAID_WINDOW", time.Minute),
+		AntiRaidQuietPeriod:        getEnvDuration("ANTI_RAID_QUIET_PERIOD", 10*time.Minute),
+		AntiRaidRestrictDuration:   getEnvDuration("ANTI_RAID_RESTRICT_DURATION", time.Hour),
+		ActionCooldown:             getEnvDuration("ACTION_COOLDOWN", 30*time.Second),
+		ActionsFile:                getEnv("ACTIONS_FILE", ""),
+		FeedbackCooldown:           getEnvDuration("FEEDBACK_COOLDOWN", 5*time.Minute),
+	}
+
+	cooldowns, err := parseCommandCooldowns(getEnvStringSlice("COMMAND_COOLDOWNS", nil))
+	if err != nil {
+		return nil, err
 	}
+	cfg.CommandCooldowns = cooldowns
+
+	quotas, err := parseCommandQuotas(getEnvStringSlice("COMMAND_DAILY_QUOTAS", nil))
+	if err != nil {
+		return nil, err
+	}
+	cfg.CommandDailyQuotas = quotas
 
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -77,6 +194,72 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DATABASE_NAME is required")
 	}
 
+	if c.MongoTimeout <= 0 {
+		return fmt.Errorf("MONGO_TIMEOUT must be positive")
+	}
+
+	if c.MongoConnectAttempts < 1 {
+		return fmt.Errorf("MONGO_CONNECT_ATTEMPTS must be at least 1")
+	}
+
+	if c.LeaderElectionEnabled && c.LeaderLease < 3*time.Second {
+		return fmt.Errorf("LEADER_LEASE must be at least 3s")
+	}
+
+	for _, prefix := range c.CommandPrefixes {
+		if strings.ContainsAny(prefix, "@ ") {
+			return fmt.Errorf("COMMAND_PREFIXES contains invalid prefix %q", prefix)
+		}
+	}
+
+	if c.CommandMaxArgs < 0 || c.CommandMaxLength < 0 {
+		return fmt.Errorf("COMMAND_MAX_ARGS and COMMAND_MAX_LENGTH must not be negative")
+	}
+
+	if c.MessageCountFlushInterval <= 0 || c.MessageCountFlushThreshold <= 0 {
+		return fmt.Errorf("MESSAGE_COUNT_FLUSH_INTERVAL and MESSAGE_COUNT_FLUSH_THRESHOLD must be positive")
+	}
+
+	if c.UserCacheTTL <= 0 {
+		return fmt.Errorf("USER_CACHE_TTL must be positive")
+	}
+
+	if c.MembershipCacheTTL <= 0 {
+		return fmt.Errorf("MEMBERSHIP_CACHE_TTL must be positive")
+	}
+
+	if c.UserSeenInterval <= 0 {
+		return fmt.Errorf("USER_SEEN_INTERVAL must be positive")
+	}
+
+	for _, p := range c.LogRedactPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("LOG_REDACT_PATTERNS contains invalid pattern %q: %w", p, err)
+		}
+	}
+
+	if c.GroupCacheRepairPercent < 0 || c.GroupCacheRepairPercent > 100 {
+		return fmt.Errorf("GROUP_CACHE_REPAIR_PERCENT must be between 0 and 100")
+	}
+
+	if c.AntiRaidJoinThreshold < 2 {
+		return fmt.Errorf("ANTI_RAID_JOIN_THRESHOLD must be at least 2")
+	}
+
+	if c.AntiRaidWindow <= 0 || c.AntiRaidQuietPeriod <= 0 {
+		return fmt.Errorf("ANTI_RAID_WINDOW and ANTI_RAID_QUIET_PERIOD must be positive")
+	}
+
+	switch c.CacheBackend {
+	case "memory":
+	case "redis":
+		if c.RedisAddr == "" {
+			return fmt.Errorf("REDIS_ADDR is required when CACHE_BACKEND=redis")
+		}
+	default:
+		return fmt.Errorf("CACHE_BACKEND must be \"memory\" or \"redis\", got %q", c.CacheBackend)
+	}
+
 	return nil
 }
 
@@ -128,6 +311,68 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// getEnvStringSlice 获取字符串切片类型环境变量（逗号分隔，忽略空项）
+func getEnvStringSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	result := make([]string, 0)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+
+	if len(result) == 0 {
+		return defaultValue
+	}
+	return result
+}
+
+// parseCommandCooldowns 解析命令冷却配置，格式为 "命令名=时长"，如 report=5m,slap=30s
+func parseCommandCooldowns(specs []string) (map[string]time.Duration, error) {
+	cooldowns := make(map[string]time.Duration, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("COMMAND_COOLDOWNS contains invalid entry %q, expected name=duration", spec)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("COMMAND_COOLDOWNS contains invalid duration in %q", spec)
+		}
+		cooldowns[name] = d
+	}
+	return cooldowns, nil
+}
+
+// parseCommandQuotas 解析命令每日配额配置，格式为 "命令名=次数"，如 cleanup=5,stats=20
+func parseCommandQuotas(specs []string) (map[string]int, error) {
+	quotas := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("COMMAND_DAILY_QUOTAS contains invalid entry %q, expected name=count", spec)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("COMMAND_DAILY_QUOTAS contains invalid count in %q", spec)
+		}
+		quotas[name] = n
+	}
+	return quotas, nil
+}
+
+// getEnvFields 获取以空白分隔的字符串列表环境变量（用于可能包含逗号的正则等）
+func getEnvFields(key string) []string {
+	return strings.Fields(os.Getenv(key))
+}
+
 // getEnvInt64Slice 获取int64切片类型环境变量（逗号分隔）
 func getEnvInt64Slice(key string, defaultValue []int64) []int64 {
 	value := os.Getenv(key)
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadTestConfig 用必填项加载默认配置
+func loadTestConfig(t *testing.T) *Config {
+	t.Helper()
+	t.Setenv("TELEGRAM_TOKEN", "test-token")
+	t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestValidate_NonPositiveIntervals(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{"flush interval", func(c *Config) { c.MessageCountFlushInterval = 0 }},
+		{"flush threshold", func(c *Config) { c.MessageCountFlushThreshold = -1 }},
+		{"anti-raid window", func(c *Config) { c.AntiRaidWindow = 0 }},
+		{"anti-raid quiet period", func(c *Config) { c.AntiRaidQuietPeriod = -time.Second }},
+		{"user cache ttl", func(c *Config) { c.UserCacheTTL = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadTestConfig(t)
+			require.NoError(t, cfg.Validate())
+
+			tt.modify(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestLoad_LogRedaction(t *testing.T) {
+	t.Setenv("LOG_REDACT_MESSAGES", "false")
+	t.Setenv("LOG_REDACT_PATTERNS", `sk-[A-Za-z0-9]{20,} ghp_[A-Za-z0-9]{36}`)
+
+	cfg := loadTestConfig(t)
+	assert.False(t, cfg.LogRedactMessages)
+	assert.Equal(t, []string{`sk-[A-Za-z0-9]{20,}`, `ghp_[A-Za-z0-9]{36}`}, cfg.LogRedactPatterns)
+}
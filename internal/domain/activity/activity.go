@@ -0,0 +1,79 @@
+package activity
+
+import (
+	"context"
+	"time"
+)
+
+// DayLayout 按天统计使用的日期格式（UTC）
+const DayLayout = "2006-01-02"
+
+// Key 消息计数的维度：某个用户在某个群组
+type Key struct {
+	GroupID int64
+	UserID  int64
+}
+
+// Metric 按天统计的指标
+type Metric string
+
+const (
+	MetricMessages   Metric = "messages"           // 消息数
+	MetricCommands   Metric = "commands"           // 命令数
+	MetricModeration Metric = "moderation_actions" // 管理操作数（封禁等）
+)
+
+// BucketKey 按天统计的维度：某个群组某天的某项指标
+type BucketKey struct {
+	GroupID int64
+	Day     string // YYYY-MM-DD（UTC）
+	Metric  Metric
+}
+
+// Bucket 群组某天的统计
+type Bucket struct {
+	GroupID           int64
+	Day               string
+	Messages          int64
+	Commands          int64
+	ModerationActions int64
+}
+
+// CommandKey 命令使用次数的维度：某个命令在某个群组
+type CommandKey struct {
+	GroupID int64
+	Command string
+}
+
+// CommandUsage 命令使用次数
+type CommandUsage struct {
+	Command string
+	Count   int64
+}
+
+// GroupMessages 群组在一段时间内的消息数
+type GroupMessages struct {
+	GroupID  int64
+	Messages int64
+}
+
+// DayOf 返回时间所在的统计日期
+func DayOf(t time.Time) string {
+	return t.UTC().Format(DayLayout)
+}
+
+// Repository 消息计数仓储接口
+type Repository interface {
+	// IncrementMessageCounts 批量累加消息数，不存在的记录会被创建
+	IncrementMessageCounts(ctx context.Context, counts map[Key]int64) error
+	// IncrementBuckets 批量累加按天统计，不存在的记录会被创建
+	IncrementBuckets(ctx context.Context, counts map[BucketKey]int64) error
+	// FindBuckets 查询群组在日期范围内（包含首尾）的按天统计，按日期升序
+	FindBuckets(ctx context.Context, groupID int64, from, to string) ([]*Bucket, error)
+	// IncrementCommandUsage 批量累加命令使用次数，不存在的记录会被创建
+	IncrementCommandUsage(ctx context.Context, counts map[CommandKey]int64) error
+	// FindCommandUsage 查询群组内所有命令的使用次数（无序）
+	FindCommandUsage(ctx context.Context, groupID int64) ([]*CommandUsage, error)
+	// TopGroupsByMessages 查询日期范围内（包含首尾）消息数最多的 n 个群组，按消息数降序
+	TopGroupsByMessages(ctx context.Context, from, to string, n int) ([]*GroupMessages, error)
+}
@@ -0,0 +1,35 @@
+package feedback
+
+import (
+	"context"
+	"time"
+)
+
+// Feedback 用户反馈
+// 用户通过 /feedback 提交的意见或问题报告，由机器人所有者查看
+type Feedback struct {
+	UserID    int64
+	Username  string
+	GroupID   int64 // 提交所在的群组，私聊提交时为 0
+	Text      string
+	CreatedAt time.Time
+}
+
+// NewFeedback 创建反馈
+func NewFeedback(userID int64, username string, groupID int64, text string) *Feedback {
+	return &Feedback{
+		UserID:    userID,
+		Username:  username,
+		GroupID:   groupID,
+		Text:      text,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Repository 反馈仓储接口
+type Repository interface {
+	Create(ctx context.Context, f *Feedback) error
+	// ListRecent 按时间倒序返回反馈，groupID 为 0 时不按群组过滤
+	// before 非零时只返回早于 before 的记录（分页游标）
+	ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*Feedback, error)
+}
@@ -3,9 +3,26 @@ package group
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 )
 
+const (
+	SettingShadowBanned      = "shadow_banned"        // 影子封禁的用户 ID 列表
+	SettingProtectedUsers    = "protected_users"      // 不受自动管理（如防突袭禁言）影响的用户 ID 列表
+	SettingDefaultPermission = "default_permission"   // 新用户在本群的默认权限（"none" 或 "user"）
+	SettingTimezone          = "timezone"             // 面向用户的时间使用的时区（IANA 名称，如 Asia/Shanghai）
+	SettingTimeFormat        = "time_format"          // 面向用户的时间显示方式（"absolute" 或 "relative"）
+	SettingAutoDeleteSeconds = "auto_delete_seconds"  // 机器人回复消息在多少秒后自动删除（0 或未设置表示不删除）
+	SettingReasonTemplates   = "reason_templates"     // 管理操作原因模板（名称 → 原因文本）
+	SettingMinAccountAgeDays = "min_account_age_days" // 新成员账号的最小年龄（天，0 或未设置表示不检查）
+	SettingRules             = "rules"                // 群规文本
+	SettingRulesAckMinutes   = "rules_ack_minutes"    // 新成员同意群规的时限（分钟，0 或未设置表示不要求同意）
+)
+
 var (
 	ErrGroupNotFound = errors.New("group not found")
 )
@@ -98,6 +115,19 @@ func (g *Group) GetCommandConfig(commandName string) *CommandConfig {
 	}
 }
 
+// StaleCommands 返回 known 中不存在的命令的配置（按命令名排序）
+// 命令从代码中移除后，群组文档中仍会残留其启用/禁用配置
+func (g *Group) StaleCommands(known map[string]bool) []string {
+	var stale []string
+	for name := range g.Commands {
+		if !known[name] {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
 // SetSetting 设置群组配置项
 func (g *Group) SetSetting(key string, value interface{}) {
 	g.Settings[key] = value
@@ -110,6 +140,180 @@ func (g *Group) GetSetting(key string) (interface{}, bool) {
 	return value, ok
 }
 
+// GetStringSetting 获取字符串类型的配置项
+// 配置项不存在或类型不是字符串时返回 false
+func (g *Group) GetStringSetting(key string) (string, bool) {
+	value, ok := g.Settings[key].(string)
+	return value, ok
+}
+
+// DeleteSetting 删除配置项
+func (g *Group) DeleteSetting(key string) {
+	delete(g.Settings, key)
+	g.UpdatedAt = time.Now()
+}
+
+// GetInt64Setting 获取整数类型的配置项
+// 配置经数据库或缓存序列化后数字类型会变化（如 int32、float64），统一转换为 int64
+func (g *Group) GetInt64Setting(key string) (int64, bool) {
+	switch n := g.Settings[key].(type) {
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// GetInt64ListSetting 获取整数列表类型的配置项
+// 配置经数据库或缓存序列化后列表和元素类型会变化（如 []interface{}、float64），统一转换为 []int64
+func (g *Group) GetInt64ListSetting(key string) []int64 {
+	v := reflect.ValueOf(g.Settings[key])
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+
+	ids := make([]int64, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		switch n := v.Index(i).Interface().(type) {
+		case int64:
+			ids = append(ids, n)
+		case int32:
+			ids = append(ids, int64(n))
+		case int:
+			ids = append(ids, int64(n))
+		case float64:
+			ids = append(ids, int64(n))
+		}
+	}
+	return ids
+}
+
+// GetStringMapSetting 获取字符串映射类型的配置项
+// 配置经数据库或缓存序列化后映射类型会变化（如 map[string]interface{}、primitive.M），统一转换为 map[string]string
+func (g *Group) GetStringMapSetting(key string) map[string]string {
+	v := reflect.ValueOf(g.Settings[key])
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	m := make(map[string]string, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		if s, ok := iter.Value().Interface().(string); ok {
+			m[iter.Key().String()] = s
+		}
+	}
+	return m
+}
+
+// ReasonTemplate 获取原因模板，名称不区分大小写
+func (g *Group) ReasonTemplate(name string) (string, bool) {
+	text, ok := g.GetStringMapSetting(SettingReasonTemplates)[strings.ToLower(name)]
+	return text, ok
+}
+
+// SetReasonTemplate 添加或覆盖原因模板
+func (g *Group) SetReasonTemplate(name, text string) {
+	templates := g.GetStringMapSetting(SettingReasonTemplates)
+	if templates == nil {
+		templates = make(map[string]string)
+	}
+	templates[strings.ToLower(name)] = text
+	g.SetSetting(SettingReasonTemplates, templates)
+}
+
+// DeleteReasonTemplate 删除原因模板，模板不存在时返回 false
+func (g *Group) DeleteReasonTemplate(name string) bool {
+	templates := g.GetStringMapSetting(SettingReasonTemplates)
+	name = strings.ToLower(name)
+	if _, ok := templates[name]; !ok {
+		return false
+	}
+
+	delete(templates, name)
+	if len(templates) == 0 {
+		g.DeleteSetting(SettingReasonTemplates)
+	} else {
+		g.SetSetting(SettingReasonTemplates, templates)
+	}
+	return true
+}
+
+// IsShadowBanned 检查用户是否被影子封禁
+func (g *Group) IsShadowBanned(userID int64) bool {
+	return g.hasListID(SettingShadowBanned, userID)
+}
+
+// ShadowBan 影子封禁用户，已封禁时返回 false
+func (g *Group) ShadowBan(userID int64) bool {
+	return g.addListID(SettingShadowBanned, userID)
+}
+
+// LiftShadowBan 解除影子封禁，未封禁时返回 false
+func (g *Group) LiftShadowBan(userID int64) bool {
+	return g.removeListID(SettingShadowBanned, userID)
+}
+
+// IsProtected 检查用户是否受保护（自动管理跳过该用户）
+func (g *Group) IsProtected(userID int64) bool {
+	return g.hasListID(SettingProtectedUsers, userID)
+}
+
+// Protect 将用户加入保护列表，已在列表中时返回 false
+func (g *Group) Protect(userID int64) bool {
+	return g.addListID(SettingProtectedUsers, userID)
+}
+
+// Unprotect 将用户移出保护列表，不在列表中时返回 false
+func (g *Group) Unprotect(userID int64) bool {
+	return g.removeListID(SettingProtectedUsers, userID)
+}
+
+// hasListID 检查用户 ID 列表配置项中是否包含 userID
+func (g *Group) hasListID(key string, userID int64) bool {
+	for _, id := range g.GetInt64ListSetting(key) {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// addListID 向用户 ID 列表配置项添加 userID，已存在时返回 false
+func (g *Group) addListID(key string, userID int64) bool {
+	if g.hasListID(key, userID) {
+		return false
+	}
+	g.SetSetting(key, append(g.GetInt64ListSetting(key), userID))
+	return true
+}
+
+// removeListID 从用户 ID 列表配置项移除 userID，不存在时返回 false；列表为空时删除配置项
+func (g *Group) removeListID(key string, userID int64) bool {
+	ids := g.GetInt64ListSetting(key)
+	kept := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id != userID {
+			kept = append(kept, id)
+		}
+	}
+	if len(kept) == len(ids) {
+		return false
+	}
+
+	if len(kept) == 0 {
+		g.DeleteSetting(key)
+	} else {
+		g.SetSetting(key, kept)
+	}
+	return true
+}
+
 // IsFeatureEnabled 检查功能是否启用
 // 如果功能未配置，默认返回 true（默认启用）
 func (g *Group) IsFeatureEnabled(featureName string) bool {
@@ -122,6 +326,13 @@ func (g *Group) IsFeatureEnabled(featureName string) bool {
 	return true
 }
 
+// IsFeatureOptedIn 检查需要显式启用的功能是否已启用
+// 与 IsFeatureEnabled 不同，未配置时返回 false（默认关闭）
+func (g *Group) IsFeatureOptedIn(featureName string) bool {
+	enabled, ok := g.Settings[featureName].(bool)
+	return ok && enabled
+}
+
 // EnableFeature 启用功能
 func (g *Group) EnableFeature(featureName string) {
 	g.Settings[featureName] = true
@@ -134,6 +345,61 @@ func (g *Group) DisableFeature(featureName string) {
 	g.UpdatedAt = time.Now()
 }
 
+// Location 返回群组时区，未设置或无法解析时为 UTC
+func (g *Group) Location() *time.Location {
+	name, ok := g.GetStringSetting(SettingTimezone)
+	if !ok {
+		return time.UTC
+	}
+	loc, err := LoadTimezone(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// AutoDeleteAfter 返回机器人回复消息的自动删除时间，未开启时为 0
+func (g *Group) AutoDeleteAfter() time.Duration {
+	seconds, ok := g.GetInt64Setting(SettingAutoDeleteSeconds)
+	if !ok || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// MinAccountAge 返回新成员账号的最小年龄，未开启时为 0
+func (g *Group) MinAccountAge() time.Duration {
+	days, ok := g.GetInt64Setting(SettingMinAccountAgeDays)
+	if !ok || days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// Rules 返回群规文本，未设置时为空
+func (g *Group) Rules() string {
+	rules, _ := g.GetStringSetting(SettingRules)
+	return rules
+}
+
+// RulesAckTimeout 返回新成员同意群规的时限，未开启时为 0
+func (g *Group) RulesAckTimeout() time.Duration {
+	minutes, ok := g.GetInt64Setting(SettingRulesAckMinutes)
+	if !ok || minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// LoadTimezone 解析 IANA 时区名称（如 Asia/Shanghai、UTC）
+// 拒绝空字符串和 "Local"：后者取决于服务器配置，不是群组可以依赖的时区
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("invalid timezone %q", name)
+	}
+	return time.LoadLocation(name)
+}
+
 // Repository 群组仓储接口
 type Repository interface {
 	FindByID(ctx context.Context, id int64) (*Group, error)
@@ -141,4 +407,6 @@ type Repository interface {
 	Update(ctx context.Context, group *Group) error
 	Delete(ctx context.Context, id int64) error
 	FindAll(ctx context.Context) ([]*Group, error)
+	// UnsetCommands 只删除群组中指定命令的启用/禁用配置，不覆盖其他字段
+	UnsetCommands(ctx context.Context, id int64, names []string) error
 }
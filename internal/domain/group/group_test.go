@@ -2,6 +2,7 @@ package group
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -83,6 +84,19 @@ func TestGroup_DisableFeature(t *testing.T) {
 	assert.Equal(t, false, g.Settings["calculator"])
 }
 
+func TestGroup_IsFeatureOptedIn(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+
+	// 未配置时默认关闭
+	assert.False(t, g.IsFeatureOptedIn("anti_raid"))
+
+	g.EnableFeature("anti_raid")
+	assert.True(t, g.IsFeatureOptedIn("anti_raid"))
+
+	g.DisableFeature("anti_raid")
+	assert.False(t, g.IsFeatureOptedIn("anti_raid"))
+}
+
 func TestGroup_ToggleFeature(t *testing.T) {
 	g := NewGroup(123, "Test Group", "group")
 
@@ -97,3 +111,121 @@ func TestGroup_ToggleFeature(t *testing.T) {
 	g.EnableFeature("calculator")
 	assert.True(t, g.IsFeatureEnabled("calculator"))
 }
+
+func TestGroup_GetStringSetting(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+
+	_, ok := g.GetStringSetting("rules")
+	assert.False(t, ok)
+
+	g.SetSetting("rules", "be nice")
+	value, ok := g.GetStringSetting("rules")
+	assert.True(t, ok)
+	assert.Equal(t, "be nice", value)
+
+	// 类型不匹配
+	g.SetSetting("calculator", true)
+	_, ok = g.GetStringSetting("calculator")
+	assert.False(t, ok)
+
+	g.DeleteSetting("rules")
+	_, ok = g.GetStringSetting("rules")
+	assert.False(t, ok)
+}
+
+func TestGroup_GetInt64ListSetting(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+	assert.Empty(t, g.GetInt64ListSetting(SettingShadowBanned))
+
+	// 从缓存（JSON）或数据库读出的列表
+	g.SetSetting(SettingShadowBanned, []interface{}{float64(1), int64(2), int32(3)})
+	assert.Equal(t, []int64{1, 2, 3}, g.GetInt64ListSetting(SettingShadowBanned))
+
+	g.SetSetting(SettingShadowBanned, "not a list")
+	assert.Empty(t, g.GetInt64ListSetting(SettingShadowBanned))
+}
+
+func TestGroup_AutoDeleteAfter(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+	assert.Zero(t, g.AutoDeleteAfter())
+
+	g.SetSetting(SettingAutoDeleteSeconds, 30)
+	assert.Equal(t, 30*time.Second, g.AutoDeleteAfter())
+
+	// 从缓存（JSON）读出的数字
+	g.SetSetting(SettingAutoDeleteSeconds, float64(60))
+	assert.Equal(t, time.Minute, g.AutoDeleteAfter())
+
+	g.SetSetting(SettingAutoDeleteSeconds, 0)
+	assert.Zero(t, g.AutoDeleteAfter())
+}
+
+func TestGroup_RulesAckTimeout(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+	assert.Zero(t, g.RulesAckTimeout())
+
+	g.SetSetting(SettingRulesAckMinutes, 5)
+	assert.Equal(t, 5*time.Minute, g.RulesAckTimeout())
+
+	g.SetSetting(SettingRulesAckMinutes, 0)
+	assert.Zero(t, g.RulesAckTimeout())
+}
+
+func TestGroup_ReasonTemplates(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+	_, ok := g.ReasonTemplate("spam")
+	assert.False(t, ok)
+
+	g.SetReasonTemplate("Spam", "发送广告")
+	g.SetReasonTemplate("flood", "刷屏")
+	text, ok := g.ReasonTemplate("SPAM")
+	assert.True(t, ok)
+	assert.Equal(t, "发送广告", text)
+
+	// 从数据库或缓存读出的映射
+	g.SetSetting(SettingReasonTemplates, map[string]interface{}{"spam": "发送广告，永久封禁", "bad": 1})
+	assert.Equal(t, map[string]string{"spam": "发送广告，永久封禁"}, g.GetStringMapSetting(SettingReasonTemplates))
+
+	assert.True(t, g.DeleteReasonTemplate("spam"))
+	assert.False(t, g.DeleteReasonTemplate("spam"))
+	_, ok = g.GetSetting(SettingReasonTemplates)
+	assert.False(t, ok)
+}
+
+func TestGroup_ShadowBan(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+
+	assert.True(t, g.ShadowBan(456))
+	assert.False(t, g.ShadowBan(456))
+	assert.True(t, g.ShadowBan(789))
+	assert.True(t, g.IsShadowBanned(456))
+	assert.False(t, g.IsShadowBanned(111))
+
+	assert.True(t, g.LiftShadowBan(456))
+	assert.False(t, g.LiftShadowBan(456))
+	assert.False(t, g.IsShadowBanned(456))
+	assert.True(t, g.IsShadowBanned(789))
+
+	assert.True(t, g.LiftShadowBan(789))
+	_, ok := g.GetSetting(SettingShadowBanned)
+	assert.False(t, ok)
+}
+
+func TestGroup_Protect(t *testing.T) {
+	g := NewGroup(123, "Test Group", "group")
+	g.ShadowBan(456)
+
+	assert.True(t, g.Protect(456))
+	assert.False(t, g.Protect(456))
+	assert.True(t, g.IsProtected(456))
+	assert.False(t, g.IsProtected(789))
+
+	// 与影子封禁列表互不影响
+	assert.True(t, g.IsShadowBanned(456))
+
+	assert.True(t, g.Unprotect(456))
+	assert.False(t, g.Unprotect(456))
+	_, ok := g.GetSetting(SettingProtectedUsers)
+	assert.False(t, ok)
+	assert.True(t, g.IsShadowBanned(456))
+}
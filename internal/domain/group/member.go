@@ -0,0 +1,37 @@
+package group
+
+// MemberStatus 群组成员状态（与 Telegram ChatMember.status 一致）
+type MemberStatus string
+
+const (
+	MemberStatusCreator       MemberStatus = "creator"
+	MemberStatusAdministrator MemberStatus = "administrator"
+	MemberStatusMember        MemberStatus = "member"
+	MemberStatusRestricted    MemberStatus = "restricted"
+	MemberStatusLeft          MemberStatus = "left"
+	MemberStatusKicked        MemberStatus = "kicked"
+)
+
+// Member Telegram 群组成员的实时信息
+// 由 Telegram 适配器从 API 返回的成员信息中提取，不保存到数据库
+type Member struct {
+	UserID    int64
+	Username  string
+	FirstName string
+	LastName  string
+	IsBot     bool
+
+	Status      MemberStatus
+	IsMember    bool   // 当前是否在群组中（被限制的成员也可能仍在群组中）
+	CustomTitle string // 管理员头衔（仅 creator/administrator）
+
+	// 管理员权限（仅 administrator；creator 拥有全部权限）
+	CanRestrictMembers bool
+	CanDeleteMessages  bool
+	CanPromoteMembers  bool
+}
+
+// IsAdmin 是否为 Telegram 群组管理员（包括群主）
+func (m *Member) IsAdmin() bool {
+	return m.Status == MemberStatusCreator || m.Status == MemberStatusAdministrator
+}
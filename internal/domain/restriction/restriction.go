@@ -0,0 +1,73 @@
+package restriction
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRecordNotFound = errors.New("restriction record not found")
+)
+
+// Kind 限制类型
+type Kind string
+
+const (
+	KindBan     Kind = "ban"     // 临时封禁
+	KindSilence Kind = "silence" // 全群禁言（UserID 为 0）
+)
+
+// Record 限制记录
+// 记录有期限的处罚，到期后由定时任务解除，不依赖 Telegram 自身的计时
+type Record struct {
+	ID        string
+	GroupID   int64
+	UserID    int64
+	Kind      Kind
+	Reason    string
+	State     string // 解除时需要恢复的状态（如全群禁言前的群组默认权限），格式由使用方决定
+	Until     time.Time
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+// NewTempBan 创建临时封禁记录
+func NewTempBan(groupID, userID int64, until time.Time, reason string, createdBy int64) *Record {
+	return &Record{
+		GroupID:   groupID,
+		UserID:    userID,
+		Kind:      KindBan,
+		Reason:    reason,
+		Until:     until,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewSilence 创建全群禁言记录，state 为禁言前的群组默认权限
+func NewSilence(groupID int64, until time.Time, state string, createdBy int64) *Record {
+	return &Record{
+		GroupID:   groupID,
+		Kind:      KindSilence,
+		State:     state,
+		Until:     until,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsExpired 是否已到期
+func (r *Record) IsExpired(now time.Time) bool {
+	return !r.Until.After(now)
+}
+
+// Repository 限制记录仓储接口
+type Repository interface {
+	// Save 保存记录，同一群组、用户、类型只保留一条（重复处罚会覆盖期限）
+	Save(ctx context.Context, r *Record) error
+	FindExpired(ctx context.Context, kind Kind, now time.Time) ([]*Record, error)
+	// FindActiveByGroup 查找群组内尚未到期的记录，按到期时间升序
+	FindActiveByGroup(ctx context.Context, groupID int64, kind Kind, now time.Time) ([]*Record, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrMessageNotFound = errors.New("scheduled message not found")
+)
+
+// Message 定时消息
+// 按固定间隔重复发送到指定群组
+type Message struct {
+	ID        string
+	GroupID   int64
+	Text      string
+	Interval  time.Duration
+	NextRunAt time.Time
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+// NewMessage 创建定时消息，首次发送时间为当前时间加一个间隔
+func NewMessage(groupID int64, text string, interval time.Duration, createdBy int64) *Message {
+	now := time.Now()
+	return &Message{
+		GroupID:   groupID,
+		Text:      text,
+		Interval:  interval,
+		NextRunAt: now.Add(interval),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+}
+
+// IsDue 是否到达发送时间
+func (m *Message) IsDue(now time.Time) bool {
+	return !m.NextRunAt.After(now)
+}
+
+// Advance 推进到下一次发送时间
+// 如果错过了多个周期（例如机器人停机），直接跳到 now 之后的第一个周期，不补发
+func (m *Message) Advance(now time.Time) {
+	if m.Interval <= 0 {
+		return
+	}
+	for !m.NextRunAt.After(now) {
+		m.NextRunAt = m.NextRunAt.Add(m.Interval)
+	}
+}
+
+// Repository 定时消息仓储接口
+type Repository interface {
+	Save(ctx context.Context, m *Message) error
+	FindByGroup(ctx context.Context, groupID int64) ([]*Message, error)
+	FindDue(ctx context.Context, now time.Time) ([]*Message, error)
+	UpdateNextRun(ctx context.Context, id string, nextRunAt time.Time) error
+	Delete(ctx context.Context, groupID int64, id string) error
+}
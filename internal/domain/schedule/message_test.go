@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_IsDue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Message{NextRunAt: now}
+
+	assert.True(t, m.IsDue(now))
+	assert.True(t, m.IsDue(now.Add(time.Second)))
+	assert.False(t, m.IsDue(now.Add(-time.Second)))
+}
+
+func TestMessage_Advance(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("advances by one interval", func(t *testing.T) {
+		m := &Message{Interval: time.Hour, NextRunAt: base}
+		m.Advance(base)
+		assert.Equal(t, base.Add(time.Hour), m.NextRunAt)
+	})
+
+	t.Run("skips missed runs", func(t *testing.T) {
+		m := &Message{Interval: time.Hour, NextRunAt: base}
+		m.Advance(base.Add(150 * time.Minute))
+		assert.Equal(t, base.Add(3*time.Hour), m.NextRunAt)
+	})
+
+	t.Run("zero interval does nothing", func(t *testing.T) {
+		m := &Message{NextRunAt: base}
+		m.Advance(base.Add(time.Hour))
+		assert.Equal(t, base, m.NextRunAt)
+	})
+}
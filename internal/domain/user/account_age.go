@@ -0,0 +1,61 @@
+package user
+
+import "time"
+
+// accountAgeSample 用户 ID 与注册时间的对应样本
+type accountAgeSample struct {
+	id int64
+	at time.Time
+}
+
+// accountAgeSamples 用户 ID 与大致注册时间的对应关系（按 ID 升序）
+// Telegram 不提供账号注册时间，但用户 ID 基本按注册顺序递增。
+// 这些点来自公开流传的 ID/注册时间样本并取整到月，只是粗略值，
+// 相邻样本之间线性插值，误差可能达到数月
+var accountAgeSamples = []accountAgeSample{
+	{id: 1_000_000, at: time.Date(2013, 8, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 100_000_000, at: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 400_000_000, at: time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 800_000_000, at: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 1_500_000_000, at: time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 2_000_000_000, at: time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 5_000_000_000, at: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 6_000_000_000, at: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 7_000_000_000, at: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	{id: 8_000_000_000, at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+// EstimateRegistration 根据用户 ID 估算账号注册时间（近似值，只用于启发式判断）
+// 早于第一个样本的 ID 返回第一个样本的时间；
+// 大于最后一个样本的 ID 按最后一段的增长速度外推
+func EstimateRegistration(userID int64) time.Time {
+	first := accountAgeSamples[0]
+	if userID <= first.id {
+		return first.at
+	}
+
+	for i := 1; i < len(accountAgeSamples); i++ {
+		if userID <= accountAgeSamples[i].id {
+			return interpolate(accountAgeSamples[i-1], accountAgeSamples[i], userID)
+		}
+	}
+
+	n := len(accountAgeSamples)
+	return interpolate(accountAgeSamples[n-2], accountAgeSamples[n-1], userID)
+}
+
+// EstimateAccountAge 估算账号在 now 时的年龄（近似值），不会小于 0
+func EstimateAccountAge(userID int64, now time.Time) time.Duration {
+	age := now.Sub(EstimateRegistration(userID))
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// interpolate 在两个样本之间（或之外）按 ID 线性插值
+func interpolate(a, b accountAgeSample, userID int64) time.Time {
+	span := b.at.Sub(a.at)
+	ratio := float64(userID-a.id) / float64(b.id-a.id)
+	return a.at.Add(time.Duration(ratio * float64(span)))
+}
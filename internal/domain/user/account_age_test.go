@@ -0,0 +1,50 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateRegistration(t *testing.T) {
+	// 样本点上返回样本时间
+	assert.Equal(t, time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC), EstimateRegistration(800_000_000))
+
+	// 早于第一个样本
+	assert.Equal(t, time.Date(2013, 8, 1, 0, 0, 0, 0, time.UTC), EstimateRegistration(42))
+
+	// 样本之间线性插值
+	mid := EstimateRegistration(5_500_000_000)
+	assert.Equal(t, 2022, mid.Year())
+	assert.Equal(t, time.July, mid.Month())
+
+	// ID 越大越新，超出样本范围时继续外推
+	assert.True(t, EstimateRegistration(9_000_000_000).After(EstimateRegistration(8_000_000_000)))
+}
+
+func TestEstimateAccountAge(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		userID int64
+		minAge time.Duration
+		young  bool
+	}{
+		{"2015 account", 100_000_000, 30 * 24 * time.Hour, false},
+		{"2024 account", 7_000_000_000, 365 * 24 * time.Hour, false},
+		{"recent account, short threshold", 9_700_000_000, 7 * 24 * time.Hour, false},
+		{"recent account, long threshold", 9_700_000_000, 180 * 24 * time.Hour, true},
+		{"brand new account", 10_200_000_000, 7 * 24 * time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.young, EstimateAccountAge(tt.userID, now) < tt.minAge)
+		})
+	}
+
+	// 估算时间晚于当前时间时年龄为 0
+	assert.Zero(t, EstimateAccountAge(20_000_000_000, now))
+}
@@ -0,0 +1,49 @@
+package user
+
+import "sort"
+
+// AdminPage 群组管理员分页结果
+type AdminPage struct {
+	Total  int     // 满足条件的管理员总数
+	Admins []*User // 当前页
+}
+
+// PageAdmins 按最低权限过滤群组管理员并分页
+// 结果按权限从高到低排序，同级按用户 ID 排序，保证翻页稳定；
+// minPerm 低于 Admin 时按 Admin 处理，limit <= 0 表示不分页
+func PageAdmins(admins []*User, groupID int64, minPerm Permission, offset, limit int) *AdminPage {
+	if minPerm < PermissionAdmin {
+		minPerm = PermissionAdmin
+	}
+
+	filtered := make([]*User, 0, len(admins))
+	for _, u := range admins {
+		if u.GetPermission(groupID) >= minPerm {
+			filtered = append(filtered, u)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		pi, pj := filtered[i].GetPermission(groupID), filtered[j].GetPermission(groupID)
+		if pi != pj {
+			return pi > pj
+		}
+		return filtered[i].ID < filtered[j].ID
+	})
+
+	page := &AdminPage{Total: len(filtered)}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(filtered) {
+		page.Admins = []*User{}
+		return page
+	}
+
+	end := len(filtered)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	page.Admins = filtered[offset:end]
+	return page
+}
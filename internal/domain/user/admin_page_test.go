@@ -0,0 +1,72 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pageTestGroupID = int64(-100123)
+
+// newPageTestAdmins 创建 1 个 Owner、2 个 SuperAdmin、3 个 Admin（ID 乱序）
+func newPageTestAdmins() []*User {
+	perms := map[int64]Permission{
+		6: PermissionAdmin,
+		2: PermissionSuperAdmin,
+		5: PermissionAdmin,
+		1: PermissionOwner,
+		4: PermissionAdmin,
+		3: PermissionSuperAdmin,
+	}
+
+	admins := make([]*User, 0, len(perms))
+	for id, perm := range perms {
+		u := NewUser(id, "", "", "")
+		u.SetPermission(pageTestGroupID, perm)
+		admins = append(admins, u)
+	}
+	return admins
+}
+
+func adminIDs(users []*User) []int64 {
+	ids := make([]int64, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func TestPageAdmins_Paging(t *testing.T) {
+	admins := newPageTestAdmins()
+
+	first := PageAdmins(admins, pageTestGroupID, PermissionAdmin, 0, 4)
+	assert.Equal(t, 6, first.Total)
+	assert.Equal(t, []int64{1, 2, 3, 4}, adminIDs(first.Admins))
+
+	second := PageAdmins(admins, pageTestGroupID, PermissionAdmin, 4, 4)
+	assert.Equal(t, 6, second.Total)
+	assert.Equal(t, []int64{5, 6}, adminIDs(second.Admins))
+
+	beyond := PageAdmins(admins, pageTestGroupID, PermissionAdmin, 8, 4)
+	assert.Equal(t, 6, beyond.Total)
+	assert.Empty(t, beyond.Admins)
+}
+
+func TestPageAdmins_MinPermission(t *testing.T) {
+	admins := newPageTestAdmins()
+
+	page := PageAdmins(admins, pageTestGroupID, PermissionSuperAdmin, 0, 2)
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, []int64{1, 2}, adminIDs(page.Admins))
+
+	owners := PageAdmins(admins, pageTestGroupID, PermissionOwner, 0, 0)
+	require.Len(t, owners.Admins, 1)
+	assert.Equal(t, 1, owners.Total)
+	assert.Equal(t, int64(1), owners.Admins[0].ID)
+
+	// 低于 Admin 的过滤条件按 Admin 处理
+	all := PageAdmins(admins, pageTestGroupID, PermissionUser, 0, 0)
+	assert.Equal(t, 6, all.Total)
+	assert.Len(t, all.Admins, 6)
+}
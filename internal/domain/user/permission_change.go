@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// PermissionChange 权限变更记录
+// 每次通过命令修改用户权限都会追加一条，用于追溯谁在何时提升/降低了谁的权限
+type PermissionChange struct {
+	GroupID   int64
+	UserID    int64
+	OldPerm   Permission
+	NewPerm   Permission
+	ActorID   int64 // 执行变更的用户
+	CreatedAt time.Time
+}
+
+// NewPermissionChange 创建权限变更记录
+func NewPermissionChange(groupID, userID int64, oldPerm, newPerm Permission, actorID int64) *PermissionChange {
+	return &PermissionChange{
+		GroupID:   groupID,
+		UserID:    userID,
+		OldPerm:   oldPerm,
+		NewPerm:   newPerm,
+		ActorID:   actorID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// PermissionChangeRepository 权限变更记录仓储接口
+type PermissionChangeRepository interface {
+	Append(ctx context.Context, c *PermissionChange) error
+	// FindByUser 按时间倒序返回用户在群组内最近的权限变更
+	FindByUser(ctx context.Context, groupID, userID int64, limit int) ([]*PermissionChange, error)
+	// ListRecent 按时间倒序返回群组内的权限变更，before 非零时只返回早于 before 的记录（分页游标）
+	ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*PermissionChange, error)
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,7 @@ var (
 type Permission int
 
 const (
+	PermissionNone       Permission = 0 // 无权限（例如新成员需审核后才能使用命令）
 	PermissionUser       Permission = 1
 	PermissionAdmin      Permission = 2
 	PermissionSuperAdmin Permission = 3
@@ -22,6 +24,8 @@ const (
 
 func (p Permission) String() string {
 	switch p {
+	case PermissionNone:
+		return "None"
 	case PermissionUser:
 		return "User"
 	case PermissionAdmin:
@@ -35,6 +39,24 @@ func (p Permission) String() string {
 	}
 }
 
+// ParsePermission 解析权限等级名称（none/user/admin/superadmin/owner，不区分大小写）
+func ParsePermission(s string) (Permission, bool) {
+	switch strings.ToLower(s) {
+	case "none":
+		return PermissionNone, true
+	case "user":
+		return PermissionUser, true
+	case "admin":
+		return PermissionAdmin, true
+	case "superadmin":
+		return PermissionSuperAdmin, true
+	case "owner":
+		return PermissionOwner, true
+	default:
+		return 0, false
+	}
+}
+
 func (p Permission) CanManage(target Permission) bool {
 	return p > target
 }
@@ -46,6 +68,7 @@ type User struct {
 	FirstName   string
 	LastName    string
 	Permissions map[int64]Permission // groupID -> Permission
+	LastSeenAt  time.Time            // 最近一次发消息的时间（按节流间隔写入，不是精确值）
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -65,23 +88,17 @@ func NewUser(id int64, username, firstName, lastName string) *User {
 }
 
 // GetPermission 获取用户在特定群组的权限
-// 返回全局权限和群组权限中的较高值
+// 返回全局权限和群组权限中的较高值；群组未设置权限时默认为 User，
+// 群组显式设置为 None 时只有全局权限能覆盖
 func (u *User) GetPermission(groupID int64) Permission {
-	globalPerm := PermissionUser
-	groupPerm := PermissionUser
-
-	// 检查全局权限（groupID = 0）
-	if perm, ok := u.Permissions[0]; ok {
-		globalPerm = perm
-	}
-
 	// 检查群组特定权限
-	if perm, ok := u.Permissions[groupID]; ok {
-		groupPerm = perm
+	groupPerm, ok := u.Permissions[groupID]
+	if !ok {
+		groupPerm = PermissionUser
 	}
 
-	// 返回两者中的较高权限
-	if globalPerm > groupPerm {
+	// 检查全局权限（groupID = 0），返回两者中的较高权限
+	if globalPerm, ok := u.Permissions[0]; ok && globalPerm > groupPerm {
 		return globalPerm
 	}
 	return groupPerm
@@ -108,6 +125,23 @@ func (u *User) IsAdmin(groupID int64) bool {
 	return u.GetPermission(groupID) >= PermissionAdmin
 }
 
+// ProfileChanged 检查 Telegram 上的用户名和显示名是否与保存的不同
+// 三项都为空时视为没有资料（如临时构造的用户），不会用空值覆盖已保存的名字
+func (u *User) ProfileChanged(username, firstName, lastName string) bool {
+	if username == "" && firstName == "" && lastName == "" {
+		return false
+	}
+	return u.Username != username || u.FirstName != firstName || u.LastName != lastName
+}
+
+// SetProfile 更新用户名和显示名
+func (u *User) SetProfile(username, firstName, lastName string) {
+	u.Username = username
+	u.FirstName = firstName
+	u.LastName = lastName
+	u.UpdatedAt = time.Now()
+}
+
 // Repository 用户仓储接口
 type Repository interface {
 	FindByID(ctx context.Context, id int64) (*User, error)
@@ -115,6 +149,9 @@ type Repository interface {
 	Save(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
 	UpdatePermission(ctx context.Context, userID int64, groupID int64, perm Permission) error // 细粒度权限更新，避免并发冲突
+	RecordSeen(ctx context.Context, user *User) error                                         // upsert 用户名、显示名和最近活跃时间，不覆盖权限
 	Delete(ctx context.Context, id int64) error
 	FindAdminsByGroup(ctx context.Context, groupID int64) ([]*User, error)
+	FindInactive(ctx context.Context, groupID int64, before time.Time, limit int) ([]*User, error) // 在群组有权限记录、before 之后未出现的用户
+	Count(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_GetPermissionNone(t *testing.T) {
+	u := NewUser(1, "newbie", "", "")
+	assert.Equal(t, PermissionUser, u.GetPermission(-100))
+
+	// 群组显式设置为 None 时不再回落到默认的 User
+	u.SetPermission(-100, PermissionNone)
+	assert.Equal(t, PermissionNone, u.GetPermission(-100))
+	assert.False(t, u.HasPermission(-100, PermissionUser))
+	assert.Equal(t, PermissionUser, u.GetPermission(-200))
+
+	// 全局权限仍然覆盖群组权限
+	u.SetPermission(0, PermissionAdmin)
+	assert.Equal(t, PermissionAdmin, u.GetPermission(-100))
+}
+
+func TestParsePermission(t *testing.T) {
+	perm, ok := ParsePermission("SuperAdmin")
+	assert.True(t, ok)
+	assert.Equal(t, PermissionSuperAdmin, perm)
+
+	perm, ok = ParsePermission("none")
+	assert.True(t, ok)
+	assert.Equal(t, PermissionNone, perm)
+
+	_, ok = ParsePermission("root")
+	assert.False(t, ok)
+}
@@ -0,0 +1,21 @@
+package handler
+
+// ActionResult 处理器执行的管理操作
+// 处理器仍只返回 error；执行操作后调用 ctx.RecordAction 记录，
+// 审计日志等中间件通过 ctx.Actions 统一读取，不必了解每个命令的细节
+type ActionResult struct {
+	Action   string // 操作名，如 "tempban"、"shadowban"
+	TargetID int64  // 操作对象的用户 ID（0 表示不针对用户，如全群禁言）
+	Message  string // 操作说明，如时长和原因
+}
+
+// RecordAction 记录处理器执行的管理操作
+// 注意：不是并发安全的，不要跨 goroutine 调用
+func (c *Context) RecordAction(result ActionResult) {
+	c.actions = append(c.actions, result)
+}
+
+// Actions 返回本条消息已记录的管理操作（按记录顺序）
+func (c *Context) Actions() []ActionResult {
+	return c.actions
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// CallbackQuery 内联键盘按钮回调（用户点击机器人消息上的回调按钮）
+type CallbackQuery struct {
+	ID        string
+	Data      string
+	UserID    int64
+	Username  string
+	FirstName string
+	ChatID    int64 // 按钮所在消息的聊天，消息不可访问时仍然有值
+	MessageID int   // 按钮所在消息的 ID
+}
+
+// CallbackAnswer 回调应答，Text 非空时在客户端显示提示，ShowAlert 时以弹窗显示
+type CallbackAnswer struct {
+	Text      string
+	ShowAlert bool
+}
+
+// CallbackHandler 回调处理器接口
+// 按 Data 的前缀分发：处理器处理 Data 为 "<Prefix>:..." 的回调
+type CallbackHandler interface {
+	// Prefix 回调数据前缀（不含冒号）
+	Prefix() string
+
+	// HandleCallback 处理回调，返回给用户的应答
+	HandleCallback(ctx context.Context, q *CallbackQuery) (CallbackAnswer, error)
+}
+
+// CallbackRouter 回调路由器
+type CallbackRouter struct {
+	handlers map[string]CallbackHandler
+	mu       sync.RWMutex
+}
+
+// NewCallbackRouter 创建回调路由器
+func NewCallbackRouter() *CallbackRouter {
+	return &CallbackRouter{
+		handlers: make(map[string]CallbackHandler),
+	}
+}
+
+// Register 注册回调处理器，相同前缀的处理器后注册的覆盖先注册的
+func (r *CallbackRouter) Register(h CallbackHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[h.Prefix()] = h
+}
+
+// Route 按前缀路由回调，没有对应处理器时返回空应答（只结束客户端的加载状态）
+func (r *CallbackRouter) Route(ctx context.Context, q *CallbackQuery) (CallbackAnswer, error) {
+	prefix, _, _ := strings.Cut(q.Data, ":")
+
+	r.mu.RLock()
+	h, ok := r.handlers[prefix]
+	r.mu.RUnlock()
+
+	if !ok {
+		return CallbackAnswer{}, nil
+	}
+	return h.HandleCallback(ctx, q)
+}
+
+// Count 返回已注册的处理器数量
+func (r *CallbackRouter) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handlers)
+}
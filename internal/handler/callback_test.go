@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCallbackHandler 模拟回调处理器，记录收到的回调数据
+type mockCallbackHandler struct {
+	prefix string
+	answer CallbackAnswer
+	data   []string
+}
+
+func (m *mockCallbackHandler) Prefix() string { return m.prefix }
+
+func (m *mockCallbackHandler) HandleCallback(ctx context.Context, q *CallbackQuery) (CallbackAnswer, error) {
+	m.data = append(m.data, q.Data)
+	return m.answer, nil
+}
+
+func TestCallbackRouter_Route(t *testing.T) {
+	router := NewCallbackRouter()
+	vote := &mockCallbackHandler{prefix: "vote", answer: CallbackAnswer{Text: "ok"}}
+	other := &mockCallbackHandler{prefix: "votes"}
+	router.Register(vote)
+	router.Register(other)
+	assert.Equal(t, 2, router.Count())
+
+	answer, err := router.Route(context.Background(), &CallbackQuery{ID: "1", Data: "vote:abc:1"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", answer.Text)
+
+	// 按完整前缀匹配，未注册的前缀返回空应答
+	answer, err = router.Route(context.Background(), &CallbackQuery{ID: "2", Data: "unknown:1"})
+	require.NoError(t, err)
+	assert.Empty(t, answer.Text)
+
+	assert.Equal(t, []string{"vote:abc:1"}, vote.data)
+	assert.Empty(t, other.data)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	commandPrefixes   = []string{"/"}
+	botUsername       string // 机器人自己的用户名（不含 @），为空时不校验 /cmd@botname 的目标
+	commandPrefixesMu sync.RWMutex
+)
+
+// SetCommandPrefixes 设置命令前缀（启动时调用，如 "/"、"!"、"."）
+// 较长的前缀优先匹配；传入空列表时恢复默认的 "/"
+func SetCommandPrefixes(prefixes []string) {
+	p := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix != "" {
+			p = append(p, prefix)
+		}
+	}
+	if len(p) == 0 {
+		p = []string{"/"}
+	}
+	sort.SliceStable(p, func(i, j int) bool {
+		return len(p[i]) > len(p[j])
+	})
+
+	commandPrefixesMu.Lock()
+	defer commandPrefixesMu.Unlock()
+	commandPrefixes = p
+}
+
+// SetBotUsername 设置机器人自己的用户名（启动时通过 getMe 获取）
+// 设置后，群组中发给其他机器人的命令（/cmd@otherbot）不再视为命令
+func SetBotUsername(username string) {
+	commandPrefixesMu.Lock()
+	defer commandPrefixesMu.Unlock()
+	botUsername = strings.TrimPrefix(username, "@")
+}
+
+// CommandPrefixes 返回当前的命令前缀
+func CommandPrefixes() []string {
+	commandPrefixesMu.RLock()
+	defer commandPrefixesMu.RUnlock()
+
+	p := make([]string, len(commandPrefixes))
+	copy(p, commandPrefixes)
+	return p
+}
+
+// ParseCommand 解析命令名
+// "/ping@mybot arg" -> "ping"；文本不以命令前缀开头、前缀后没有命令名，
+// 或命令 @ 的是其他机器人时返回 false
+func ParseCommand(text string) (string, bool) {
+	parts := strings.Fields(text)
+	if len(parts) == 0 {
+		return "", false
+	}
+
+	commandPrefixesMu.RLock()
+	prefixes := commandPrefixes
+	self := botUsername
+	commandPrefixesMu.RUnlock()
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(parts[0], prefix) {
+			continue
+		}
+
+		cmd := strings.TrimPrefix(parts[0], prefix)
+
+		// 移除 @botname（Telegram 在群组中为命令附加的目标机器人）
+		if idx := strings.Index(cmd, "@"); idx != -1 {
+			target := cmd[idx+1:]
+			cmd = cmd[:idx]
+			if self != "" && !strings.EqualFold(target, self) {
+				return "", false
+			}
+		}
+
+		return cmd, cmd != ""
+	}
+
+	return "", false
+}
+
+// IsCommand 文本是否为命令
+func IsCommand(text string) bool {
+	_, ok := ParseCommand(text)
+	return ok
+}
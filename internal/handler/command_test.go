@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"/ping", "ping", true},
+		{"/ping@mybot", "ping", true},
+		{"/ban@mybot @spammer 1h", "ban", true},
+		{"  /help  ", "help", true},
+		{"/", "", false},
+		{"!ping", "", false},
+		{"hello", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cmd, ok := ParseCommand(tt.input)
+			assert.Equal(t, tt.expected, cmd)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestParseCommand_CustomPrefixes(t *testing.T) {
+	SetCommandPrefixes([]string{"/", "!", "!!"})
+	defer SetCommandPrefixes(nil)
+
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"/ping", "ping", true},
+		{"!ping", "ping", true},
+		{"!ping@mybot arg", "ping", true},
+		{"!!ping", "ping", true}, // 较长的前缀优先
+		{".ping", "", false},
+		{"!", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cmd, ok := ParseCommand(tt.input)
+			assert.Equal(t, tt.expected, cmd)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+
+	assert.Equal(t, []string{"!!", "/", "!"}, CommandPrefixes())
+}
+
+func TestSetCommandPrefixes_EmptyRestoresDefault(t *testing.T) {
+	SetCommandPrefixes([]string{""})
+	defer SetCommandPrefixes(nil)
+
+	assert.Equal(t, []string{"/"}, CommandPrefixes())
+	assert.True(t, IsCommand("/ping"))
+}
+
+func TestParseCommand_BotUsername(t *testing.T) {
+	SetBotUsername("MyBot")
+	defer SetBotUsername("")
+
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"/ban@mybot @spammer 1h", "ban", true},
+		{"/ban@MyBot", "ban", true},
+		{"/ban", "ban", true},
+		{"/ban@otherbot @spammer", "", false},
+		{"/ban@", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cmd, ok := ParseCommand(tt.input)
+			assert.Equal(t, tt.expected, cmd)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
+	"telegram-bot/pkg/errors"
+	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -19,6 +21,9 @@ type Context struct {
 	Update  *models.Update
 	Message *models.Message
 
+	// 请求追踪
+	RequestID string // 请求ID（由日志中间件生成，同一条消息在整个处理链中保持不变）
+
 	// 聊天信息
 	ChatType  string // "private", "group", "supergroup", "channel"
 	ChatID    int64
@@ -31,16 +36,39 @@ type Context struct {
 	LastName  string
 	User      *user.User // 数据库用户对象（由中间件注入）
 
+	// 以群组或频道身份发送（sender_chat）
+	// 匿名管理员的 SenderChatID 等于 ChatID，关联频道转发的消息为频道 ID；
+	// 此时 UserID 是 Telegram 的占位账号（如 GroupAnonymousBot），不代表真实用户
+	SenderChatID    int64
+	SenderChatTitle string
+
 	// 群组信息
 	Group *group.Group // 数据库群组对象（由中间件注入）
 
 	// 消息内容
 	Text      string
 	MessageID int
+	IsEdit    bool // 是否为编辑后的消息（edited_message 更新）
 
 	// 回复消息
 	ReplyTo *ReplyInfo
 
+	// 当前执行的处理器（由 Router 在执行中间件链前设置）
+	// 中间件可据此读取处理器实现的可选接口（如 middleware.MembershipRequired）
+	Handler Handler
+
+	// 发送限流（可选，由入口注入；为 nil 时不限速）
+	Throttle SendThrottler
+
+	// 已发送消息记录（可选，由入口注入；为 nil 时不记录）
+	Sent SentRecorder
+
+	// 回复消息自动删除（可选，由入口注入；为 nil 时不删除）
+	AutoDelete AutoDeleter
+
+	// 处理器记录的管理操作（RecordAction）
+	actions []ActionResult
+
 	// 上下文存储（用于处理器之间传递数据）
 	// 注意：此 map 不是并发安全的。
 	// 在当前架构中，每个消息处理在独立的 goroutine 中进行，
@@ -49,6 +77,24 @@ type Context struct {
 	values map[string]interface{}
 }
 
+// SendThrottler 发送限流接口
+// Wait 阻塞直到可以向指定聊天发送消息
+type SendThrottler interface {
+	Wait(ctx context.Context, chatID int64) error
+}
+
+// SentRecorder 已发送消息记录接口
+// 用于之后删除或编辑机器人自己发送的消息（如 /cleanup）
+type SentRecorder interface {
+	RecordSent(chatID int64, messageID int)
+}
+
+// AutoDeleter 消息自动删除接口
+// ScheduleDelete 在 after 之后删除聊天中的指定消息
+type AutoDeleter interface {
+	ScheduleDelete(chatID int64, messageID int, after time.Duration)
+}
+
 // ReplyInfo 回复消息信息
 type ReplyInfo struct {
 	MessageID int
@@ -72,6 +118,25 @@ func (c *Context) IsChannel() bool {
 	return c.ChatType == "channel"
 }
 
+// IsSenderChat 是否以群组或频道身份发送
+func (c *Context) IsSenderChat() bool {
+	return c.SenderChatID != 0
+}
+
+// IsAnonymousAdmin 是否为匿名管理员（以群组自身身份发送）
+func (c *Context) IsAnonymousAdmin() bool {
+	return c.SenderChatID != 0 && c.SenderChatID == c.ChatID
+}
+
+// RequestContext 返回本条消息的 context（携带处理期限、请求ID），未设置时返回 context.Background()
+// 处理器访问仓储和 Telegram API 时应使用它，超过处理期限后调用会被取消
+func (c *Context) RequestContext() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
+}
+
 // Set 在上下文中存储值
 // 注意：不是并发安全的，不要跨 goroutine 调用
 func (c *Context) Set(key string, value interface{}) {
@@ -93,45 +158,36 @@ func (c *Context) Get(key string) (interface{}, bool) {
 
 // Reply 回复消息（纯文本）
 func (c *Context) Reply(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
-		ChatID: c.ChatID,
-		Text:   text,
-		ReplyParameters: &models.ReplyParameters{
-			MessageID: c.MessageID,
-		},
+	return c.reply(&bot.SendMessageParams{
+		ChatID:          c.ChatID,
+		Text:            text,
+		ReplyParameters: c.replyParameters(),
 	})
-	return err
 }
 
 // ReplyMarkdown 回复消息（Markdown 格式）
 func (c *Context) ReplyMarkdown(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
-		ChatID:    c.ChatID,
-		Text:      text,
-		ParseMode: models.ParseModeMarkdown,
-		ReplyParameters: &models.ReplyParameters{
-			MessageID: c.MessageID,
-		},
+	return c.reply(&bot.SendMessageParams{
+		ChatID:          c.ChatID,
+		Text:            text,
+		ParseMode:       models.ParseModeMarkdown,
+		ReplyParameters: c.replyParameters(),
 	})
-	return err
 }
 
 // ReplyHTML 回复消息（HTML 格式）
 func (c *Context) ReplyHTML(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
-		ChatID:    c.ChatID,
-		Text:      text,
-		ParseMode: models.ParseModeHTML,
-		ReplyParameters: &models.ReplyParameters{
-			MessageID: c.MessageID,
-		},
+	return c.reply(&bot.SendMessageParams{
+		ChatID:          c.ChatID,
+		Text:            text,
+		ParseMode:       models.ParseModeHTML,
+		ReplyParameters: c.replyParameters(),
 	})
-	return err
 }
 
 // Send 发送消息（不回复）
 func (c *Context) Send(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
+	_, err := c.send(&bot.SendMessageParams{
 		ChatID: c.ChatID,
 		Text:   text,
 	})
@@ -140,7 +196,7 @@ func (c *Context) Send(text string) error {
 
 // SendMarkdown 发送消息（Markdown 格式，不回复）
 func (c *Context) SendMarkdown(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
+	_, err := c.send(&bot.SendMessageParams{
 		ChatID:    c.ChatID,
 		Text:      text,
 		ParseMode: models.ParseModeMarkdown,
@@ -150,7 +206,7 @@ func (c *Context) SendMarkdown(text string) error {
 
 // SendHTML 发送消息（HTML 格式，不回复）
 func (c *Context) SendHTML(text string) error {
-	_, err := c.Bot.SendMessage(c.Ctx, &bot.SendMessageParams{
+	_, err := c.send(&bot.SendMessageParams{
 		ChatID:    c.ChatID,
 		Text:      text,
 		ParseMode: models.ParseModeHTML,
@@ -158,6 +214,53 @@ func (c *Context) SendHTML(text string) error {
 	return err
 }
 
+// replyParameters 回复当前消息
+func (c *Context) replyParameters() *models.ReplyParameters {
+	return &models.ReplyParameters{MessageID: c.MessageID}
+}
+
+// reply 发送回复消息
+// 群组开启自动删除（group.SettingAutoDeleteSeconds）时，回复在设定时间后删除；
+// Send 系列发送的消息（如公告、欢迎消息）不受影响
+func (c *Context) reply(params *bot.SendMessageParams) error {
+	msg, err := c.send(params)
+	if err != nil {
+		return err
+	}
+
+	if c.AutoDelete != nil && c.Group != nil && msg != nil {
+		if after := c.Group.AutoDeleteAfter(); after > 0 {
+			c.AutoDelete.ScheduleDelete(c.ChatID, msg.ID, after)
+		}
+	}
+	return nil
+}
+
+// send 等待限流放行后发送消息，并记录发送的消息 ID
+func (c *Context) send(params *bot.SendMessageParams) (*models.Message, error) {
+	if err := c.waitSend(); err != nil {
+		return nil, err
+	}
+
+	msg, err := c.Bot.SendMessage(c.Ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Sent != nil && msg != nil {
+		c.Sent.RecordSent(c.ChatID, msg.ID)
+	}
+	return msg, nil
+}
+
+// waitSend 发送前等待限流放行
+func (c *Context) waitSend() error {
+	if c.Throttle == nil {
+		return nil
+	}
+	return c.Throttle.Wait(c.Ctx, c.ChatID)
+}
+
 // DeleteMessage 删除消息
 func (c *Context) DeleteMessage() error {
 	_, err := c.Bot.DeleteMessage(c.Ctx, &bot.DeleteMessageParams{
@@ -194,8 +297,10 @@ func (c *Context) RequirePermission(required user.Permission) error {
 			currentPerm = c.User.GetPermission(groupID)
 		}
 
-		return fmt.Errorf("❌ 权限不足！需要权限: %s，当前权限: %s",
-			required.String(), currentPerm.String())
+		return errors.New(errors.CodeInsufficientPermission,
+			fmt.Sprintf("权限不足！需要权限: %s，当前权限: %s", required.String(), currentPerm.String())).
+			WithContext("required", required.String()).
+			WithContext("current", currentPerm.String())
 	}
 	return nil
 }
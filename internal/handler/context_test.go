@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"telegram-bot/internal/domain/group"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingThrottle struct {
+	chatIDs []int64
+	err     error
+}
+
+func (t *recordingThrottle) Wait(ctx context.Context, chatID int64) error {
+	t.chatIDs = append(t.chatIDs, chatID)
+	return t.err
+}
+
+func TestContext_SendGoesThroughThrottle(t *testing.T) {
+	throttle := &recordingThrottle{err: context.Canceled}
+	ctx := &Context{Ctx: context.Background(), ChatID: -100, Throttle: throttle}
+
+	// 限流返回错误时不发送（Bot 为 nil 也不会被调用）
+	assert.ErrorIs(t, ctx.Reply("hi"), context.Canceled)
+	assert.ErrorIs(t, ctx.SendHTML("<b>hi</b>"), context.Canceled)
+	assert.Equal(t, []int64{-100, -100}, throttle.chatIDs)
+}
+
+type recordingAutoDeleter struct {
+	scheduled []time.Duration
+}
+
+func (d *recordingAutoDeleter) ScheduleDelete(chatID int64, messageID int, after time.Duration) {
+	d.scheduled = append(d.scheduled, after)
+}
+
+// newSendTestBot 创建所有消息都发送成功（message_id 为 42）的 Bot
+func newSendTestBot(t *testing.T) *bot.Bot {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	return b
+}
+
+func TestContext_ReplyAutoDelete(t *testing.T) {
+	b := newSendTestBot(t)
+	g := group.NewGroup(-100, "Test", "supergroup")
+	deleter := &recordingAutoDeleter{}
+	ctx := &Context{Ctx: context.Background(), Bot: b, ChatID: -100, Group: g, AutoDelete: deleter}
+
+	// 未设置时不删除
+	require.NoError(t, ctx.Reply("hi"))
+	assert.Empty(t, deleter.scheduled)
+
+	// 开启后回复被安排删除，Send 发送的消息不受影响
+	g.SetSetting(group.SettingAutoDeleteSeconds, 30)
+	require.NoError(t, ctx.ReplyHTML("<b>done</b>"))
+	require.NoError(t, ctx.Send("announcement"))
+	assert.Equal(t, []time.Duration{30 * time.Second}, deleter.scheduled)
+
+	// 0 表示关闭
+	g.SetSetting(group.SettingAutoDeleteSeconds, 0)
+	require.NoError(t, ctx.Reply("hi"))
+	assert.Len(t, deleter.scheduled, 1)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+
+	"telegram-bot/pkg/errors"
+)
+
+// GenericErrorMessage 未知错误时返回给用户的提示
+const GenericErrorMessage = "❌ 处理消息时出错，请稍后再试"
+
+// BotPermissionMessage 机器人缺少管理员权限时的提示
+const BotPermissionMessage = "❌ 我需要管理员权限（封禁/限制成员）才能执行此操作，请将我设为管理员并授予相应权限"
+
+// errorMessages 错误码到用户提示的映射
+var errorMessages = map[string]string{
+	errors.CodeInsufficientPermission: "❌ 权限不足，无法执行此操作",
+	errors.CodePermission:             "❌ 权限不足，无法执行此操作",
+	errors.CodeBotPermission:          BotPermissionMessage,
+	errors.CodeValidation:             "❌ 输入有误，请检查命令格式",
+	errors.CodeNotFound:               "❌ 未找到相关数据",
+	errors.CodeUserNotFound:           "❌ 用户不存在或未使用过此机器人",
+	errors.CodeRateLimit:              "⏳ 操作太频繁，请稍后再试",
+	errors.CodeTimeout:                "⌛ 请求超时，请稍后再试",
+	errors.CodeUnsupportedChatType:    "ℹ️ 该命令不能在当前聊天中使用",
+	errors.CodeMaintenance:            "🛠 机器人正在维护，暂时无法处理命令，请稍后再试",
+	errors.CodeNotMember:              "❌ 只有本群成员才能使用此命令",
+	errors.CodeExternal:               "❌ Telegram 服务暂时不可用，请稍后再试",
+}
+
+// ErrUsage 命令参数有误（用 errors.Is 判断）
+// 处理器返回 Usage(help) 而不是自行回复用法，Router 向用户回复用法说明，不视为处理失败
+var ErrUsage = errors.New(errors.CodeValidation, "invalid command usage")
+
+// usageError 携带用法说明的 ErrUsage
+type usageError struct {
+	help string
+}
+
+func (e *usageError) Error() string { return ErrUsage.Error() }
+func (e *usageError) Unwrap() error { return ErrUsage }
+
+// Usage 返回携带用法说明（HTML）的 ErrUsage
+func Usage(help string) error {
+	return &usageError{help: help}
+}
+
+// UsageHelp 取出错误携带的用法说明，不是用法错误时返回 false
+func UsageHelp(err error) (string, bool) {
+	var ue *usageError
+	if !errors.As(err, &ue) {
+		return "", false
+	}
+	return ue.help, true
+}
+
+// UserMessage 将处理器返回的错误转换为面向用户的提示
+// 已知错误码返回对应的友好提示，其他错误返回通用提示（不暴露内部细节）
+func UserMessage(err error) string {
+	var appErr errors.Error
+	if !errors.As(err, &appErr) {
+		return GenericErrorMessage
+	}
+
+	msg, ok := errorMessages[appErr.Code()]
+	if !ok {
+		return GenericErrorMessage
+	}
+
+	// 权限错误附带所需权限，方便用户了解原因
+	if required, ok := errors.GetContext(appErr, "required"); ok {
+		current, _ := errors.GetContext(appErr, "current")
+		msg += fmt.Sprintf("\n需要权限: %s，当前权限: %s", required, current)
+	}
+
+	// 命令冷却中附带剩余时间
+	if retryAfter, ok := errors.GetContext(appErr, "retry_after"); ok {
+		msg += fmt.Sprintf("\n请在 %s 后再试", retryAfter)
+	}
+
+	// 命令配额用完时附带配额说明和重置时间
+	if quota, ok := errors.GetContext(appErr, "quota"); ok {
+		msg += fmt.Sprintf("\n%s", quota)
+	}
+	if resetsAt, ok := errors.GetContext(appErr, "resets_at"); ok {
+		msg += fmt.Sprintf("\n将于 %s 重置", resetsAt)
+	}
+
+	// 输入超过上限时附带限制说明
+	if limit, ok := errors.GetContext(appErr, "limit"); ok {
+		msg += fmt.Sprintf("\n%s", limit)
+	}
+
+	// 聊天类型错误附带支持的聊天类型
+	if allowed, ok := errors.GetContext(appErr, "allowed"); ok {
+		msg += fmt.Sprintf("\n可在以下聊天中使用: %s", allowed)
+	}
+
+	return msg
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserMessage_InsufficientPermission(t *testing.T) {
+	ctx := &Context{
+		ChatType: "supergroup",
+		ChatID:   -100,
+		User:     user.NewUser(1, "alice", "Alice", ""),
+	}
+
+	err := ctx.RequirePermission(user.PermissionAdmin)
+	assert.True(t, errors.HasCode(err, errors.CodeInsufficientPermission))
+
+	msg := UserMessage(err)
+	assert.Contains(t, msg, "权限不足")
+	assert.Contains(t, msg, "需要权限: Admin，当前权限: User")
+}
+
+func TestUserMessage_KnownCodes(t *testing.T) {
+	assert.Equal(t, "⏳ 操作太频繁，请稍后再试", UserMessage(errors.RateLimit("too many")))
+	assert.Equal(t, "❌ 输入有误，请检查命令格式", UserMessage(errors.Validation("", "bad input")))
+
+	// 经过 fmt.Errorf 包装后仍能识别
+	wrapped := fmt.Errorf("handler failed: %w", errors.Timeout("slow"))
+	assert.Equal(t, "⌛ 请求超时，请稍后再试", UserMessage(wrapped))
+}
+
+func TestUserMessage_RetryAfter(t *testing.T) {
+	err := errors.New(errors.CodeRateLimit, "command cooldown").WithContext("retry_after", "3 分钟")
+	assert.Equal(t, "⏳ 操作太频繁，请稍后再试\n请在 3 分钟 后再试", UserMessage(err))
+}
+
+func TestUserMessage_FallsBackToGeneric(t *testing.T) {
+	assert.Equal(t, GenericErrorMessage, UserMessage(fmt.Errorf("boom")))
+	assert.Equal(t, GenericErrorMessage, UserMessage(errors.Internal("", "db down")))
+}
@@ -29,3 +29,26 @@ type Handler interface {
 
 // HandlerFunc 处理函数类型
 type HandlerFunc func(ctx *Context) error
+
+// ChatTypeRestricted 可选接口：限制处理器可用的聊天类型
+// 处理器匹配后，若当前聊天类型不在 AllowedChatTypes 中，Router 不执行处理器：
+// 命令类处理器（ContinueChain 为 false）返回 CodeUnsupportedChatType 错误提示用户，监听器类静默跳过
+type ChatTypeRestricted interface {
+	// AllowedChatTypes 支持的聊天类型：private, group, supergroup, channel
+	AllowedChatTypes() []string
+}
+
+// AllowsChatType 处理器是否可以在指定聊天类型中使用
+// 未实现 ChatTypeRestricted 的处理器不限制聊天类型
+func AllowsChatType(h Handler, chatType string) bool {
+	restricted, ok := h.(ChatTypeRestricted)
+	if !ok {
+		return true
+	}
+	for _, t := range restricted.AllowedChatTypes() {
+		if t == chatType {
+			return true
+		}
+	}
+	return false
+}
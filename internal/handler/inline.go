@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"sync"
+)
+
+// maxInlineResults Telegram 单次 answerInlineQuery 最多返回 50 条结果
+const maxInlineResults = 50
+
+// InlineQuery 内联查询（用户在任意聊天输入 @botname 查询内容）
+type InlineQuery struct {
+	ID       string
+	Query    string
+	Offset   string
+	UserID   int64
+	Username string
+}
+
+// InlineResult 内联查询结果（以文章形式展示，选中后发送 Text）
+type InlineResult struct {
+	ID          string
+	Title       string
+	Description string
+	Text        string // HTML 格式
+}
+
+// InlineHandler 内联查询处理器接口
+type InlineHandler interface {
+	// Match 判断是否处理该查询
+	Match(q *InlineQuery) bool
+
+	// Results 返回查询结果
+	Results(ctx context.Context, q *InlineQuery) ([]InlineResult, error)
+}
+
+// InlineRouter 内联查询路由器
+// 汇总所有匹配处理器的结果
+type InlineRouter struct {
+	handlers []InlineHandler
+	mu       sync.RWMutex
+}
+
+// NewInlineRouter 创建内联查询路由器
+func NewInlineRouter() *InlineRouter {
+	return &InlineRouter{
+		handlers: make([]InlineHandler, 0),
+	}
+}
+
+// Register 注册内联查询处理器（按注册顺序排列结果）
+func (r *InlineRouter) Register(h InlineHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, h)
+}
+
+// Route 路由内联查询
+// 单个处理器出错不影响其他处理器的结果，返回最后一个错误供调用方记录
+func (r *InlineRouter) Route(ctx context.Context, q *InlineQuery) ([]InlineResult, error) {
+	r.mu.RLock()
+	handlers := r.handlers
+	r.mu.RUnlock()
+
+	var lastErr error
+	results := make([]InlineResult, 0)
+
+	for _, h := range handlers {
+		if !h.Match(q) {
+			continue
+		}
+
+		items, err := h.Results(ctx, q)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		results = append(results, items...)
+	}
+
+	if len(results) > maxInlineResults {
+		results = results[:maxInlineResults]
+	}
+	return results, lastErr
+}
+
+// Count 返回已注册的处理器数量
+func (r *InlineRouter) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handlers)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockInlineHandler 模拟内联查询处理器
+type mockInlineHandler struct {
+	shouldMatch bool
+	results     []InlineResult
+	err         error
+	called      bool
+}
+
+func (m *mockInlineHandler) Match(q *InlineQuery) bool {
+	return m.shouldMatch
+}
+
+func (m *mockInlineHandler) Results(ctx context.Context, q *InlineQuery) ([]InlineResult, error) {
+	m.called = true
+	return m.results, m.err
+}
+
+func TestInlineRouter_Route(t *testing.T) {
+	router := NewInlineRouter()
+
+	first := &mockInlineHandler{shouldMatch: true, results: []InlineResult{{ID: "a", Title: "A"}}}
+	skipped := &mockInlineHandler{shouldMatch: false, results: []InlineResult{{ID: "x"}}}
+	failing := &mockInlineHandler{shouldMatch: true, err: errors.New("boom")}
+	second := &mockInlineHandler{shouldMatch: true, results: []InlineResult{{ID: "b", Title: "B"}}}
+
+	router.Register(first)
+	router.Register(skipped)
+	router.Register(failing)
+	router.Register(second)
+	assert.Equal(t, 4, router.Count())
+
+	results, err := router.Route(context.Background(), &InlineQuery{ID: "q1", Query: "test"})
+
+	assert.EqualError(t, err, "boom")
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].ID)
+	assert.Equal(t, "b", results[1].ID)
+	assert.False(t, skipped.called)
+}
+
+func TestInlineRouter_RouteLimitsResults(t *testing.T) {
+	items := make([]InlineResult, 0, 60)
+	for i := 0; i < 60; i++ {
+		items = append(items, InlineResult{ID: fmt.Sprintf("%d", i)})
+	}
+
+	router := NewInlineRouter()
+	router.Register(&mockInlineHandler{shouldMatch: true, results: items})
+
+	results, err := router.Route(context.Background(), &InlineQuery{ID: "q1"})
+
+	require.NoError(t, err)
+	assert.Len(t, results, maxInlineResults)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import "github.com/go-telegram/bot/models"
+
+// Button 内联键盘按钮
+// Data 非空时为回调按钮（点击后机器人收到 callback_query，Telegram 限制最多 64 字节），
+// 否则 URL 非空时为链接按钮
+type Button struct {
+	Text string
+	Data string
+	URL  string
+}
+
+// CallbackButton 创建回调按钮
+func CallbackButton(text, data string) Button {
+	return Button{Text: text, Data: data}
+}
+
+// URLButton 创建链接按钮
+func URLButton(text, url string) Button {
+	return Button{Text: text, URL: url}
+}
+
+// Keyboard 内联键盘构建器，按行添加按钮
+//
+//	buttons := NewKeyboard().
+//		Row(CallbackButton("✅ 同意", "vote:yes"), CallbackButton("❌ 反对", "vote:no")).
+//		Row(URLButton("群规", "https://t.me/example")).
+//		Buttons()
+type Keyboard struct {
+	rows [][]Button
+}
+
+// NewKeyboard 创建空的内联键盘
+func NewKeyboard() *Keyboard {
+	return &Keyboard{}
+}
+
+// Row 添加一行按钮，空行被忽略
+func (k *Keyboard) Row(buttons ...Button) *Keyboard {
+	if len(buttons) > 0 {
+		k.rows = append(k.rows, buttons)
+	}
+	return k
+}
+
+// Buttons 返回按行排列的按钮
+func (k *Keyboard) Buttons() [][]Button {
+	return k.rows
+}
+
+// InlineKeyboardMarkup 将按钮转换为 Telegram 内联键盘，没有按钮时返回 nil
+func InlineKeyboardMarkup(buttons [][]Button) *models.InlineKeyboardMarkup {
+	if len(buttons) == 0 {
+		return nil
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(buttons))
+	for _, row := range buttons {
+		items := make([]models.InlineKeyboardButton, 0, len(row))
+		for _, b := range row {
+			items = append(items, models.InlineKeyboardButton{
+				Text:         b.Text,
+				CallbackData: b.Data,
+				URL:          b.URL,
+			})
+		}
+		rows = append(rows, items)
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyboard_Rows(t *testing.T) {
+	buttons := NewKeyboard().
+		Row(CallbackButton("Yes", "vote:yes"), CallbackButton("No", "vote:no"), CallbackButton("Skip", "vote:skip")).
+		Row().
+		Row(URLButton("Rules", "https://t.me/example")).
+		Buttons()
+
+	// 空行被忽略
+	require.Len(t, buttons, 2)
+	assert.Len(t, buttons[0], 3)
+	assert.Len(t, buttons[1], 1)
+
+	markup := InlineKeyboardMarkup(buttons)
+	require.NotNil(t, markup)
+	assert.Equal(t, [][]models.InlineKeyboardButton{
+		{
+			{Text: "Yes", CallbackData: "vote:yes"},
+			{Text: "No", CallbackData: "vote:no"},
+			{Text: "Skip", CallbackData: "vote:skip"},
+		},
+		{
+			{Text: "Rules", URL: "https://t.me/example"},
+		},
+	}, markup.InlineKeyboard)
+}
+
+func TestInlineKeyboardMarkup_Empty(t *testing.T) {
+	assert.Nil(t, InlineKeyboardMarkup(nil))
+	assert.Nil(t, InlineKeyboardMarkup(NewKeyboard().Buttons()))
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"sync/atomic"
+	"telegram-bot/pkg/errors"
+)
+
+// Maintenance 全局维护模式开关（进程内状态，重启后恢复为关闭）
+// 开启后非 Owner 的命令统一回复维护提示，Owner 仍可正常操作（包括关闭维护模式）；
+// 其他处理器（关键词、正则、监听器）按 pauseListeners 决定继续运行还是暂停
+type Maintenance struct {
+	active         atomic.Bool
+	ownerIDs       []int64
+	pauseListeners bool
+}
+
+// NewMaintenance 创建维护模式开关（初始为关闭）
+// ownerIDs 为不受维护模式影响的用户（BOT_OWNER_IDS）
+func NewMaintenance(ownerIDs []int64, pauseListeners bool) *Maintenance {
+	return &Maintenance{
+		ownerIDs:       ownerIDs,
+		pauseListeners: pauseListeners,
+	}
+}
+
+// Active 是否处于维护模式
+func (m *Maintenance) Active() bool {
+	return m.active.Load()
+}
+
+// SetActive 开启或关闭维护模式
+func (m *Maintenance) SetActive(active bool) {
+	m.active.Store(active)
+}
+
+// isOwner 用户是否为配置的 Owner
+func (m *Maintenance) isOwner(userID int64) bool {
+	for _, id := range m.ownerIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// blocks 维护模式下是否拦截该处理器
+// 返回 refuse=true 表示需要回复维护提示（命令），skip=true 表示静默跳过
+func (m *Maintenance) blocks(h Handler, ctx *Context) (refuse, skip bool) {
+	if m == nil || !m.Active() || m.isOwner(ctx.UserID) {
+		return false, false
+	}
+	if _, ok := h.(commandDescriber); ok {
+		return true, false
+	}
+	return false, m.pauseListeners
+}
+
+// maintenanceError 维护模式下拒绝命令的错误
+func maintenanceError() error {
+	return errors.New(errors.CodeMaintenance, "bot is in maintenance mode")
+}
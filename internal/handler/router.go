@@ -1,8 +1,16 @@
 package handler
 
 import (
+	"context"
+	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/pkg/errors"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Router 消息路由器
@@ -10,6 +18,10 @@ import (
 type Router struct {
 	handlers    []Handler
 	middlewares []Middleware
+	timeout     time.Duration // 单条消息的处理期限（0 表示不限制）
+	maintenance *Maintenance  // 维护模式开关（可为 nil）
+	maxArgs     int           // 命令参数个数上限（0 表示不限制）
+	maxLength   int           // 命令文本长度上限（字符，0 表示不限制）
 	mu          sync.RWMutex
 }
 
@@ -34,6 +46,24 @@ func (r *Router) Register(h Handler) {
 	})
 }
 
+// Validate 检查注册的处理器，同名命令注册了多次时返回错误（启动时调用）
+// 同名命令中只有优先级最高的一个会被执行，另一个永远不会匹配，通常是注册错误
+func (r *Router) Validate() error {
+	seen := make(map[string]Handler)
+	for _, h := range r.GetHandlers() {
+		cmd, ok := h.(commandDescriber)
+		if !ok {
+			continue
+		}
+		name := cmd.GetName()
+		if first, exists := seen[name]; exists {
+			return fmt.Errorf("command %q registered twice: %T and %T", name, first, h)
+		}
+		seen[name] = h
+	}
+	return nil
+}
+
 // Use 注册全局中间件
 // 中间件会应用到所有匹配的处理器
 func (r *Router) Use(mw Middleware) {
@@ -43,30 +73,114 @@ func (r *Router) Use(mw Middleware) {
 	r.middlewares = append(r.middlewares, mw)
 }
 
+// SetTimeout 设置单条消息的处理期限（0 表示不限制）
+// 期限通过 ctx.Ctx 传递给仓储和 Telegram API 调用（包括重试等待），超时后这些调用被取消，
+// 避免一条卡住的消息长时间占用处理协程并拖慢关闭
+func (r *Router) SetTimeout(timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timeout = timeout
+}
+
+// SetCommandLimits 设置命令输入的参数个数和文本长度（字符）上限，0 表示不限制
+// 超过上限的命令在分发给命令处理器之前被拒绝，避免拼接大量参数的命令消耗过多内存和处理时间；
+// 监听器（如消息统计）不受影响
+func (r *Router) SetCommandLimits(maxArgs, maxLength int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.maxArgs = maxArgs
+	r.maxLength = maxLength
+}
+
+// SetMaintenance 设置维护模式开关
+// 维护模式下非 Owner 的命令被拒绝并回复维护提示，其他处理器按配置继续运行或暂停
+func (r *Router) SetMaintenance(m *Maintenance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.maintenance = m
+}
+
 // Route 路由消息到匹配的处理器
-// 返回 error 表示处理过程中出现错误
+// 返回 error 表示处理过程中出现错误；超过处理期限时返回 CodeTimeout 错误
 func (r *Router) Route(ctx *Context) error {
 	r.mu.RLock()
 	handlers := r.handlers
+	timeout := r.timeout
+	maintenance := r.maintenance
+	limits := commandLimits{maxArgs: r.maxArgs, maxLength: r.maxLength}
 	r.mu.RUnlock()
 
+	if timeout > 0 {
+		reqCtx, cancel := context.WithTimeout(ctx.RequestContext(), timeout)
+		defer cancel()
+		ctx.Ctx = reqCtx
+	}
+
+	err := r.route(ctx, handlers, maintenance, limits)
+	if ctx.Ctx != nil && errors.Is(ctx.Ctx.Err(), context.DeadlineExceeded) {
+		return errors.WrapWithCode(ctx.Ctx.Err(), errors.CodeTimeout, "update processing deadline exceeded").
+			WithContext("timeout", timeout.String())
+	}
+	return err
+}
+
+// route 依次执行匹配的处理器
+func (r *Router) route(ctx *Context, handlers []Handler, maintenance *Maintenance, limits commandLimits) error {
 	var lastErr error
 	matchedCount := 0
 
 	// 遍历所有处理器，执行匹配的
 	for _, h := range handlers {
+		// 超过处理期限后不再执行后续处理器
+		if ctx.Ctx != nil && ctx.Ctx.Err() != nil {
+			break
+		}
+
 		// 匹配检查
 		if !h.Match(ctx) {
 			continue
 		}
 
+		// 聊天类型检查（处理器实现 ChatTypeRestricted 时）
+		if !AllowsChatType(h, ctx.ChatType) {
+			if h.ContinueChain() {
+				continue
+			}
+			return unsupportedChatTypeError(h.(ChatTypeRestricted))
+		}
+
+		// 维护模式检查（Owner 不受影响）
+		refuse, skip := maintenance.blocks(h, ctx)
+		if refuse {
+			return maintenanceError()
+		}
+		if skip {
+			continue
+		}
+
+		// 命令输入长度检查（命令类处理器）
+		if !h.ContinueChain() {
+			if err := limits.check(ctx.Text); err != nil {
+				return err
+			}
+		}
+
 		matchedCount++
+		ctx.Handler = h
 
 		// 构建中间件链
 		handler := r.buildChain(h)
 
 		// 执行处理器
-		if err := handler(ctx); err != nil {
+		err := handler(ctx)
+		if help, ok := UsageHelp(err); ok {
+			// 参数有误：回复用法说明，不视为处理失败
+			err = ctx.ReplyHTML(help)
+		}
+		if err != nil {
 			if !h.ContinueChain() {
 				// 命令类处理器：错误是用户级的，需要立即返回
 				// 例如：权限不足、参数错误等，这些应该反馈给用户
@@ -91,6 +205,69 @@ func (r *Router) Route(ctx *Context) error {
 	return lastErr
 }
 
+// commandLimits 命令输入上限
+type commandLimits struct {
+	maxArgs   int
+	maxLength int
+}
+
+// check 命令文本超过上限时返回 CodeValidation 错误，非命令文本不检查
+func (l commandLimits) check(text string) error {
+	if (l.maxArgs <= 0 && l.maxLength <= 0) || !IsCommand(text) {
+		return nil
+	}
+
+	if l.maxLength > 0 && utf8.RuneCountInString(text) > l.maxLength {
+		return errors.New(errors.CodeValidation, "command input too long").
+			WithContext("limit", fmt.Sprintf("命令最长 %d 个字符", l.maxLength))
+	}
+	// 参数个数不含命令本身
+	if l.maxArgs > 0 && countFields(text)-1 > l.maxArgs {
+		return errors.New(errors.CodeValidation, "too many command arguments").
+			WithContext("limit", fmt.Sprintf("命令最多 %d 个参数", l.maxArgs))
+	}
+	return nil
+}
+
+// countFields 统计空白分隔的字段数（与 strings.Fields 一致，但不分配切片）
+func countFields(s string) int {
+	n := 0
+	inField := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			inField = false
+			continue
+		}
+		if !inField {
+			n++
+			inField = true
+		}
+	}
+	return n
+}
+
+// chatTypeNames 聊天类型的显示名称
+var chatTypeNames = map[string]string{
+	"private":    "私聊",
+	"group":      "群组",
+	"supergroup": "超级群组",
+	"channel":    "频道",
+}
+
+// unsupportedChatTypeError 构造聊天类型不支持的错误，附带支持的聊天类型
+func unsupportedChatTypeError(h ChatTypeRestricted) error {
+	allowed := make([]string, 0, len(h.AllowedChatTypes()))
+	for _, t := range h.AllowedChatTypes() {
+		if name, ok := chatTypeNames[t]; ok {
+			t = name
+		}
+		allowed = append(allowed, t)
+	}
+
+	return errors.New(errors.CodeUnsupportedChatType, "command is not available in this chat type").
+		WithContext("allowed", strings.Join(allowed, "、"))
+}
+
 // buildChain 构建中间件链
 func (r *Router) buildChain(h Handler) HandlerFunc {
 	// 最终处理器
@@ -123,3 +300,94 @@ func (r *Router) GetHandlers() []Handler {
 	copy(handlers, r.handlers)
 	return handlers
 }
+
+// HandlerInfo 处理器元数据（用于调试接口）
+type HandlerInfo struct {
+	Type          string   `json:"type"`                  // Go 类型名，如 *command.PingHandler
+	Priority      int      `json:"priority"`              // 优先级
+	ContinueChain bool     `json:"continue_chain"`        // 处理后是否继续执行后续处理器
+	Command       string   `json:"command,omitempty"`     // 命令名（仅命令处理器）
+	Description   string   `json:"description,omitempty"` // 命令描述（仅命令处理器）
+	Permission    string   `json:"permission,omitempty"`  // 所需权限（仅命令处理器）
+	ChatTypes     []string `json:"chat_types,omitempty"`  // 支持的聊天类型（为空表示不限制）
+}
+
+// CommandInfo 命令元数据（用于帮助、未知命令提示等需要列出命令的地方）
+type CommandInfo struct {
+	Name        string
+	Description string
+	Permission  user.Permission
+	ChatTypes   []string // 支持的聊天类型，为空表示不限制
+}
+
+// AllowsChatType 命令是否可以在指定聊天类型中使用
+func (c CommandInfo) AllowsChatType(chatType string) bool {
+	if len(c.ChatTypes) == 0 {
+		return true
+	}
+	for _, t := range c.ChatTypes {
+		if t == chatType {
+			return true
+		}
+	}
+	return false
+}
+
+// commandDescriber 命令处理器的元数据接口（嵌入 command.BaseCommand 的处理器都实现了此接口）
+type commandDescriber interface {
+	GetName() string
+	GetDescription() string
+	GetPermission() user.Permission
+}
+
+// Describe 返回所有处理器的元数据（按优先级排序）
+func (r *Router) Describe() []HandlerInfo {
+	handlers := r.GetHandlers()
+
+	infos := make([]HandlerInfo, 0, len(handlers))
+	for _, h := range handlers {
+		info := HandlerInfo{
+			Type:          fmt.Sprintf("%T", h),
+			Priority:      h.Priority(),
+			ContinueChain: h.ContinueChain(),
+		}
+		if cmd, ok := h.(commandDescriber); ok {
+			info.Command = cmd.GetName()
+			info.Description = cmd.GetDescription()
+			info.Permission = cmd.GetPermission().String()
+		}
+		info.ChatTypes = allowedChatTypes(h)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Commands 返回所有命令处理器的元数据（按命令名排序）
+func (r *Router) Commands() []CommandInfo {
+	var commands []CommandInfo
+	for _, h := range r.GetHandlers() {
+		cmd, ok := h.(commandDescriber)
+		if !ok {
+			continue
+		}
+		commands = append(commands, CommandInfo{
+			Name:        cmd.GetName(),
+			Description: cmd.GetDescription(),
+			Permission:  cmd.GetPermission(),
+			ChatTypes:   allowedChatTypes(h),
+		})
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// allowedChatTypes 处理器支持的聊天类型，未实现 ChatTypeRestricted 时为 nil
+func allowedChatTypes(h Handler) []string {
+	if restricted, ok := h.(ChatTypeRestricted); ok {
+		return restricted.AllowedChatTypes()
+	}
+	return nil
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"telegram-bot/internal/domain/user"
 	"testing"
 
+	"github.com/go-telegram/bot"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockHandler 模拟处理器
@@ -76,6 +83,58 @@ func TestRouter_Route(t *testing.T) {
 	assert.False(t, handler2.handleCalled)
 }
 
+// restrictedHandler 限制聊天类型的模拟处理器
+type restrictedHandler struct {
+	MockHandler
+	chatTypes []string
+}
+
+func (h *restrictedHandler) AllowedChatTypes() []string {
+	return h.chatTypes
+}
+
+// TestRouter_Route_ChatTypeGating 测试按聊天类型拦截
+func TestRouter_Route_ChatTypeGating(t *testing.T) {
+	tests := []struct {
+		chatType string
+		allowed  bool
+	}{
+		{chatType: "private", allowed: false},
+		{chatType: "group", allowed: true},
+		{chatType: "supergroup", allowed: true},
+		{chatType: "channel", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chatType, func(t *testing.T) {
+			router := NewRouter()
+			cmd := &restrictedHandler{
+				MockHandler: MockHandler{priority: 100, shouldMatch: true},
+				chatTypes:   []string{"group", "supergroup"},
+			}
+			listener := &restrictedHandler{
+				MockHandler: MockHandler{priority: 50, shouldMatch: true, continueChain: true},
+				chatTypes:   []string{"group", "supergroup"},
+			}
+			router.Register(cmd)
+			router.Register(listener)
+
+			err := router.Route(&Context{ChatType: tt.chatType})
+
+			assert.Equal(t, tt.allowed, cmd.handleCalled)
+			assert.Equal(t, tt.allowed, listener.handleCalled)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			// 命令被拒绝并提示支持的聊天类型，监听器静默跳过
+			require.Error(t, err)
+			assert.Equal(t, "ℹ️ 该命令不能在当前聊天中使用\n可在以下聊天中使用: 群组、超级群组", UserMessage(err))
+		})
+	}
+}
+
 // TestRouter_Route_ContinueChain 测试继续链
 func TestRouter_Route_ContinueChain(t *testing.T) {
 	router := NewRouter()
@@ -96,6 +155,46 @@ func TestRouter_Route_ContinueChain(t *testing.T) {
 	assert.True(t, handler2.handleCalled)
 }
 
+// TestRouter_Route_CommandLimits 测试命令输入上限
+func TestRouter_Route_CommandLimits(t *testing.T) {
+	router := NewRouter()
+	router.SetCommandLimits(3, 40)
+
+	cmd := &MockHandler{priority: 100, shouldMatch: true}
+	listener := &MockHandler{priority: 50, shouldMatch: true, continueChain: true}
+	router.Register(cmd)
+	router.Register(listener)
+
+	// 正常命令
+	require.NoError(t, router.Route(&Context{Text: "/rules set  a b"}))
+	assert.True(t, cmd.handleCalled)
+
+	// 参数过多：拒绝命令，监听器照常执行
+	cmd.handleCalled = false
+	listener.handleCalled = false
+	err := router.Route(&Context{Text: "/rules set a b c"})
+	require.Error(t, err)
+	assert.False(t, cmd.handleCalled)
+	assert.True(t, listener.handleCalled)
+	assert.Equal(t, "❌ 输入有误，请检查命令格式\n命令最多 3 个参数", UserMessage(err))
+
+	// 文本过长
+	err = router.Route(&Context{Text: "/rules set " + strings.Repeat("长", 40)})
+	require.Error(t, err)
+	assert.Equal(t, "❌ 输入有误，请检查命令格式\n命令最长 40 个字符", UserMessage(err))
+
+	// 非命令文本不检查
+	cmd.handleCalled = false
+	require.NoError(t, router.Route(&Context{Text: strings.Repeat("a ", 100)}))
+	assert.True(t, cmd.handleCalled)
+}
+
+func TestCountFields(t *testing.T) {
+	for _, text := range []string{"", "  ", "/ping", " /rules  set\ta\nb ", "你好　世界"} {
+		assert.Equal(t, len(strings.Fields(text)), countFields(text), text)
+	}
+}
+
 // TestRouter_Route_NoMatch 测试没有匹配的处理器
 func TestRouter_Route_NoMatch(t *testing.T) {
 	router := NewRouter()
@@ -160,3 +259,176 @@ func TestMiddleware_Chain(t *testing.T) {
 	}
 	assert.Equal(t, expected, executed)
 }
+
+func TestRouter_Describe(t *testing.T) {
+	router := NewRouter()
+	router.Register(&MockHandler{priority: 900, continueChain: true})
+
+	infos := router.Describe()
+
+	require.Len(t, infos, 1)
+	assert.Equal(t, "*handler.MockHandler", infos[0].Type)
+	assert.Equal(t, 900, infos[0].Priority)
+	assert.True(t, infos[0].ContinueChain)
+	assert.Empty(t, infos[0].Command)
+}
+
+// commandHandler 带命令元数据的模拟处理器
+type commandHandler struct {
+	MockHandler
+}
+
+func (h *commandHandler) GetName() string                { return "ping" }
+func (h *commandHandler) GetDescription() string         { return "" }
+func (h *commandHandler) GetPermission() user.Permission { return user.PermissionUser }
+
+// namedCommand 可指定命令名、权限和聊天类型的模拟命令处理器
+type namedCommand struct {
+	MockHandler
+	name       string
+	permission user.Permission
+	chatTypes  []string
+}
+
+func (h *namedCommand) GetName() string                { return h.name }
+func (h *namedCommand) GetDescription() string         { return h.name + " 命令" }
+func (h *namedCommand) GetPermission() user.Permission { return h.permission }
+func (h *namedCommand) AllowedChatTypes() []string     { return h.chatTypes }
+
+// TestRouter_Commands 测试列出命令元数据
+func TestRouter_Commands(t *testing.T) {
+	router := NewRouter()
+	router.Register(&namedCommand{MockHandler: MockHandler{priority: 100}, name: "vote", permission: user.PermissionUser, chatTypes: []string{"group", "supergroup"}})
+	router.Register(&namedCommand{MockHandler: MockHandler{priority: 90}, name: "ban", permission: user.PermissionAdmin, chatTypes: []string{"group"}})
+	router.Register(&MockHandler{priority: 900, continueChain: true})
+
+	commands := router.Commands()
+
+	// 只包含命令处理器，按命令名排序
+	require.Len(t, commands, 2)
+	assert.Equal(t, CommandInfo{Name: "ban", Description: "ban 命令", Permission: user.PermissionAdmin, ChatTypes: []string{"group"}}, commands[0])
+	assert.Equal(t, "vote", commands[1].Name)
+	assert.True(t, commands[1].AllowsChatType("supergroup"))
+	assert.False(t, commands[1].AllowsChatType("private"))
+	assert.True(t, CommandInfo{Name: "ping"}.AllowsChatType("private"))
+
+	infos := router.Describe()
+	require.Len(t, infos, 3)
+	assert.Equal(t, []string{"group"}, infos[0].ChatTypes)
+	assert.Nil(t, infos[2].ChatTypes)
+}
+
+// TestRouter_Validate 测试同名命令重复注册
+func TestRouter_Validate(t *testing.T) {
+	router := NewRouter()
+	router.Register(&namedCommand{MockHandler: MockHandler{priority: 100}, name: "vote"})
+	router.Register(&namedCommand{MockHandler: MockHandler{priority: 100}, name: "ban"})
+	router.Register(&MockHandler{priority: 900, continueChain: true})
+	require.NoError(t, router.Validate())
+
+	router.Register(&commandHandler{MockHandler: MockHandler{priority: 100}})
+	router.Register(&namedCommand{MockHandler: MockHandler{priority: 100}, name: "ping"})
+
+	err := router.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `command "ping" registered twice`)
+}
+
+// usageHandler 参数有误时返回用法说明的命令
+type usageHandler struct {
+	MockHandler
+}
+
+func (h *usageHandler) Handle(ctx *Context) error {
+	h.handleCalled = true
+	return Usage("用法: <code>/ping &lt;次数&gt;</code>")
+}
+
+// TestRouter_Route_Usage 测试用法错误回复用法说明且不视为失败
+func TestRouter_Route_Usage(t *testing.T) {
+	var sent []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		sent = append(sent, r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}))
+	t.Cleanup(server.Close)
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+
+	// 用法错误经过中间件时仍是错误（例如冷却不会开始），Router 处理后返回 nil
+	var seen error
+	router := NewRouter()
+	router.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			seen = next(ctx)
+			return seen
+		}
+	})
+	cmd := &usageHandler{MockHandler: MockHandler{priority: 100, shouldMatch: true}}
+	router.Register(cmd)
+
+	require.NoError(t, router.Route(&Context{Ctx: context.Background(), Bot: b, ChatID: -100, UserID: 1, Text: "/ping x"}))
+	assert.True(t, cmd.handleCalled)
+	assert.ErrorIs(t, seen, ErrUsage)
+	require.Len(t, sent, 1)
+	assert.Equal(t, "用法: <code>/ping &lt;次数&gt;</code>", sent[0].FormValue("text"))
+	assert.Equal(t, "HTML", sent[0].FormValue("parse_mode"))
+
+	// 没有回复时按输入有误提示
+	assert.Equal(t, "❌ 输入有误，请检查命令格式", UserMessage(Usage("help")))
+}
+
+// TestRouter_Route_Maintenance 测试维护模式拦截
+func TestRouter_Route_Maintenance(t *testing.T) {
+	const ownerID = int64(1)
+
+	tests := []struct {
+		name           string
+		userID         int64
+		pauseListeners bool
+		wantCommand    bool
+		wantListener   bool
+	}{
+		{name: "normal user refused", userID: 2, wantCommand: false, wantListener: true},
+		{name: "owner proceeds", userID: ownerID, wantCommand: true, wantListener: true},
+		{name: "listeners paused", userID: 2, pauseListeners: true, wantCommand: false, wantListener: false},
+		{name: "owner not paused", userID: ownerID, pauseListeners: true, wantCommand: true, wantListener: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintenance := NewMaintenance([]int64{ownerID}, tt.pauseListeners)
+			maintenance.SetActive(true)
+
+			router := NewRouter()
+			router.SetMaintenance(maintenance)
+			listener := &MockHandler{priority: 50, shouldMatch: true, continueChain: true}
+			cmd := &commandHandler{MockHandler: MockHandler{priority: 100, shouldMatch: true}}
+			router.Register(listener)
+			router.Register(cmd)
+
+			err := router.Route(&Context{UserID: tt.userID})
+
+			assert.Equal(t, tt.wantCommand, cmd.handleCalled)
+			assert.Equal(t, tt.wantListener, listener.handleCalled)
+			if tt.wantCommand {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, "🛠 机器人正在维护，暂时无法处理命令，请稍后再试", UserMessage(err))
+		})
+	}
+
+	t.Run("inactive", func(t *testing.T) {
+		router := NewRouter()
+		router.SetMaintenance(NewMaintenance([]int64{ownerID}, true))
+		cmd := &commandHandler{MockHandler: MockHandler{priority: 100, shouldMatch: true}}
+		router.Register(cmd)
+
+		assert.NoError(t, router.Route(&Context{UserID: 2}))
+		assert.True(t, cmd.handleCalled)
+	})
+}
@@ -0,0 +1,175 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math/rand"
+	"os"
+	"sync"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+// Action 趣味动作定义（如 /slap、/hug）
+// 模板使用 %[1]s 表示发起者，%[2]s 表示目标
+type Action struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Templates     []string `json:"templates"`
+	SelfTemplates []string `json:"self_templates"` // 对自己执行时使用的模板，为空则拒绝对自己执行
+}
+
+// DefaultActions 默认动作集合
+var DefaultActions = []Action{
+	{
+		Name:        "slap",
+		Description: "拍一下某人",
+		Templates: []string{
+			"👋 %[1]s 用一条大鱼拍了 %[2]s 一下",
+			"👋 %[1]s 轻轻拍了拍 %[2]s 的脑袋",
+			"👋 %[1]s 抡起拖鞋拍向了 %[2]s",
+		},
+		SelfTemplates: []string{
+			"🤦 %[1]s 给了自己一巴掌",
+		},
+	},
+	{
+		Name:        "hug",
+		Description: "拥抱某人",
+		Templates: []string{
+			"🤗 %[1]s 给了 %[2]s 一个大大的拥抱",
+			"🤗 %[1]s 紧紧抱住了 %[2]s",
+			"🤗 %[1]s 温柔地抱了抱 %[2]s",
+		},
+		SelfTemplates: []string{
+			"🫂 %[1]s 抱了抱自己，要好好爱自己哦",
+		},
+	},
+}
+
+// ActionHandler 趣味动作命令处理器
+type ActionHandler struct {
+	*BaseCommand
+	userRepo UserRepository
+	action   Action
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewActionHandler 创建趣味动作命令处理器
+// 冷却时间由命令冷却中间件按动作名计时（ACTION_COOLDOWN）
+func NewActionHandler(groupRepo GroupRepository, userRepo UserRepository, action Action) *ActionHandler {
+	return &ActionHandler{
+		BaseCommand: NewBaseCommand(
+			action.Name,
+			action.Description,
+			user.PermissionUser, // 所有用户可用
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		userRepo: userRepo,
+		action:   action,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// LoadActions 从 JSON 文件（ACTIONS_FILE）读取动作集合，path 为空时返回 DefaultActions
+// 文件内容为 Action 数组，每个动作必须有命令名和至少一个模板，命令名不能重复
+func LoadActions(path string) ([]Action, error) {
+	if path == "" {
+		return DefaultActions, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read actions file: %w", err)
+	}
+	var actions []Action
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("parse actions file %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool, len(actions))
+	for i, action := range actions {
+		if action.Name == "" || len(action.Templates) == 0 {
+			return nil, fmt.Errorf("action #%d in %s needs a name and at least one template", i+1, path)
+		}
+		if seen[action.Name] {
+			return nil, fmt.Errorf("duplicate action %q in %s", action.Name, path)
+		}
+		seen[action.Name] = true
+	}
+	return actions, nil
+}
+
+// DefaultCooldowns 返回动作命令和 /feedback 的默认冷却时间（命令名 -> 冷却时间），
+// actions 与传给 NewActionHandlers 的动作集合相同。
+// 由命令冷却中间件统一执行，COMMAND_COOLDOWNS 中的同名配置覆盖默认值
+func DefaultCooldowns(actions []Action, actionCooldown, feedbackCooldown time.Duration) map[string]time.Duration {
+	cooldowns := map[string]time.Duration{"feedback": feedbackCooldown}
+	for _, action := range actions {
+		cooldowns[action.Name] = actionCooldown
+	}
+	return cooldowns
+}
+
+// NewActionHandlers 根据动作集合批量创建处理器
+func NewActionHandlers(groupRepo GroupRepository, userRepo UserRepository, actions []Action) []*ActionHandler {
+	handlers := make([]*ActionHandler, 0, len(actions))
+	for _, action := range actions {
+		handlers = append(handlers, NewActionHandler(groupRepo, userRepo, action))
+	}
+	return handlers
+}
+
+// Handle 处理命令
+func (h *ActionHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析目标用户
+	target, err := GetTargetUser(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+
+	// 3. 生成文本（对自己执行时使用专用模板）
+	text, ok := h.render(ctx.User, target)
+	if !ok {
+		return ctx.Reply("❌ 不能对自己执行此操作")
+	}
+
+	return ctx.ReplyHTML(text)
+}
+
+// render 根据发起者和目标生成动作文本
+// 目标为自己且没有自我模板时返回 false
+func (h *ActionHandler) render(actor, target *user.User) (string, bool) {
+	templates := h.action.Templates
+	if actor.ID == target.ID {
+		templates = h.action.SelfTemplates
+	}
+	if len(templates) == 0 {
+		return "", false
+	}
+
+	tmpl := h.pickTemplate(templates)
+	return fmt.Sprintf(tmpl,
+		"<b>"+html.EscapeString(FormatUsername(actor))+"</b>",
+		"<b>"+html.EscapeString(FormatUsername(target))+"</b>",
+	), true
+}
+
+// pickTemplate 随机选择一个模板
+func (h *ActionHandler) pickTemplate(templates []string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return templates[h.rng.Intn(len(templates))]
+}
@@ -0,0 +1,143 @@
+package command
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestActionHandler(action Action, seed int64) *ActionHandler {
+	h := NewActionHandler(new(MockGroupRepository), new(MockUserRepository), action)
+	h.rng = rand.New(rand.NewSource(seed))
+	return h
+}
+
+func TestActionHandler_PickTemplateDeterministic(t *testing.T) {
+	action := DefaultActions[0]
+	h1 := newTestActionHandler(action, 42)
+	h2 := newTestActionHandler(action, 42)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, h1.pickTemplate(action.Templates), h2.pickTemplate(action.Templates))
+	}
+}
+
+func TestActionHandler_Render(t *testing.T) {
+	action := Action{
+		Name:          "slap",
+		Templates:     []string{"%[1]s slaps %[2]s"},
+		SelfTemplates: []string{"%[1]s slaps themselves"},
+	}
+	h := newTestActionHandler(action, 1)
+	actor := &user.User{ID: 1, Username: "alice"}
+	target := &user.User{ID: 2, FirstName: "<Bob>"}
+
+	t.Run("other user", func(t *testing.T) {
+		text, ok := h.render(actor, target)
+		require.True(t, ok)
+		assert.Equal(t, "<b>@alice</b> slaps <b>&lt;Bob&gt;</b>", text)
+	})
+
+	t.Run("self target", func(t *testing.T) {
+		text, ok := h.render(actor, actor)
+		require.True(t, ok)
+		assert.Equal(t, "<b>@alice</b> slaps themselves", text)
+	})
+
+	t.Run("self target without templates", func(t *testing.T) {
+		h := newTestActionHandler(Action{Name: "hug", Templates: []string{"%[1]s hugs %[2]s"}}, 1)
+		_, ok := h.render(actor, actor)
+		assert.False(t, ok)
+	})
+}
+
+func TestActionHandler_ResolveTarget(t *testing.T) {
+	reqCtx := context.TODO()
+	target := &user.User{ID: 456, Username: "target"}
+
+	t.Run("by username", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "target").Return(target, nil).Once()
+
+		ctx := &handler.Context{Text: "/slap @target"}
+		result, err := GetTargetUser(reqCtx, ctx, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, target, result)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("by reply", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByID", reqCtx, int64(456)).Return(target, nil).Once()
+
+		ctx := &handler.Context{Text: "/hug", ReplyTo: &handler.ReplyInfo{UserID: 456}}
+		result, err := GetTargetUser(reqCtx, ctx, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, target, result)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestNewActionHandlers(t *testing.T) {
+	handlers := NewActionHandlers(new(MockGroupRepository), new(MockUserRepository), DefaultActions)
+	require.Len(t, handlers, len(DefaultActions))
+	assert.Equal(t, "slap", handlers[0].GetName())
+	assert.Equal(t, "hug", handlers[1].GetName())
+}
+
+func TestDefaultCooldowns(t *testing.T) {
+	// 冷却时间按传入的动作集合设置，与注册的处理器一致
+	actions := []Action{{Name: "poke", Templates: []string{"%[1]s 戳了戳 %[2]s"}}}
+	cooldowns := DefaultCooldowns(actions, 30*time.Second, 5*time.Minute)
+	assert.Equal(t, map[string]time.Duration{"feedback": 5 * time.Minute, "poke": 30 * time.Second}, cooldowns)
+}
+
+func TestLoadActions(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "actions.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("defaults without file", func(t *testing.T) {
+		actions, err := LoadActions("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultActions, actions)
+	})
+
+	t.Run("loads actions from file", func(t *testing.T) {
+		path := writeFile(t, `[{"name": "poke", "description": "戳一下某人", "templates": ["%[1]s 戳了戳 %[2]s"], "self_templates": ["%[1]s 戳了戳自己"]}]`)
+		actions, err := LoadActions(path)
+		require.NoError(t, err)
+		assert.Equal(t, []Action{{
+			Name:          "poke",
+			Description:   "戳一下某人",
+			Templates:     []string{"%[1]s 戳了戳 %[2]s"},
+			SelfTemplates: []string{"%[1]s 戳了戳自己"},
+		}}, actions)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		for _, content := range []string{
+			`not json`,
+			`[{"name": "poke"}]`,
+			`[{"name": "poke", "templates": ["a"]}, {"name": "poke", "templates": ["b"]}]`,
+		} {
+			_, err := LoadActions(writeFile(t, content))
+			assert.Error(t, err, content)
+		}
+
+		_, err := LoadActions(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+const (
+	FeatureAntiRaid = "anti_raid" // 防突袭功能名称（与 listener/antiraid.go 保持一致）
+)
+
+// RaidController 防突袭模式状态接口
+type RaidController interface {
+	IsActive(groupID int64) bool
+	Lift(groupID int64) bool
+}
+
+// AntiRaidHandler 防突袭命令处理器
+// /antiraid 查看状态，/antiraid on|off 开启/关闭自动防突袭（关闭时同时解除当前的防突袭模式）
+type AntiRaidHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+	raids     RaidController
+}
+
+// NewAntiRaidHandler 创建防突袭命令处理器
+func NewAntiRaidHandler(groupRepo GroupRepository, raids RaidController) *AntiRaidHandler {
+	return &AntiRaidHandler{
+		BaseCommand: NewBaseCommand(
+			"antiraid",
+			"查看/开启/关闭防突袭模式",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		raids:     raids,
+	}
+}
+
+// Handle 处理命令
+func (h *AntiRaidHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 || args[0] == "status" {
+		return ctx.ReplyHTML(h.formatStatus(g.IsFeatureOptedIn(FeatureAntiRaid), ctx.ChatID))
+	}
+
+	// 3. 切换功能
+	switch args[0] {
+	case "on":
+		g.EnableFeature(FeatureAntiRaid)
+	case "off":
+		g.DisableFeature(FeatureAntiRaid)
+		h.raids.Lift(ctx.ChatID)
+	default:
+		return handler.Usage(antiRaidUsage())
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	return ctx.ReplyHTML(h.formatStatus(args[0] == "on", ctx.ChatID))
+}
+
+// formatStatus 格式化防突袭状态
+func (h *AntiRaidHandler) formatStatus(enabled bool, chatID int64) string {
+	text := fmt.Sprintf("🛡 <b>防突袭</b>\n\n自动检测: %s", getStatusEmoji(enabled))
+	if h.raids.IsActive(chatID) {
+		text += "\n当前状态: 🚨 防突袭模式中（新成员将被暂时禁言）"
+	} else {
+		text += "\n当前状态: 正常"
+	}
+	return text + "\n\n" + antiRaidUsage()
+}
+
+// antiRaidUsage 返回命令用法说明
+func antiRaidUsage() string {
+	return "用法: <code>/antiraid on|off|status</code>\n" +
+		"<i>开启后，短时间内大量新成员入群会自动触发防突袭模式，一段时间无人入群后自动解除</i>"
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// autoDeleteMaxSeconds 自动删除的最长时间：机器人无法删除群组中超过 48 小时的消息
+const autoDeleteMaxSeconds = 48 * 60 * 60
+
+// AutoDeleteHandler 设置机器人回复自动删除命令处理器
+// /autodelete <秒数> 机器人的回复（如管理操作确认）在设定时间后自动删除；/autodelete off 关闭
+// 只影响回复消息，欢迎消息、公告等不受影响
+type AutoDeleteHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewAutoDeleteHandler 创建设置机器人回复自动删除命令处理器
+func NewAutoDeleteHandler(groupRepo GroupRepository) *AutoDeleteHandler {
+	return &AutoDeleteHandler{
+		BaseCommand: NewBaseCommand(
+			"autodelete",
+			"设置机器人回复在多少秒后自动删除",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *AutoDeleteHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前设置
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		status := "未开启"
+		if after := g.AutoDeleteAfter(); after > 0 {
+			status = fmt.Sprintf("%d 秒后删除", int(after.Seconds()))
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("🧹 机器人回复自动删除: <b>%s</b>\n用法: <code>/autodelete 秒数|off</code>", status))
+	}
+
+	// 4. 更新设置
+	var seconds int64
+	if !strings.EqualFold(args[0], "off") {
+		seconds, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil || seconds < 0 || seconds > autoDeleteMaxSeconds {
+			return ctx.Reply(fmt.Sprintf("❌ 请输入 0-%d 之间的秒数，或 off 关闭", autoDeleteMaxSeconds))
+		}
+	}
+
+	if seconds == 0 {
+		g.DeleteSetting(group.SettingAutoDeleteSeconds)
+	} else {
+		g.SetSetting(group.SettingAutoDeleteSeconds, seconds)
+	}
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	if seconds == 0 {
+		return ctx.Reply("✅ 已关闭机器人回复自动删除")
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 机器人回复将在 <b>%d 秒</b>后自动删除", seconds))
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoDeleteHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Twice()
+
+	h := NewAutoDeleteHandler(groupRepo)
+
+	// 超出范围不保存
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/autodelete 999999")))
+	assert.Zero(t, g.AutoDeleteAfter())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/autodelete 30")))
+	assert.Equal(t, 30*time.Second, g.AutoDeleteAfter())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/autodelete off")))
+	assert.Zero(t, g.AutoDeleteAfter())
+	_, ok := g.GetSetting(group.SettingAutoDeleteSeconds)
+	assert.False(t, ok)
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "请输入 0-172800 之间的秒数")
+	assert.Contains(t, replies[1], "30 秒")
+	assert.Equal(t, "✅ 已关闭机器人回复自动删除", replies[2])
+	groupRepo.AssertExpectations(t)
+}
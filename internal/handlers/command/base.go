@@ -32,6 +32,8 @@ type BaseCommand struct {
 	permission  user.Permission
 	chatTypes   []string // 支持的聊天类型：private, group, supergroup, channel
 	groupRepo   GroupRepository
+
+	requireMembership bool // 群组中要求调用者是群组成员（默认关闭，避免额外的 API 调用）
 }
 
 // NewBaseCommand 创建命令基类
@@ -63,25 +65,27 @@ func (c *BaseCommand) Match(ctx *handler.Context) bool {
 		return false
 	}
 
-	// 2. 必须以 / 开头
-	if ctx.Text[0] != '/' {
+	// 2. 必须以命令前缀开头（默认 /，可通过 COMMAND_PREFIXES 配置）
+	cmdName, ok := handler.ParseCommand(ctx.Text)
+	if !ok {
 		return false
 	}
 
-	// 3. 解析命令名
-	cmdName := parseCommandName(ctx.Text)
-	if cmdName != c.name {
+	// 2.1. 编辑后的消息不重新执行命令（避免 /command 在编辑时重复触发）
+	if ctx.IsEdit {
 		return false
 	}
 
-	// 4. 检查聊天类型
-	if !c.isSupportedChatType(ctx.ChatType) {
+	// 3. 匹配命令名
+	if cmdName != c.name {
 		return false
 	}
 
-	// 5. 检查群组是否启用（如果是群组且有 groupRepo）
+	// 聊天类型不在此检查：由 Router 通过 AllowedChatTypes 拦截并提示用户
+
+	// 4. 检查群组是否启用（如果是群组且有 groupRepo）
 	if ctx.IsGroup() && c.groupRepo != nil {
-		reqCtx := context.TODO() // TODO: 从 handler.Context 传递
+		reqCtx := ctx.RequestContext()
 		g, err := c.groupRepo.FindByID(reqCtx, ctx.ChatID)
 		if err != nil {
 			// 区分群组不存在和数据库错误
@@ -128,38 +132,31 @@ func (c *BaseCommand) GetPermission() user.Permission {
 	return c.permission
 }
 
+// RequireMembership 要求群组中的调用者是群组成员（由 MembershipMiddleware 检查）
+// 用于容易被非成员滥用的命令，例如关联频道讨论组中非成员的评论
+func (c *BaseCommand) RequireMembership() {
+	c.requireMembership = true
+}
+
+// RequiresMembership 是否要求调用者是群组成员（实现 middleware.MembershipRequired）
+func (c *BaseCommand) RequiresMembership() bool {
+	return c.requireMembership
+}
+
 // CheckPermission 检查权限
 func (c *BaseCommand) CheckPermission(ctx *handler.Context) error {
 	return ctx.RequirePermission(c.permission)
 }
 
-// isSupportedChatType 检查是否支持该聊天类型
-func (c *BaseCommand) isSupportedChatType(chatType string) bool {
-	for _, t := range c.chatTypes {
-		if t == chatType {
-			return true
-		}
-	}
-	return false
+// AllowedChatTypes 支持的聊天类型（实现 handler.ChatTypeRestricted）
+func (c *BaseCommand) AllowedChatTypes() []string {
+	return c.chatTypes
 }
 
 // parseCommandName 解析命令名
-// "/ping" -> "ping"
-// "/ping@botname" -> "ping"
-// "/ping arg1 arg2" -> "ping"
+// "/command@botname arg" -> "command"，不是命令时返回空字符串
 func parseCommandName(text string) string {
-	parts := strings.Fields(text)
-	if len(parts) == 0 {
-		return ""
-	}
-
-	cmd := strings.TrimPrefix(parts[0], "/")
-
-	// 移除 @botname
-	if idx := strings.Index(cmd, "@"); idx != -1 {
-		cmd = cmd[:idx]
-	}
-
+	cmd, _ := handler.ParseCommand(text)
 	return cmd
 }
 
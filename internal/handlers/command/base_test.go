@@ -2,6 +2,7 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
@@ -75,13 +76,23 @@ func TestBaseCommand_Match(t *testing.T) {
 			expected: false,
 		},
 		{
-			name: "does not match unsupported chat type",
+			name: "does not match edited message",
 			ctx: &handler.Context{
 				Text:     "/test",
-				ChatType: "channel",
+				ChatType: "private",
+				IsEdit:   true,
 			},
 			expected: false,
 		},
+		{
+			// 聊天类型由 Router 通过 AllowedChatTypes 拦截
+			name: "matches unsupported chat type",
+			ctx: &handler.Context{
+				Text:     "/test",
+				ChatType: "channel",
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,6 +112,37 @@ func TestBaseCommand_Match(t *testing.T) {
 	}
 }
 
+// recordingListener 记录被调用次数的监听器
+type recordingListener struct {
+	calls int
+}
+
+func (l *recordingListener) Match(ctx *handler.Context) bool { return true }
+func (l *recordingListener) Handle(ctx *handler.Context) error {
+	l.calls++
+	return nil
+}
+func (l *recordingListener) Priority() int       { return 900 }
+func (l *recordingListener) ContinueChain() bool { return true }
+
+func TestRouter_EditedCommandReachesListenersOnly(t *testing.T) {
+	router := handler.NewRouter()
+	router.Register(NewPingHandler(nil))
+	listener := &recordingListener{}
+	router.Register(listener)
+
+	// 如果命令被重新执行，PingHandler 会调用 Reply（Bot 为 nil 时 panic）
+	ctx := &handler.Context{
+		Text:     "/ping",
+		ChatType: "private",
+		IsEdit:   true,
+	}
+
+	err := router.Route(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, listener.calls)
+}
+
 func TestParseCommandName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -159,6 +201,7 @@ func TestBaseCommand_GetMethods(t *testing.T) {
 	assert.Equal(t, user.PermissionAdmin, base.GetPermission())
 	assert.Equal(t, 100, base.Priority())
 	assert.False(t, base.ContinueChain())
+	assert.Equal(t, []string{"private"}, base.AllowedChatTypes())
 }
 
 func TestBaseCommand_CheckPermission(t *testing.T) {
@@ -224,3 +267,32 @@ func TestBaseCommand_CheckPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseCommand_MatchCustomPrefixes(t *testing.T) {
+	handler.SetCommandPrefixes([]string{"/", "!"})
+	defer handler.SetCommandPrefixes(nil)
+
+	cmd := NewBaseCommand("ping", "test", user.PermissionUser, nil, nil)
+
+	assert.True(t, cmd.Match(&handler.Context{Text: "/ping", ChatType: "private"}))
+	assert.True(t, cmd.Match(&handler.Context{Text: "!ping", ChatType: "private"}))
+	assert.True(t, cmd.Match(&handler.Context{Text: "!ping@mybot arg", ChatType: "private"}))
+	assert.False(t, cmd.Match(&handler.Context{Text: ".ping", ChatType: "private"}))
+	assert.Equal(t, []string{"arg"}, ParseArgs("!ping@mybot arg"))
+}
+
+func TestBaseCommand_MatchBotMention(t *testing.T) {
+	handler.SetBotUsername("mybot")
+	defer handler.SetBotUsername("")
+
+	h := NewTempBanHandler(nil, nil, nil, nil, nil, nil)
+	ctx := &handler.Context{Text: "/tempban@mybot @spammer 1h flood", ChatType: "supergroup"}
+
+	assert.True(t, h.Match(ctx))
+	req, err := parseTempBanArgs(ctx.Text, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &tempBanRequest{duration: time.Hour, reason: "flood"}, req)
+
+	// 发给其他机器人的命令不处理
+	assert.False(t, h.Match(&handler.Context{Text: "/tempban@otherbot @spammer 1h", ChatType: "supergroup"}))
+}
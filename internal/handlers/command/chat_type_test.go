@@ -0,0 +1,109 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChatTypeTestRouter 创建注册了 /help 与 /tempban 的路由器
+func newChatTypeTestRouter() *handler.Router {
+	router := handler.NewRouter()
+	router.Register(NewHelpHandler(nil, router))
+	router.Register(NewTempBanHandler(nil, nil, nil, nil, nil, nil))
+	return router
+}
+
+// newPrivateTestContext 创建私聊消息上下文（私聊 ChatID 即用户 ID）
+func newPrivateTestContext(tb *testBot, u *user.User, text string) *handler.Context {
+	ctx := newTestContext(tb, u.ID, u, text)
+	ctx.ChatType = "private"
+	return ctx
+}
+
+func TestCommands_AllowedChatTypes(t *testing.T) {
+	help := NewHelpHandler(nil, handler.NewRouter())
+	tempban := NewTempBanHandler(nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		chatType    string
+		helpAllowed bool
+		banAllowed  bool
+	}{
+		{chatType: "private", helpAllowed: true, banAllowed: false},
+		{chatType: "group", helpAllowed: true, banAllowed: true},
+		{chatType: "supergroup", helpAllowed: true, banAllowed: true},
+		{chatType: "channel", helpAllowed: false, banAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chatType, func(t *testing.T) {
+			assert.Equal(t, tt.helpAllowed, handler.AllowsChatType(help, tt.chatType))
+			assert.Equal(t, tt.banAllowed, handler.AllowsChatType(tempban, tt.chatType))
+		})
+	}
+}
+
+func TestRouter_RefusesGroupCommandInPrivateChat(t *testing.T) {
+	tb := newTestBot(t)
+	router := newChatTypeTestRouter()
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	err := router.Route(newPrivateTestContext(tb, u, "/tempban @bob 1h"))
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.CodeUnsupportedChatType))
+	assert.Contains(t, handler.UserMessage(err), "群组、超级群组")
+
+	// 处理器未执行
+	assert.Empty(t, tb.Calls("sendMessage"))
+	assert.Empty(t, tb.Calls("banChatMember"))
+}
+
+func TestRouter_RefusesCommandInChannel(t *testing.T) {
+	tb := newTestBot(t)
+	router := newChatTypeTestRouter()
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	ctx := newTestContext(tb, -100123, u, "/help")
+	ctx.ChatType = "channel"
+
+	err := router.Route(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.CodeUnsupportedChatType))
+	assert.Contains(t, handler.UserMessage(err), "私聊、群组、超级群组")
+}
+
+func TestRouter_HelpWorksInPrivateChat(t *testing.T) {
+	tb := newTestBot(t)
+	router := newChatTypeTestRouter()
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	require.NoError(t, router.Route(newPrivateTestContext(tb, u, "/help")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "/help")
+	// 私聊帮助不列出群组专用命令
+	assert.NotContains(t, replies[0], "/tempban")
+}
+
+// 私聊没有群组概念：私聊中的命令不应触发群组 ID 校验（私聊 ChatID 为正数）
+func TestRouter_PrivateChatSkipsGroupIDValidation(t *testing.T) {
+	tb := newTestBot(t)
+	router := newChatTypeTestRouter()
+	// 仓储为 nil：私聊流程若访问群组仓储会直接 panic
+	router.Register(NewStatsHandler(nil, nil, nil, nil, nil))
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	require.NoError(t, router.Route(newPrivateTestContext(tb, u, "/help")))
+
+	err := router.Route(newPrivateTestContext(tb, u, "/stats"))
+	require.Error(t, err)
+	assert.False(t, errors.HasCode(err, errors.CodeValidation))
+	assert.True(t, errors.HasCode(err, errors.CodeUnsupportedChatType))
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+)
+
+const (
+	defaultCleanupCount = 10 // 未指定数量时删除的消息数
+	maxCleanupCount     = 50 // 单次最多删除的消息数
+)
+
+// SentMessageStore 机器人已发送消息记录接口
+type SentMessageStore interface {
+	Recent(chatID int64, n int) []int
+	Forget(chatID int64, messageIDs []int)
+}
+
+// MessageDeleter 删除消息接口
+// 消息已被删除时返回 CodeNotFound 错误
+type MessageDeleter interface {
+	DeleteMessage(ctx context.Context, chatID int64, messageID int) error
+}
+
+// CleanupHandler 清理机器人消息命令处理器
+// /cleanup [n] 删除机器人在本群最近发送的 n 条消息（只删除记录过的机器人消息）
+type CleanupHandler struct {
+	*BaseCommand
+	sent    SentMessageStore
+	deleter MessageDeleter
+}
+
+// NewCleanupHandler 创建清理机器人消息命令处理器
+func NewCleanupHandler(groupRepo GroupRepository, sent SentMessageStore, deleter MessageDeleter) *CleanupHandler {
+	return &CleanupHandler{
+		BaseCommand: NewBaseCommand(
+			"cleanup",
+			"删除机器人最近发送的消息",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		sent:    sent,
+		deleter: deleter,
+	}
+}
+
+// Handle 处理命令
+func (h *CleanupHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析数量
+	count := defaultCleanupCount
+	if args := ParseArgs(ctx.Text); len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return ctx.Reply(fmt.Sprintf("❌ 用法: /cleanup [数量]（1-%d）", maxCleanupCount))
+		}
+		count = n
+	}
+	if count > maxCleanupCount {
+		count = maxCleanupCount
+	}
+
+	// 3. 逐条删除，已被删除的消息视为成功
+	ids := h.sent.Recent(ctx.ChatID, count)
+	if len(ids) == 0 {
+		return ctx.Reply("🧹 没有可清理的机器人消息")
+	}
+
+	deleted, failed := 0, 0
+	for _, id := range ids {
+		err := h.deleter.DeleteMessage(reqCtx, ctx.ChatID, id)
+		switch {
+		case err == nil:
+			deleted++
+		case errors.HasCode(err, errors.CodeNotFound):
+			// 已被手动删除
+		default:
+			failed++
+		}
+	}
+
+	// 删除失败的消息（例如超过 48 小时）之后也无法删除，一并移除记录
+	h.sent.Forget(ctx.ChatID, ids)
+
+	text := fmt.Sprintf("🧹 已删除 %d 条机器人消息", deleted)
+	if failed > 0 {
+		text += fmt.Sprintf("，%d 条删除失败（超过 48 小时的消息无法删除）", failed)
+	}
+	return ctx.Send(text)
+}
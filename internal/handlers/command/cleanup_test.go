@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSentStore 按聊天记录机器人消息 ID（末尾为最新）
+type fakeSentStore struct {
+	chats map[int64][]int
+}
+
+func (f *fakeSentStore) RecordSent(chatID int64, messageID int) {
+	f.chats[chatID] = append(f.chats[chatID], messageID)
+}
+
+func (f *fakeSentStore) Recent(chatID int64, n int) []int {
+	ids := f.chats[chatID]
+	var recent []int
+	for i := len(ids) - 1; i >= 0 && len(recent) < n; i-- {
+		recent = append(recent, ids[i])
+	}
+	return recent
+}
+
+func (f *fakeSentStore) Forget(chatID int64, messageIDs []int) {
+	forget := make(map[int]bool)
+	for _, id := range messageIDs {
+		forget[id] = true
+	}
+	var kept []int
+	for _, id := range f.chats[chatID] {
+		if !forget[id] {
+			kept = append(kept, id)
+		}
+	}
+	f.chats[chatID] = kept
+}
+
+// fakeMessageDeleter 记录删除的消息，gone 中的消息视为已被删除
+type fakeMessageDeleter struct {
+	deleted []int
+	gone    map[int]bool
+}
+
+func (f *fakeMessageDeleter) DeleteMessage(ctx context.Context, chatID int64, messageID int) error {
+	if f.gone[messageID] {
+		return errors.New(errors.CodeNotFound, "message not found")
+	}
+	f.deleted = append(f.deleted, messageID)
+	return nil
+}
+
+func TestContext_RecordsSentMessages(t *testing.T) {
+	tb := newTestBot(t)
+	store := &fakeSentStore{chats: make(map[int64][]int)}
+
+	ctx := newTestContext(tb, -100123, user.NewUser(1, "alice", "", ""), "hi")
+	ctx.Sent = store
+
+	require.NoError(t, ctx.Reply("one"))
+	require.NoError(t, ctx.SendHTML("<b>two</b>"))
+
+	assert.Equal(t, []int{1, 2}, store.chats[-100123])
+}
+
+func TestCleanupHandler_DeletesOnlyBotMessages(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	// 机器人在本群发送过 101-105，其他群的记录不受影响
+	store := &fakeSentStore{chats: map[int64][]int{
+		chatID:  {101, 102, 103, 104, 105},
+		-100999: {900},
+	}}
+	deleter := &fakeMessageDeleter{gone: map[int]bool{104: true}}
+
+	h := NewCleanupHandler(nil, store, deleter)
+	ctx := newTestContext(tb, chatID, admin, "/cleanup 3")
+	ctx.MessageID = 500 // 命令消息本身不是机器人消息
+	require.NoError(t, h.Handle(ctx))
+
+	assert.Equal(t, []int{105, 103}, deleter.deleted)
+	assert.Equal(t, []int{101, 102}, store.chats[chatID])
+	assert.Equal(t, []int{900}, store.chats[-100999])
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Equal(t, "🧹 已删除 2 条机器人消息", replies[0])
+}
+
+func TestCleanupHandler_CapsCount(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	store := &fakeSentStore{chats: make(map[int64][]int)}
+	for i := 1; i <= maxCleanupCount+10; i++ {
+		store.RecordSent(chatID, i)
+	}
+	deleter := &fakeMessageDeleter{}
+
+	h := NewCleanupHandler(nil, store, deleter)
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/cleanup 1000")))
+
+	assert.Len(t, deleter.deleted, maxCleanupCount)
+	assert.Len(t, store.chats[chatID], 10)
+	assert.Equal(t, []string{fmt.Sprintf("🧹 已删除 %d 条机器人消息", maxCleanupCount)}, tb.Replies())
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+)
+
+// CommandConfigRepository 启动时检查命令配置所需的群组仓储接口
+type CommandConfigRepository interface {
+	FindAll(ctx context.Context) ([]*group.Group, error)
+	UnsetCommands(ctx context.Context, id int64, names []string) error
+}
+
+// StaleCommandConfig 群组中已不存在的命令的配置
+type StaleCommandConfig struct {
+	GroupID  int64
+	Commands []string
+	Pruned   bool // 是否已从群组配置中删除
+}
+
+// ReconcileCommandConfigs 找出群组中已从代码移除的命令的启用/禁用配置
+// prune 为 true 时只删除这些命令的配置（不覆盖群组的其他字段）；否则只返回，由调用方记录。
+// 删除失败时继续处理其他群组，返回最后一个错误
+func ReconcileCommandConfigs(ctx context.Context, repo CommandConfigRepository, commands []handler.CommandInfo, prune bool) ([]StaleCommandConfig, error) {
+	groups, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find groups: %w", err)
+	}
+
+	known := make(map[string]bool, len(commands))
+	for _, cmd := range commands {
+		known[cmd.Name] = true
+	}
+
+	var stale []StaleCommandConfig
+	var lastErr error
+	for _, g := range groups {
+		names := g.StaleCommands(known)
+		if len(names) == 0 {
+			continue
+		}
+		if !prune {
+			stale = append(stale, StaleCommandConfig{GroupID: g.ID, Commands: names})
+			continue
+		}
+
+		if err := repo.UnsetCommands(ctx, g.ID, names); err != nil {
+			lastErr = fmt.Errorf("unset commands in group %d: %w", g.ID, err)
+			stale = append(stale, StaleCommandConfig{GroupID: g.ID, Commands: names})
+			continue
+		}
+		stale = append(stale, StaleCommandConfig{GroupID: g.ID, Commands: names, Pruned: true})
+	}
+	return stale, lastErr
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileCommandConfigs(t *testing.T) {
+	commands := []handler.CommandInfo{{Name: "ping"}, {Name: "ban"}}
+
+	newGroups := func() (*group.Group, *group.Group) {
+		stale := group.NewGroup(-100123, "Stale", "supergroup")
+		stale.DisableCommand("ping", 1)
+		stale.DisableCommand("warn", 1)
+		stale.EnableCommand("oldcmd", 1)
+		clean := group.NewGroup(-100456, "Clean", "supergroup")
+		clean.DisableCommand("ban", 1)
+		return stale, clean
+	}
+
+	t.Run("flags without pruning", func(t *testing.T) {
+		stale, clean := newGroups()
+		repo := new(MockGlobalStatsGroupRepository)
+		repo.On("FindAll", mock.Anything).Return([]*group.Group{stale, clean}, nil)
+
+		result, err := ReconcileCommandConfigs(context.Background(), repo, commands, false)
+		require.NoError(t, err)
+		assert.Equal(t, []StaleCommandConfig{{GroupID: -100123, Commands: []string{"oldcmd", "warn"}}}, result)
+		assert.Len(t, stale.Commands, 3)
+		repo.AssertNotCalled(t, "UnsetCommands", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("prunes unknown commands", func(t *testing.T) {
+		stale, clean := newGroups()
+		repo := new(MockGlobalStatsGroupRepository)
+		repo.On("FindAll", mock.Anything).Return([]*group.Group{stale, clean}, nil)
+		// 只删除未知命令的配置，已知命令的配置保留
+		repo.On("UnsetCommands", mock.Anything, int64(-100123), []string{"oldcmd", "warn"}).Return(nil).Once()
+
+		result, err := ReconcileCommandConfigs(context.Background(), repo, commands, true)
+		require.NoError(t, err)
+		assert.Equal(t, []StaleCommandConfig{{GroupID: -100123, Commands: []string{"oldcmd", "warn"}, Pruned: true}}, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unset failure", func(t *testing.T) {
+		stale, _ := newGroups()
+		repo := new(MockGlobalStatsGroupRepository)
+		repo.On("FindAll", mock.Anything).Return([]*group.Group{stale}, nil)
+		repo.On("UnsetCommands", mock.Anything, int64(-100123), []string{"oldcmd", "warn"}).Return(assert.AnError).Once()
+
+		result, err := ReconcileCommandConfigs(context.Background(), repo, commands, true)
+		require.ErrorIs(t, err, assert.AnError)
+		require.Len(t, result, 1)
+		assert.False(t, result[0].Pruned)
+	})
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"strconv"
+	"time"
+)
+
+// 列表命令的分页游标：上一页最后一条记录的创建时间（Unix 毫秒）
+// 下一页查询早于该时间的记录，与 MongoDB 存储的毫秒精度一致
+
+// parseCursor 解析分页游标
+func parseCursor(s string) (time.Time, bool) {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// formatCursor 格式化分页游标
+func formatCursor(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// nextPageHint 本页已满时返回翻页提示（command 为不含游标的完整命令），否则返回空字符串
+func nextPageHint(command string, count, limit int, last time.Time) string {
+	if count < limit {
+		return ""
+	}
+	return "\n➡️ 下一页: <code>" + command + " " + formatCursor(last) + "</code>"
+}
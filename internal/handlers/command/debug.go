@@ -0,0 +1,123 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// DebugHandler 调试命令处理器（仅限配置的 Owner）
+// 回复机器人从当前消息解析出的上下文，便于排查问题
+type DebugHandler struct {
+	*BaseCommand
+	ownerIDs []int64
+}
+
+// NewDebugHandler 创建调试命令处理器
+func NewDebugHandler(groupRepo GroupRepository, ownerIDs []int64) *DebugHandler {
+	return &DebugHandler{
+		BaseCommand: NewBaseCommand(
+			"debug",
+			"查看当前消息的解析结果",
+			user.PermissionOwner, // 需要 Owner 权限
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		ownerIDs: ownerIDs,
+	}
+}
+
+// Handle 处理命令
+func (h *DebugHandler) Handle(ctx *handler.Context) error {
+	return ctx.ReplyHTML(h.buildResponse(ctx))
+}
+
+// buildResponse 生成回复内容
+// 群组内被设置为 Owner 的用户也无法查看，仅限 BOT_OWNER_IDS 中配置的用户
+func (h *DebugHandler) buildResponse(ctx *handler.Context) string {
+	if !isOwnerID(h.ownerIDs, ctx.UserID) {
+		return "❌ 此命令仅限机器人所有者使用"
+	}
+	return formatDebugInfo(ctx)
+}
+
+// formatDebugInfo 格式化上下文信息
+func formatDebugInfo(ctx *handler.Context) string {
+	var sb strings.Builder
+	sb.WriteString("🔧 <b>Debug</b>\n<pre>")
+
+	writeField := func(key string, value interface{}) {
+		sb.WriteString(html.EscapeString(fmt.Sprintf("%-12s %v\n", key+":", value)))
+	}
+
+	writeField("request_id", ctx.RequestID)
+	writeField("user_id", ctx.UserID)
+	writeField("username", ctx.Username)
+	writeField("chat_id", ctx.ChatID)
+	writeField("chat_type", ctx.ChatType)
+	writeField("message_id", ctx.MessageID)
+	writeField("is_edit", ctx.IsEdit)
+	writeField("command", parseCommandName(ctx.Text))
+	writeField("args", fmt.Sprintf("%q", ParseArgs(ctx.Text)))
+
+	if ctx.ReplyTo != nil {
+		writeField("reply_to", fmt.Sprintf("msg=%d user=%d @%s", ctx.ReplyTo.MessageID, ctx.ReplyTo.UserID, ctx.ReplyTo.Username))
+	} else {
+		writeField("reply_to", "-")
+	}
+
+	if ctx.User != nil {
+		groupID := ctx.ChatID
+		if ctx.IsPrivate() {
+			groupID = 0 // 全局权限
+		}
+		writeField("permission", ctx.User.GetPermission(groupID).String())
+	} else {
+		writeField("permission", "-")
+	}
+
+	if ctx.Group != nil {
+		writeField("disabled", formatDisabledCommands(ctx))
+		writeField("settings", formatSettings(ctx.Group.Settings))
+	}
+
+	sb.WriteString("</pre>")
+	return sb.String()
+}
+
+// formatDisabledCommands 列出群组中被禁用的命令
+func formatDisabledCommands(ctx *handler.Context) string {
+	var disabled []string
+	for name, cfg := range ctx.Group.Commands {
+		if !cfg.Enabled {
+			disabled = append(disabled, name)
+		}
+	}
+	if len(disabled) == 0 {
+		return "-"
+	}
+	sort.Strings(disabled)
+	return strings.Join(disabled, ",")
+}
+
+// formatSettings 按键排序输出群组配置
+func formatSettings(settings map[string]interface{}) string {
+	if len(settings) == 0 {
+		return "-"
+	}
+
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, settings[k]))
+	}
+	return strings.Join(parts, ",")
+}
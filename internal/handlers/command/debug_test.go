@@ -0,0 +1,56 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDebugContext(userID int64) *handler.Context {
+	u := user.NewUser(userID, "alice", "Alice", "")
+	u.SetPermission(-100, user.PermissionOwner) // 群组内 Owner，但不在配置列表中
+
+	g := group.NewGroup(-100, "Test", "supergroup")
+	g.DisableCommand("ping", 1)
+	g.DisableFeature("calculator")
+
+	return &handler.Context{
+		RequestID: "req123",
+		ChatType:  "supergroup",
+		ChatID:    -100,
+		UserID:    userID,
+		Username:  "alice",
+		Text:      "/debug foo <bar>",
+		MessageID: 42,
+		ReplyTo:   &handler.ReplyInfo{MessageID: 41, UserID: 456, Username: "bob"},
+		User:      u,
+		Group:     g,
+	}
+}
+
+func TestDebugHandler_RefusesNonOwner(t *testing.T) {
+	h := NewDebugHandler(new(MockGroupRepository), []int64{1})
+
+	result := h.buildResponse(newDebugContext(2))
+	assert.Equal(t, "❌ 此命令仅限机器人所有者使用", result)
+	assert.NotContains(t, result, "req123")
+}
+
+func TestDebugHandler_OwnerGetsDump(t *testing.T) {
+	h := NewDebugHandler(new(MockGroupRepository), []int64{1})
+
+	result := h.buildResponse(newDebugContext(1))
+	assert.Contains(t, result, "request_id:  req123")
+	assert.Contains(t, result, "user_id:     1")
+	assert.Contains(t, result, "chat_id:     -100")
+	assert.Contains(t, result, "message_id:  42")
+	assert.Contains(t, result, `args:        [&#34;foo&#34; &#34;&lt;bar&gt;&#34;]`)
+	assert.Contains(t, result, "reply_to:    msg=41 user=456 @bob")
+	assert.Contains(t, result, "permission:  Owner")
+	assert.Contains(t, result, "disabled:    ping")
+	assert.Contains(t, result, "settings:    calculator=false")
+}
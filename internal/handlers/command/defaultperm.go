@@ -0,0 +1,75 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// DefaultPermHandler 设置新用户默认权限命令处理器
+// /defaultperm none 让首次出现的用户默认没有权限（需要管理员 /setperm 后才能使用命令），
+// /defaultperm user 恢复默认
+type DefaultPermHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewDefaultPermHandler 创建设置新用户默认权限命令处理器
+func NewDefaultPermHandler(groupRepo GroupRepository) *DefaultPermHandler {
+	return &DefaultPermHandler{
+		BaseCommand: NewBaseCommand(
+			"defaultperm",
+			"设置新用户在本群的默认权限",
+			user.PermissionSuperAdmin, // 需要 SuperAdmin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *DefaultPermHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前设置
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		current := user.PermissionUser
+		if value, ok := g.GetStringSetting(group.SettingDefaultPermission); ok && strings.EqualFold(value, "none") {
+			current = user.PermissionNone
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("ℹ️ 新用户默认权限: <b>%s</b>\n用法: <code>/defaultperm none|user</code>", current.String()))
+	}
+
+	// 4. 更新设置
+	var text string
+	switch strings.ToLower(args[0]) {
+	case "none":
+		g.SetSetting(group.SettingDefaultPermission, "none")
+		text = "✅ 新用户默认权限已设为 <b>None</b>\n<i>首次出现的用户需要管理员使用 /setperm 授予权限后才能使用命令</i>"
+	case "user":
+		g.DeleteSetting(group.SettingDefaultPermission)
+		text = "✅ 新用户默认权限已恢复为 <b>User</b>"
+	default:
+		return ctx.Reply("❌ 用法: /defaultperm none|user")
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+	return ctx.ReplyHTML(text)
+}
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"fmt"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
@@ -11,10 +10,11 @@ import (
 type DemoteHandler struct {
 	*BaseCommand
 	userRepo UserRepository
+	auditLog PermissionAuditLog
 }
 
 // NewDemoteHandler 创建降低权限命令处理器
-func NewDemoteHandler(groupRepo GroupRepository, userRepo UserRepository) *DemoteHandler {
+func NewDemoteHandler(groupRepo GroupRepository, userRepo UserRepository, auditLog PermissionAuditLog) *DemoteHandler {
 	return &DemoteHandler{
 		BaseCommand: NewBaseCommand(
 			"demote",
@@ -24,12 +24,13 @@ func NewDemoteHandler(groupRepo GroupRepository, userRepo UserRepository) *Demot
 			groupRepo,
 		),
 		userRepo: userRepo,
+		auditLog: auditLog,
 	}
 }
 
 // Handle 处理命令
 func (h *DemoteHandler) Handle(ctx *handler.Context) error {
-	reqCtx := context.TODO()
+	reqCtx := ctx.RequestContext()
 
 	// 1. 检查权限
 	if err := h.CheckPermission(ctx); err != nil {
@@ -39,7 +40,7 @@ func (h *DemoteHandler) Handle(ctx *handler.Context) error {
 	// 2. 获取目标用户
 	targetUser, err := GetTargetUser(reqCtx, ctx, h.userRepo)
 	if err != nil {
-		return ctx.Reply(fmt.Sprintf("❌ %s", err.Error()))
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
 	}
 
 	// 2.1. 不能对自己执行操作
@@ -71,13 +72,14 @@ func (h *DemoteHandler) Handle(ctx *handler.Context) error {
 		return ctx.Reply("❌ 权限更新失败，请稍后重试")
 	}
 
-	// 7. 更新本地对象（用于显示）
+	// 7. 更新本地对象（用于显示），并记录变更
 	targetUser.SetPermission(ctx.ChatID, newPerm)
+	auditNote := recordPermissionChange(reqCtx, h.auditLog, ctx, targetUser, currentPerm, newPerm)
 
 	// 8. 成功反馈
 	return ctx.ReplyHTML(fmt.Sprintf("✅ 用户 <b>%s</b> 权限已降低:\n<b>%s</b> → <b>%s</b> %s",
 		FormatUsername(targetUser),
 		currentPerm.String(),
 		newPerm.String(),
-		GetPermIcon(newPerm)))
+		GetPermIcon(newPerm)) + auditNote)
 }
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/feedback"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	feedbackMaxLength = 1000 // 单条反馈最大字数
+	feedbackListLimit = 10   // /feedback list 展示的条数
+)
+
+// FeedbackRepository 用户反馈仓储接口
+type FeedbackRepository interface {
+	Create(ctx context.Context, f *feedback.Feedback) error
+	ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*feedback.Feedback, error)
+}
+
+// FeedbackHandler 用户反馈命令处理器
+// /feedback <内容> 提交反馈（冷却时间由命令冷却中间件控制，FEEDBACK_COOLDOWN）；
+// /feedback list [游标] 分页查看反馈（仅限配置的 Owner，不受冷却限制）
+type FeedbackHandler struct {
+	*BaseCommand
+	feedbackRepo FeedbackRepository
+	ownerIDs     []int64
+}
+
+// NewFeedbackHandler 创建用户反馈命令处理器
+func NewFeedbackHandler(groupRepo GroupRepository, feedbackRepo FeedbackRepository, ownerIDs []int64) *FeedbackHandler {
+	return &FeedbackHandler{
+		BaseCommand: NewBaseCommand(
+			"feedback",
+			"向机器人维护者提交反馈",
+			user.PermissionUser, // 所有用户可用
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		feedbackRepo: feedbackRepo,
+		ownerIDs:     ownerIDs,
+	}
+}
+
+// CooldownExempt 查看反馈列表不占用提交反馈的冷却
+func (h *FeedbackHandler) CooldownExempt(ctx *handler.Context) bool {
+	args := ParseArgs(ctx.Text)
+	return len(args) > 0 && strings.EqualFold(args[0], "list")
+}
+
+// Handle 处理命令
+func (h *FeedbackHandler) Handle(ctx *handler.Context) error {
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	args := ParseArgs(ctx.Text)
+	if len(args) >= 1 && len(args) <= 2 && strings.EqualFold(args[0], "list") {
+		var before time.Time
+		if len(args) == 2 {
+			var ok bool
+			if before, ok = parseCursor(args[1]); !ok {
+				return ctx.Reply("❌ 无效的分页游标")
+			}
+		}
+		return h.handleList(ctx, before)
+	}
+
+	// 用法错误和内容过长返回错误，不占用冷却
+	text := trimLeadingWords(ctx.Text, 1)
+	if text == "" {
+		return handler.Usage("用法: <code>/feedback 反馈内容</code>")
+	}
+	if utf8.RuneCountInString(text) > feedbackMaxLength {
+		return errors.New(errors.CodeValidation, "feedback too long").
+			WithContext("limit", fmt.Sprintf("反馈内容最多 %d 字", feedbackMaxLength))
+	}
+
+	// 私聊提交时不关联群组
+	var groupID int64
+	if ctx.IsGroup() {
+		groupID = ctx.ChatID
+	}
+
+	f := feedback.NewFeedback(ctx.UserID, ctx.Username, groupID, text)
+	if err := h.feedbackRepo.Create(ctx.RequestContext(), f); err != nil {
+		return ctx.Reply("❌ 提交失败，请稍后重试")
+	}
+
+	return ctx.Reply("✅ 感谢你的反馈，维护者会尽快查看")
+}
+
+// handleList 查看早于 before 的反馈（before 为零值时从最新开始）
+func (h *FeedbackHandler) handleList(ctx *handler.Context, before time.Time) error {
+	// 群组内被设置为 Owner 的用户也无法查看，仅限 BOT_OWNER_IDS 中配置的用户
+	if !isOwnerID(h.ownerIDs, ctx.UserID) {
+		return ctx.Reply("❌ 此命令仅限机器人所有者使用")
+	}
+
+	// groupID 为 0：列出所有群组和私聊的反馈
+	items, err := h.feedbackRepo.ListRecent(ctx.RequestContext(), 0, feedbackListLimit, before)
+	if err != nil {
+		return ctx.Reply("❌ 获取反馈失败，请稍后重试")
+	}
+
+	text := formatFeedbackList(items)
+	if len(items) > 0 {
+		text += nextPageHint("/feedback list", len(items), feedbackListLimit, items[len(items)-1].CreatedAt)
+	}
+	return ctx.ReplyHTML(text)
+}
+
+// formatFeedbackList 格式化反馈列表（时间为 UTC）
+func formatFeedbackList(items []*feedback.Feedback) string {
+	if len(items) == 0 {
+		return "📭 暂无反馈"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📬 <b>反馈（%d 条）</b>\n", len(items)))
+	for _, f := range items {
+		from := fmt.Sprintf("User#%d", f.UserID)
+		if f.Username != "" {
+			from = "@" + f.Username
+		}
+		where := "私聊"
+		if f.GroupID != 0 {
+			where = fmt.Sprintf("群组 <code>%d</code>", f.GroupID)
+		}
+
+		sb.WriteString(fmt.Sprintf("\n🕒 %s · %s · %s\n%s\n",
+			formatAbsoluteTime(nil, f.CreatedAt), html.EscapeString(from), where, html.EscapeString(f.Text)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/feedback"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFeedbackRepository 模拟反馈仓储
+type MockFeedbackRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeedbackRepository) Create(ctx context.Context, f *feedback.Feedback) error {
+	args := m.Called(ctx, f)
+	return args.Error(0)
+}
+
+func (m *MockFeedbackRepository) ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*feedback.Feedback, error) {
+	args := m.Called(ctx, groupID, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*feedback.Feedback), args.Error(1)
+}
+
+func TestFeedbackHandler_Submit(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+	u := user.NewUser(2, "alice", "Alice", "")
+
+	repo := new(MockFeedbackRepository)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(f *feedback.Feedback) bool {
+		return f.UserID == 2 && f.GroupID == chatID && f.Text == "/stats 太慢了\n第二行"
+	})).Return(nil).Once()
+
+	h := NewFeedbackHandler(new(MockGroupRepository), repo, []int64{1})
+
+	// 用法错误和内容过长返回错误，不占用冷却
+	_, ok := handler.UsageHelp(h.Handle(newTestContext(tb, chatID, u, "/feedback")))
+	assert.True(t, ok)
+	err := h.Handle(newTestContext(tb, chatID, u, "/feedback "+strings.Repeat("长", feedbackMaxLength+1)))
+	assert.Contains(t, handler.UserMessage(err), "反馈内容最多 1000 字")
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, u, "/feedback /stats 太慢了\n第二行")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "感谢你的反馈")
+	repo.AssertExpectations(t)
+}
+
+func TestFeedbackHandler_CooldownExempt(t *testing.T) {
+	h := NewFeedbackHandler(new(MockGroupRepository), new(MockFeedbackRepository), []int64{1})
+
+	assert.True(t, h.CooldownExempt(&handler.Context{Text: "/feedback list"}))
+	assert.True(t, h.CooldownExempt(&handler.Context{Text: "/feedback LIST abc"}))
+	assert.False(t, h.CooldownExempt(&handler.Context{Text: "/feedback 列表太慢"}))
+}
+
+func TestFeedbackHandler_List(t *testing.T) {
+	tb := newTestBot(t)
+	owner := user.NewUser(1, "owner", "Owner", "")
+	other := user.NewUser(2, "alice", "Alice", "")
+
+	repo := new(MockFeedbackRepository)
+	repo.On("ListRecent", mock.Anything, int64(0), feedbackListLimit, time.Time{}).Return([]*feedback.Feedback{
+		{UserID: 2, Username: "alice", GroupID: -100, Text: "<b>bug</b>", CreatedAt: time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)},
+		{UserID: 3, Text: "建议", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	h := NewFeedbackHandler(new(MockGroupRepository), repo, []int64{1})
+
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, other, "/feedback list")))
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, owner, "/feedback list")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+	assert.Equal(t, "❌ 此命令仅限机器人所有者使用", replies[0])
+	assert.Contains(t, replies[1], "反馈（2 条）")
+	// 未满一页时没有下一页
+	assert.NotContains(t, replies[1], "下一页")
+	assert.Contains(t, replies[1], "2025-01-02 03:04 · @alice · 群组 <code>-100</code>\n&lt;b&gt;bug&lt;/b&gt;")
+	assert.Contains(t, replies[1], "User#3 · 私聊\n建议")
+	repo.AssertExpectations(t)
+}
+
+func TestFeedbackHandler_ListCursor(t *testing.T) {
+	tb := newTestBot(t)
+	owner := user.NewUser(1, "owner", "Owner", "")
+
+	start := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	page := func(from time.Time, n int) []*feedback.Feedback {
+		items := make([]*feedback.Feedback, n)
+		for i := range items {
+			items[i] = &feedback.Feedback{UserID: 2, Text: "反馈", CreatedAt: from.Add(-time.Duration(i) * time.Minute)}
+		}
+		return items
+	}
+
+	first := page(start, feedbackListLimit)
+	last := first[len(first)-1].CreatedAt
+	second := page(last.Add(-time.Minute), 3)
+
+	repo := new(MockFeedbackRepository)
+	repo.On("ListRecent", mock.Anything, int64(0), feedbackListLimit, time.Time{}).Return(first, nil).Once()
+	repo.On("ListRecent", mock.Anything, int64(0), feedbackListLimit, mock.MatchedBy(func(before time.Time) bool {
+		return before.Equal(last)
+	})).Return(second, nil).Once()
+
+	h := NewFeedbackHandler(new(MockGroupRepository), repo, []int64{1})
+
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, owner, "/feedback list")))
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	cursor := formatCursor(last)
+	assert.Contains(t, replies[0], "<code>/feedback list "+cursor+"</code>")
+
+	// 游标前进到上一页最后一条，最后一页不再提示下一页
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, owner, "/feedback list "+cursor)))
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, owner, "/feedback list abc")))
+
+	replies = tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[1], "反馈（3 条）")
+	assert.NotContains(t, replies[1], "下一页")
+	assert.Equal(t, "❌ 无效的分页游标", replies[2])
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,208 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+const (
+	globalStatsCacheTTL   = 5 * time.Minute // 全局统计缓存时间（聚合开销较大）
+	globalStatsTopGroups  = 5               // 展示的活跃群组数量
+	globalStatsActiveDays = 7               // 按最近几天的消息数排名活跃群组
+)
+
+// GlobalStatsGroupRepository 全局统计所需的群组仓储接口
+type GlobalStatsGroupRepository interface {
+	GroupRepository
+	FindAll(ctx context.Context) ([]*group.Group, error)
+}
+
+// GlobalStatsUserRepository 全局统计所需的用户仓储接口
+type GlobalStatsUserRepository interface {
+	Count(ctx context.Context) (int64, error)
+}
+
+// GlobalStatsActivityReader 全局统计所需的消息计数接口（mongodb.ActivityRepository 实现）
+type GlobalStatsActivityReader interface {
+	TopGroupsByMessages(ctx context.Context, from, to string, n int) ([]*activity.GroupMessages, error)
+}
+
+// ActiveGroup 最近一段时间消息数最多的群组
+type ActiveGroup struct {
+	ID       int64
+	Title    string // 群组记录已删除时为空
+	Messages int64
+}
+
+// RetryStats Telegram API 调用的重试统计（与 telegram.RetryStats 保持一致）
+type RetryStats struct {
+	Attempts            int64 // 调用总次数（含重试）
+	SucceededAfterRetry int64 // 经过重试后成功的操作数
+	Exhausted           int64 // 用尽重试次数仍失败的操作数
+}
+
+// GlobalStats 跨群组聚合统计
+type GlobalStats struct {
+	TotalGroups  int
+	GroupsByType map[string]int
+	TotalUsers   int64
+	ActiveGroups []ActiveGroup // 最近 globalStatsActiveDays 天消息数最多的群组，按消息数降序
+	Retries      *RetryStats   // Telegram API 重试统计（实时，不缓存；未配置时为 nil）
+	GeneratedAt  time.Time
+}
+
+// GlobalStatsHandler 全局统计命令处理器（仅限配置的 Owner）
+type GlobalStatsHandler struct {
+	*BaseCommand
+	groupRepo  GlobalStatsGroupRepository
+	userRepo   GlobalStatsUserRepository
+	activity   GlobalStatsActivityReader
+	ownerIDs   []int64
+	retryStats func() RetryStats // 可为 nil
+
+	mu       sync.Mutex
+	cached   *GlobalStats
+	cacheTTL time.Duration
+	now      func() time.Time
+}
+
+// NewGlobalStatsHandler 创建全局统计命令处理器
+// retryStats 提供 Telegram API 重试统计，为 nil 时不展示
+func NewGlobalStatsHandler(groupRepo GlobalStatsGroupRepository, userRepo GlobalStatsUserRepository, activityRepo GlobalStatsActivityReader, ownerIDs []int64, retryStats func() RetryStats) *GlobalStatsHandler {
+	return &GlobalStatsHandler{
+		BaseCommand: NewBaseCommand(
+			"gstats",
+			"查看所有群组的全局统计",
+			user.PermissionOwner, // 需要 Owner 权限
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		activity:   activityRepo,
+		ownerIDs:   ownerIDs,
+		retryStats: retryStats,
+		cacheTTL:   globalStatsCacheTTL,
+		now:        time.Now,
+	}
+}
+
+// Handle 处理命令
+func (h *GlobalStatsHandler) Handle(ctx *handler.Context) error {
+	// 1. 仅限配置的 Owner（BOT_OWNER_IDS）
+	if !h.isConfiguredOwner(ctx.UserID) {
+		return ctx.Reply("❌ 此命令仅限机器人所有者使用")
+	}
+
+	// 2. 收集统计（带缓存）
+	stats, err := h.Collect(ctx.RequestContext())
+	if err != nil {
+		return ctx.Reply("❌ 获取全局统计失败，请稍后重试")
+	}
+
+	// 3. 重试统计是实时的，不写入缓存
+	if h.retryStats != nil {
+		withRetries := *stats
+		retries := h.retryStats()
+		withRetries.Retries = &retries
+		stats = &withRetries
+	}
+
+	return ctx.ReplyHTML(formatGlobalStats(stats))
+}
+
+// Collect 聚合所有群组和用户的统计信息
+// 结果会缓存 cacheTTL 时长，避免频繁全表扫描
+func (h *GlobalStatsHandler) Collect(ctx context.Context) (*GlobalStats, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	if h.cached != nil && now.Sub(h.cached.GeneratedAt) < h.cacheTTL {
+		return h.cached, nil
+	}
+
+	groups, err := h.groupRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	totalUsers, err := h.userRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	// 按最近几天的消息数（BufferedCounter 写入的按天统计）选出最活跃的群组
+	from := activity.DayOf(now.AddDate(0, 0, -(globalStatsActiveDays - 1)))
+	top, err := h.activity.TopGroupsByMessages(ctx, from, activity.DayOf(now), globalStatsTopGroups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank active groups: %w", err)
+	}
+
+	stats := &GlobalStats{
+		TotalGroups:  len(groups),
+		GroupsByType: make(map[string]int),
+		TotalUsers:   totalUsers,
+		GeneratedAt:  now,
+	}
+
+	titles := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		stats.GroupsByType[g.Type]++
+		titles[g.ID] = g.Title
+	}
+
+	for _, t := range top {
+		stats.ActiveGroups = append(stats.ActiveGroups, ActiveGroup{ID: t.GroupID, Title: titles[t.GroupID], Messages: t.Messages})
+	}
+
+	h.cached = stats
+	return stats, nil
+}
+
+// isConfiguredOwner 检查用户ID是否在配置的Owner列表中
+func (h *GlobalStatsHandler) isConfiguredOwner(userID int64) bool {
+	return isOwnerID(h.ownerIDs, userID)
+}
+
+// formatGlobalStats 格式化全局统计输出
+func formatGlobalStats(stats *GlobalStats) string {
+	var sb strings.Builder
+	sb.WriteString("🌐 <b>全局统计</b>\n\n")
+	sb.WriteString(fmt.Sprintf("👥 群组总数: <b>%d</b>\n", stats.TotalGroups))
+
+	types := make([]string, 0, len(stats.GroupsByType))
+	for t := range stats.GroupsByType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		sb.WriteString(fmt.Sprintf("  • %s: %d\n", t, stats.GroupsByType[t]))
+	}
+
+	sb.WriteString(fmt.Sprintf("👤 用户总数: <b>%d</b>\n", stats.TotalUsers))
+
+	if len(stats.ActiveGroups) > 0 {
+		sb.WriteString(fmt.Sprintf("\n🔥 <b>近 %d 天最活跃群组</b>\n", globalStatsActiveDays))
+		for i, g := range stats.ActiveGroups {
+			sb.WriteString(fmt.Sprintf("%d. %s (<code>%d</code>) · %d 条消息\n", i+1, html.EscapeString(g.Title), g.ID, g.Messages))
+		}
+	}
+
+	if stats.Retries != nil {
+		sb.WriteString(fmt.Sprintf("\n🔁 <b>Telegram 重试</b>\n调用: %d 次 · 重试后成功: %d · 重试耗尽: %d\n",
+			stats.Retries.Attempts, stats.Retries.SucceededAfterRetry, stats.Retries.Exhausted))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<i>统计时间: %s</i>", stats.GeneratedAt.Format("2006-01-02 15:04:05")))
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/group"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockGlobalStatsGroupRepository extends MockGroupRepository with FindAll
+type MockGlobalStatsGroupRepository struct {
+	MockGroupRepository
+}
+
+func (m *MockGlobalStatsGroupRepository) FindAll(ctx context.Context) ([]*group.Group, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*group.Group), args.Error(1)
+}
+
+func (m *MockGlobalStatsGroupRepository) UnsetCommands(ctx context.Context, id int64, names []string) error {
+	args := m.Called(ctx, id, names)
+	return args.Error(0)
+}
+
+// MockUserCounter is a mock for GlobalStatsUserRepository
+type MockUserCounter struct {
+	mock.Mock
+}
+
+func (m *MockUserCounter) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockActivityReader is a mock for GlobalStatsActivityReader
+type MockActivityReader struct {
+	mock.Mock
+}
+
+func (m *MockActivityReader) TopGroupsByMessages(ctx context.Context, from, to string, n int) ([]*activity.GroupMessages, error) {
+	args := m.Called(ctx, from, to, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*activity.GroupMessages), args.Error(1)
+}
+
+func seededGroups(base time.Time) []*group.Group {
+	groups := make([]*group.Group, 0, 7)
+	for i := 0; i < 7; i++ {
+		groupType := "supergroup"
+		if i%3 == 0 {
+			groupType = "group"
+		}
+		g := group.NewGroup(int64(-100-i), "Group", groupType)
+		g.UpdatedAt = base.Add(time.Duration(i) * time.Hour)
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+func TestGlobalStatsHandler_Collect(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	groupRepo := new(MockGlobalStatsGroupRepository)
+	userRepo := new(MockUserCounter)
+	groupRepo.On("FindAll", mock.Anything).Return(seededGroups(base), nil).Once()
+	userRepo.On("Count", mock.Anything).Return(int64(42), nil).Once()
+
+	activityRepo := new(MockActivityReader)
+	// 按最近 7 天（含当天）的消息数排名
+	activityRepo.On("TopGroupsByMessages", mock.Anything, "2024-12-26", "2025-01-01", globalStatsTopGroups).Return([]*activity.GroupMessages{
+		{GroupID: -102, Messages: 500},
+		{GroupID: -999, Messages: 20},
+	}, nil).Once()
+
+	h := NewGlobalStatsHandler(groupRepo, userRepo, activityRepo, []int64{1}, nil)
+	h.now = func() time.Time { return base }
+
+	stats, err := h.Collect(context.TODO())
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, stats.TotalGroups)
+	assert.Equal(t, 3, stats.GroupsByType["group"])
+	assert.Equal(t, 4, stats.GroupsByType["supergroup"])
+	assert.Equal(t, int64(42), stats.TotalUsers)
+
+	// 按消息数排名，已删除的群组没有标题
+	assert.Equal(t, []ActiveGroup{
+		{ID: -102, Title: "Group", Messages: 500},
+		{ID: -999, Messages: 20},
+	}, stats.ActiveGroups)
+	activityRepo.AssertExpectations(t)
+}
+
+func TestGlobalStatsHandler_CollectUsesCache(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	groupRepo := new(MockGlobalStatsGroupRepository)
+	userRepo := new(MockUserCounter)
+	groupRepo.On("FindAll", mock.Anything).Return(seededGroups(base), nil).Twice()
+	userRepo.On("Count", mock.Anything).Return(int64(10), nil).Twice()
+
+	activityRepo := new(MockActivityReader)
+	activityRepo.On("TopGroupsByMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+
+	h := NewGlobalStatsHandler(groupRepo, userRepo, activityRepo, []int64{1}, nil)
+	current := base
+	h.now = func() time.Time { return current }
+
+	_, err := h.Collect(context.TODO())
+	require.NoError(t, err)
+
+	// 缓存有效期内不再查询数据库
+	current = base.Add(time.Minute)
+	_, err = h.Collect(context.TODO())
+	require.NoError(t, err)
+	groupRepo.AssertNumberOfCalls(t, "FindAll", 1)
+
+	// 缓存过期后重新聚合
+	current = base.Add(globalStatsCacheTTL + time.Second)
+	stats, err := h.Collect(context.TODO())
+	require.NoError(t, err)
+	groupRepo.AssertNumberOfCalls(t, "FindAll", 2)
+	userRepo.AssertNumberOfCalls(t, "Count", 2)
+	assert.Equal(t, current, stats.GeneratedAt)
+}
+
+func TestGlobalStatsHandler_CollectError(t *testing.T) {
+	groupRepo := new(MockGlobalStatsGroupRepository)
+	userRepo := new(MockUserCounter)
+	groupRepo.On("FindAll", mock.Anything).Return(nil, assert.AnError).Once()
+
+	h := NewGlobalStatsHandler(groupRepo, userRepo, new(MockActivityReader), []int64{1}, nil)
+
+	stats, err := h.Collect(context.TODO())
+	assert.Error(t, err)
+	assert.Nil(t, stats)
+	userRepo.AssertNotCalled(t, "Count", mock.Anything)
+}
+
+func TestGlobalStatsHandler_IsConfiguredOwner(t *testing.T) {
+	h := NewGlobalStatsHandler(new(MockGlobalStatsGroupRepository), new(MockUserCounter), new(MockActivityReader), []int64{100, 200}, nil)
+
+	assert.True(t, h.isConfiguredOwner(100))
+	assert.True(t, h.isConfiguredOwner(200))
+	assert.False(t, h.isConfiguredOwner(300))
+}
+
+func TestFormatGlobalStats(t *testing.T) {
+	stats := &GlobalStats{
+		TotalGroups:  2,
+		GroupsByType: map[string]int{"supergroup": 2},
+		TotalUsers:   5,
+		ActiveGroups: []ActiveGroup{{ID: -100, Title: "<Test>", Messages: 1234}},
+		GeneratedAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	result := formatGlobalStats(stats)
+	assert.Contains(t, result, "群组总数: <b>2</b>")
+	assert.Contains(t, result, "用户总数: <b>5</b>")
+	assert.Contains(t, result, "近 7 天最活跃群组")
+	assert.Contains(t, result, "1. &lt;Test&gt; (<code>-100</code>) · 1234 条消息")
+	assert.NotContains(t, result, "Telegram 重试")
+
+	stats.Retries = &RetryStats{Attempts: 120, SucceededAfterRetry: 3, Exhausted: 1}
+	result = formatGlobalStats(stats)
+	assert.Contains(t, result, "调用: 120 次 · 重试后成功: 3 · 重试耗尽: 1")
+}
@@ -2,20 +2,11 @@ package command
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
 )
 
-// CommandInfo 命令信息接口
-// 所有嵌入 BaseCommand 的命令处理器都实现了此接口
-type CommandInfo interface {
-	GetName() string
-	GetDescription() string
-	GetPermission() user.Permission
-}
-
 // HelpHandler Help 命令处理器
 type HelpHandler struct {
 	*BaseCommand
@@ -46,8 +37,8 @@ func (h *HelpHandler) Handle(ctx *handler.Context) error {
 	var sb strings.Builder
 	sb.WriteString("📖 <b>可用命令列表</b>\n\n")
 
-	// 获取所有命令信息
-	commands := h.getCommands()
+	// 获取当前聊天类型可用的命令信息（私聊中不列出群组专用命令）
+	commands := h.getCommands(ctx.ChatType)
 
 	// 按权限等级分组显示
 	userCommands := []string{}
@@ -115,35 +106,14 @@ func (h *HelpHandler) Handle(ctx *handler.Context) error {
 	return ctx.ReplyHTML(sb.String())
 }
 
-// CommandData 命令数据
-type CommandData struct {
-	Name        string
-	Description string
-	Permission  user.Permission
-}
-
-// getCommands 获取所有命令信息
-func (h *HelpHandler) getCommands() []CommandData {
-	commands := []CommandData{}
-
-	// 遍历所有处理器
-	handlers := h.router.GetHandlers()
-	for _, hdlr := range handlers {
-		// 尝试类型断言为 CommandInfo 接口
-		if cmdInfo, ok := hdlr.(CommandInfo); ok {
-			commands = append(commands, CommandData{
-				Name:        cmdInfo.GetName(),
-				Description: cmdInfo.GetDescription(),
-				Permission:  cmdInfo.GetPermission(),
-			})
+// getCommands 获取指定聊天类型可用的命令信息（按命令名排序）
+func (h *HelpHandler) getCommands(chatType string) []handler.CommandInfo {
+	commands := []handler.CommandInfo{}
+	for _, cmd := range h.router.Commands() {
+		if cmd.AllowsChatType(chatType) {
+			commands = append(commands, cmd)
 		}
 	}
-
-	// 按命令名排序
-	sort.Slice(commands, func(i, j int) bool {
-		return commands[i].Name < commands[j].Name
-	})
-
 	return commands
 }
 
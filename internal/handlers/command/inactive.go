@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/timeutil"
+	"time"
+)
+
+const (
+	defaultInactiveWindow = 30 * 24 * time.Hour
+	minInactiveWindow     = time.Hour
+	maxInactiveWindow     = 365 * 24 * time.Hour
+	inactiveListLimit     = 50
+)
+
+// InactiveUserFinder 查询长期未出现的用户接口
+type InactiveUserFinder interface {
+	FindInactive(ctx context.Context, groupID int64, before time.Time, limit int) ([]*user.User, error)
+}
+
+// InactiveHandler 查看长期未出现用户命令处理器
+// 只统计在当前群组有权限记录的用户（管理员、被设置过权限的成员），
+// 用于找出长期不活跃的管理员；最近活跃时间为全局时间，在任意群组或私聊发言都会更新
+type InactiveHandler struct {
+	*BaseCommand
+	users InactiveUserFinder
+	now   func() time.Time
+}
+
+// NewInactiveHandler 创建查看长期未出现用户命令处理器
+func NewInactiveHandler(groupRepo GroupRepository, users InactiveUserFinder) *InactiveHandler {
+	return &InactiveHandler{
+		BaseCommand: NewBaseCommand(
+			"inactive",
+			"查看长期未出现的管理员和有权限记录的成员",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		users: users,
+		now:   time.Now,
+	}
+}
+
+// Handle 处理命令
+// 用法：/inactive [时长]，如 /inactive 30d，默认 30 天
+func (h *InactiveHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析时长
+	window := defaultInactiveWindow
+	args := ParseArgs(ctx.Text)
+	switch len(args) {
+	case 0:
+	case 1:
+		d, err := timeutil.ParseInterval(args[0])
+		if err != nil || d < minInactiveWindow || d > maxInactiveWindow {
+			return ctx.Reply("❌ 时长需在 1 小时到 365 天之间，如 7d、30d")
+		}
+		window = d
+	default:
+		return ctx.Reply("❌ 用法: /inactive [时长]，如 /inactive 30d")
+	}
+
+	// 3. 查询
+	now := h.now()
+	users, err := h.users.FindInactive(reqCtx, ctx.ChatID, now.Add(-window), inactiveListLimit)
+	if err != nil {
+		return ctx.Reply("❌ 查询失败，请稍后重试")
+	}
+	if len(users) == 0 {
+		return ctx.Reply(fmt.Sprintf("✅ 最近 %s 内所有有权限记录的用户都出现过", formatInterval(window)))
+	}
+
+	return ctx.ReplyHTML(formatInactiveUsers(ctx, users, window, now))
+}
+
+// formatInactiveUsers 按最近活跃时间从早到晚列出用户及其在当前群组的权限
+func formatInactiveUsers(ctx *handler.Context, users []*user.User, window time.Duration, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("💤 <b>超过 %s 未出现的用户</b>\n\n", formatInterval(window)))
+
+	for _, u := range users {
+		seen := "未记录"
+		if !u.LastSeenAt.IsZero() {
+			seen = formatTime(ctx.Group, u.LastSeenAt, now)
+		}
+		sb.WriteString(fmt.Sprintf("• %s [%s] — %s\n",
+			html.EscapeString(FormatUsername(u)), u.GetPermission(ctx.ChatID), seen))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n共 <b>%d</b> 人", len(users)))
+	if len(users) == inactiveListLimit {
+		sb.WriteString(fmt.Sprintf("（只显示最早的 %d 人）", inactiveListLimit))
+	}
+	return sb.String()
+}
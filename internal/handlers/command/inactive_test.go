@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInactiveFinder 按最近活跃时间筛选内存中的用户
+type fakeInactiveFinder struct {
+	users  []*user.User
+	before time.Time
+}
+
+func (f *fakeInactiveFinder) FindInactive(ctx context.Context, groupID int64, before time.Time, limit int) ([]*user.User, error) {
+	f.before = before
+	var result []*user.User
+	for _, u := range f.users {
+		if _, ok := u.Permissions[groupID]; ok && u.LastSeenAt.Before(before) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+func TestInactiveHandler(t *testing.T) {
+	tb := newTestBot(t)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(auditTestChatID, user.PermissionAdmin)
+	admin.LastSeenAt = now.Add(-time.Hour)
+
+	dormant := user.NewUser(2, "dormant", "Dormant", "")
+	dormant.SetPermission(auditTestChatID, user.PermissionAdmin)
+	dormant.LastSeenAt = now.Add(-40 * 24 * time.Hour)
+
+	never := user.NewUser(3, "", "Never", "") // 没有记录过最近活跃时间
+	never.SetPermission(auditTestChatID, user.PermissionNone)
+
+	finder := &fakeInactiveFinder{users: []*user.User{never, dormant, admin}}
+	h := NewInactiveHandler(nil, finder)
+	h.now = func() time.Time { return now }
+
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, admin, "/inactive")))
+	assert.Equal(t, now.Add(-30*24*time.Hour), finder.before)
+
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, admin, "/inactive 60d")))
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, admin, "/inactive 10m")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "超过 30d 未出现")
+	assert.Contains(t, replies[0], "Never [None] — 未记录")
+	assert.Contains(t, replies[0], "@dormant [Admin] — 2025-04-22 12:00")
+	assert.NotContains(t, replies[0], "@admin")
+	assert.Contains(t, replies[0], "共 <b>2</b> 人")
+
+	assert.Contains(t, replies[1], "Never")
+	assert.NotContains(t, replies[1], "@dormant")
+
+	assert.Contains(t, replies[2], "1 小时到 365 天")
+}
+
+func TestInactiveHandler_RequiresAdmin(t *testing.T) {
+	tb := newTestBot(t)
+	member := user.NewUser(2, "member", "Member", "")
+
+	h := NewInactiveHandler(nil, &fakeInactiveFinder{})
+	err := h.Handle(newTestContext(tb, auditTestChatID, member, "/inactive"))
+	require.Error(t, err)
+	assert.Empty(t, tb.Replies())
+}
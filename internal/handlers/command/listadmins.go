@@ -1,13 +1,16 @@
 package command
 
 import (
-	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
 )
 
+// listAdminsPageSize 管理员列表每页人数
+const listAdminsPageSize = 20
+
 // ListAdminsHandler 查看管理员列表命令处理器
 type ListAdminsHandler struct {
 	*BaseCommand
@@ -29,73 +32,84 @@ func NewListAdminsHandler(groupRepo GroupRepository, userRepo UserRepository) *L
 }
 
 // Handle 处理命令
+// 用法：/listadmins [admin|superadmin|owner] [页码]，权限等级表示只显示该等级及以上
 func (h *ListAdminsHandler) Handle(ctx *handler.Context) error {
-	reqCtx := context.TODO()
+	reqCtx := ctx.RequestContext()
 
 	// 1. 检查权限
 	if err := h.CheckPermission(ctx); err != nil {
 		return err
 	}
 
-	// 2. 查询所有管理员
+	// 2. 解析参数
+	minPerm := user.PermissionAdmin
+	page := 1
+	for _, arg := range ParseArgs(ctx.Text) {
+		if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+			page = n
+			continue
+		}
+		perm, ok := parsePermission(arg)
+		if !ok || perm < user.PermissionAdmin {
+			return ctx.Reply("❌ 用法: /listadmins [admin|superadmin|owner] [页码]")
+		}
+		minPerm = perm
+	}
+
+	// 3. 查询管理员并分页
 	admins, err := h.userRepo.FindAdminsByGroup(reqCtx, ctx.ChatID)
 	if err != nil {
 		return ctx.Reply("❌ 查询管理员列表失败，请稍后重试")
 	}
 
-	// 3. 按权限等级分组
-	owners := []string{}
-	superAdmins := []string{}
-	regularAdmins := []string{}
-
-	for _, admin := range admins {
-		perm := admin.GetPermission(ctx.ChatID)
-		username := FormatUsername(admin)
-
-		switch perm {
-		case user.PermissionOwner:
-			owners = append(owners, username)
-		case user.PermissionSuperAdmin:
-			superAdmins = append(superAdmins, username)
-		case user.PermissionAdmin:
-			regularAdmins = append(regularAdmins, username)
-		}
+	result := user.PageAdmins(admins, ctx.ChatID, minPerm, (page-1)*listAdminsPageSize, listAdminsPageSize)
+	if result.Total == 0 {
+		return ctx.Reply("👥 当前群组暂无管理员")
+	}
+
+	totalPages := (result.Total + listAdminsPageSize - 1) / listAdminsPageSize
+	if page > totalPages {
+		return ctx.Reply(fmt.Sprintf("❌ 页码超出范围（共 %d 页）", totalPages))
+	}
+
+	return ctx.ReplyHTML(formatAdminPage(result, ctx.ChatID, page, totalPages))
+}
+
+// formatAdminPage 按权限等级分组展示当前页的管理员
+func formatAdminPage(result *user.AdminPage, chatID int64, page, totalPages int) string {
+	levels := []struct {
+		perm  user.Permission
+		title string
+	}{
+		{user.PermissionOwner, "👑 <b>Owner</b>"},
+		{user.PermissionSuperAdmin, "⭐ <b>SuperAdmin</b>"},
+		{user.PermissionAdmin, "🛡 <b>Admin</b>"},
 	}
 
-	// 4. 构建输出
 	var sb strings.Builder
 	sb.WriteString("👥 <b>当前群组管理员列表</b>\n\n")
 
-	if len(owners) > 0 {
-		sb.WriteString(fmt.Sprintf("👑 <b>Owner</b> (%d人):\n", len(owners)))
-		for _, u := range owners {
-			sb.WriteString(fmt.Sprintf("  • %s\n", u))
+	for _, level := range levels {
+		names := []string{}
+		for _, admin := range result.Admins {
+			if admin.GetPermission(chatID) == level.perm {
+				names = append(names, FormatUsername(admin))
+			}
 		}
-		sb.WriteString("\n")
-	}
-
-	if len(superAdmins) > 0 {
-		sb.WriteString(fmt.Sprintf("⭐ <b>SuperAdmin</b> (%d人):\n", len(superAdmins)))
-		for _, u := range superAdmins {
-			sb.WriteString(fmt.Sprintf("  • %s\n", u))
+		if len(names) == 0 {
+			continue
 		}
-		sb.WriteString("\n")
-	}
 
-	if len(regularAdmins) > 0 {
-		sb.WriteString(fmt.Sprintf("🛡 <b>Admin</b> (%d人):\n", len(regularAdmins)))
-		for _, u := range regularAdmins {
-			sb.WriteString(fmt.Sprintf("  • %s\n", u))
+		sb.WriteString(fmt.Sprintf("%s (%d人):\n", level.title, len(names)))
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("  • %s\n", name))
 		}
 		sb.WriteString("\n")
 	}
 
-	total := len(owners) + len(superAdmins) + len(regularAdmins)
-	if total == 0 {
-		return ctx.Reply("👥 当前群组暂无管理员")
+	sb.WriteString(fmt.Sprintf("总计: <b>%d</b> 位管理员", result.Total))
+	if totalPages > 1 {
+		sb.WriteString(fmt.Sprintf("（第 %d/%d 页）", page, totalPages))
 	}
-
-	sb.WriteString(fmt.Sprintf("总计: <b>%d</b> 位管理员", total))
-
-	return ctx.ReplyHTML(sb.String())
+	return sb.String()
 }
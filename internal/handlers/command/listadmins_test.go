@@ -0,0 +1,68 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newListAdminsRepo 返回包含 1 个 SuperAdmin 和 n 个 Admin 的用户仓储
+func newListAdminsRepo(chatID int64, n int) *MockUserRepository {
+	boss := user.NewUser(1, "boss", "Boss", "")
+	boss.SetPermission(chatID, user.PermissionSuperAdmin)
+
+	admins := []*user.User{boss}
+	for i := 0; i < n; i++ {
+		u := user.NewUser(int64(100+i), fmt.Sprintf("admin%d", i), "Admin", "")
+		u.SetPermission(chatID, user.PermissionAdmin)
+		admins = append(admins, u)
+	}
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindAdminsByGroup", mock.Anything, chatID).Return(admins, nil)
+	return userRepo
+}
+
+func TestListAdminsHandler_Pagination(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+	member := user.NewUser(2, "member", "Member", "")
+
+	h := NewListAdminsHandler(nil, newListAdminsRepo(chatID, listAdminsPageSize+4))
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, member, "/listadmins 2")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, member, "/listadmins superadmin")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, member, "/listadmins 9")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+
+	// 第 2 页只剩 5 个 Admin，总数仍为全部 25 人
+	assert.Contains(t, replies[0], "<b>Admin</b> (5人)")
+	assert.NotContains(t, replies[0], "SuperAdmin")
+	assert.Contains(t, replies[0], "总计: <b>25</b> 位管理员（第 2/2 页）")
+
+	// 按等级过滤
+	assert.Contains(t, replies[1], "<b>SuperAdmin</b> (1人)")
+	assert.NotContains(t, replies[1], "🛡")
+	assert.Contains(t, replies[1], "总计: <b>1</b> 位管理员")
+
+	assert.Contains(t, replies[2], "共 2 页")
+}
+
+func TestListAdminsHandler_InvalidLevel(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	h := NewListAdminsHandler(nil, new(MockUserRepository))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, user.NewUser(2, "member", "", ""), "/listadmins user")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "用法")
+}
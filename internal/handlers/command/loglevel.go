@@ -0,0 +1,128 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/logger"
+	"time"
+)
+
+// LevelSetter 运行时修改日志级别的接口（由 logger.Logger 实现）
+type LevelSetter interface {
+	SetLevel(level logger.Level)
+}
+
+// logLevelNames /loglevel 接受的级别名称
+var logLevelNames = map[string]logger.Level{
+	"debug": logger.LevelDebug,
+	"info":  logger.LevelInfo,
+	"warn":  logger.LevelWarn,
+	"error": logger.LevelError,
+}
+
+// LogLevelHandler 日志级别命令处理器（仅限配置的 Owner）
+// /loglevel <level> 临时修改日志级别，revertAfter 后自动恢复为配置的级别；/loglevel reset 立即恢复
+type LogLevelHandler struct {
+	*BaseCommand
+	logger       LevelSetter
+	ownerIDs     []int64
+	defaultLevel logger.Level  // 配置的日志级别（LOG_LEVEL）
+	revertAfter  time.Duration // 自动恢复时间
+
+	mu      sync.Mutex
+	current logger.Level
+	revert  *time.Timer // 待执行的自动恢复（没有时为 nil）
+}
+
+// NewLogLevelHandler 创建日志级别命令处理器
+func NewLogLevelHandler(groupRepo GroupRepository, l LevelSetter, ownerIDs []int64, defaultLevel logger.Level, revertAfter time.Duration) *LogLevelHandler {
+	return &LogLevelHandler{
+		BaseCommand: NewBaseCommand(
+			"loglevel",
+			"临时修改日志级别",
+			user.PermissionOwner, // 需要 Owner 权限
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		logger:       l,
+		ownerIDs:     ownerIDs,
+		defaultLevel: defaultLevel,
+		revertAfter:  revertAfter,
+		current:      defaultLevel,
+	}
+}
+
+// Handle 处理命令
+func (h *LogLevelHandler) Handle(ctx *handler.Context) error {
+	// 群组内被设置为 Owner 的用户也无法使用，仅限 BOT_OWNER_IDS 中配置的用户
+	if !isOwnerID(h.ownerIDs, ctx.UserID) {
+		return ctx.Reply("❌ 此命令仅限机器人所有者使用")
+	}
+
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		return ctx.ReplyHTML(fmt.Sprintf("📝 当前日志级别: <b>%s</b>（配置: %s）\n用法: <code>/loglevel debug|info|warn|error|reset</code>",
+			h.Level(), h.defaultLevel))
+	}
+
+	name := strings.ToLower(args[0])
+	if name == "reset" {
+		h.set(h.defaultLevel)
+		return ctx.ReplyHTML(fmt.Sprintf("✅ 日志级别已恢复为 <b>%s</b>", h.defaultLevel))
+	}
+
+	level, ok := logLevelNames[name]
+	if !ok {
+		return ctx.Reply("❌ 用法: /loglevel debug|info|warn|error|reset")
+	}
+
+	h.set(level)
+	if level == h.defaultLevel {
+		return ctx.ReplyHTML(fmt.Sprintf("✅ 日志级别已设为 <b>%s</b>", level))
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 日志级别已设为 <b>%s</b>，%s 后自动恢复为 %s",
+		level, h.revertAfter, h.defaultLevel))
+}
+
+// Level 当前日志级别
+func (h *LogLevelHandler) Level() logger.Level {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.current
+}
+
+// set 修改日志级别，并重新安排自动恢复（恢复为配置级别时取消）
+func (h *LogLevelHandler) set(level logger.Level) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.revert != nil {
+		h.revert.Stop()
+		h.revert = nil
+	}
+
+	h.current = level
+	h.logger.SetLevel(level)
+
+	if level == h.defaultLevel {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(h.revertAfter, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// 期间再次修改过级别时，旧的定时器不再生效
+		if h.revert != timer {
+			return
+		}
+		h.revert = nil
+		h.current = h.defaultLevel
+		h.logger.SetLevel(h.defaultLevel)
+	})
+	h.revert = timer
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLevelSetter 记录日志级别修改
+type fakeLevelSetter struct {
+	mu    sync.Mutex
+	level logger.Level
+}
+
+func (f *fakeLevelSetter) SetLevel(level logger.Level) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.level = level
+}
+
+func (f *fakeLevelSetter) Level() logger.Level {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.level
+}
+
+func TestLogLevelHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+	owner := user.NewUser(1, "owner", "Owner", "")
+
+	setter := &fakeLevelSetter{level: logger.LevelInfo}
+	h := NewLogLevelHandler(new(MockGroupRepository), setter, []int64{1}, logger.LevelInfo, time.Hour)
+
+	// 非所有者无法修改
+	other := user.NewUser(2, "alice", "Alice", "")
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, other, "/loglevel debug")))
+	assert.Equal(t, logger.LevelInfo, setter.Level())
+
+	// 无效级别
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/loglevel verbose")))
+	assert.Equal(t, logger.LevelInfo, setter.Level())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/loglevel DEBUG")))
+	assert.Equal(t, logger.LevelDebug, setter.Level())
+	assert.Equal(t, logger.LevelDebug, h.Level())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/loglevel reset")))
+	assert.Equal(t, logger.LevelInfo, setter.Level())
+
+	replies := tb.Replies()
+	require.Len(t, replies, 4)
+	assert.Equal(t, "❌ 此命令仅限机器人所有者使用", replies[0])
+	assert.Contains(t, replies[1], "用法")
+	assert.Contains(t, replies[2], "<b>DEBUG</b>，1h0m0s 后自动恢复为 INFO")
+	assert.Contains(t, replies[3], "已恢复为 <b>INFO</b>")
+}
+
+func TestLogLevelHandler_AutoRevert(t *testing.T) {
+	tb := newTestBot(t)
+	owner := user.NewUser(1, "owner", "Owner", "")
+
+	setter := &fakeLevelSetter{level: logger.LevelWarn}
+	h := NewLogLevelHandler(new(MockGroupRepository), setter, []int64{1}, logger.LevelWarn, 20*time.Millisecond)
+
+	require.NoError(t, h.Handle(newPrivateTestContext(tb, owner, "/loglevel debug")))
+	assert.Equal(t, logger.LevelDebug, setter.Level())
+
+	assert.Eventually(t, func() bool {
+		return setter.Level() == logger.LevelWarn && h.Level() == logger.LevelWarn
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"strings"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// MaintenanceSwitch 维护模式开关接口（由 handler.Maintenance 实现）
+type MaintenanceSwitch interface {
+	Active() bool
+	SetActive(active bool)
+}
+
+// MaintenanceHandler 维护模式命令处理器（仅限配置的 Owner）
+// /maintenance on 开启维护模式，/maintenance off 关闭，无参数时显示当前状态
+type MaintenanceHandler struct {
+	*BaseCommand
+	maintenance MaintenanceSwitch
+	ownerIDs    []int64
+}
+
+// NewMaintenanceHandler 创建维护模式命令处理器
+func NewMaintenanceHandler(groupRepo GroupRepository, maintenance MaintenanceSwitch, ownerIDs []int64) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		BaseCommand: NewBaseCommand(
+			"maintenance",
+			"开启/关闭维护模式",
+			user.PermissionOwner, // 需要 Owner 权限
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		maintenance: maintenance,
+		ownerIDs:    ownerIDs,
+	}
+}
+
+// Handle 处理命令
+func (h *MaintenanceHandler) Handle(ctx *handler.Context) error {
+	// 群组内被设置为 Owner 的用户也无法使用，仅限 BOT_OWNER_IDS 中配置的用户
+	if !isOwnerID(h.ownerIDs, ctx.UserID) {
+		return ctx.Reply("❌ 此命令仅限机器人所有者使用")
+	}
+
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		return ctx.ReplyHTML("🛠 维护模式: " + getStatusEmoji(h.maintenance.Active()) +
+			"\n用法: <code>/maintenance on|off</code>")
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		h.maintenance.SetActive(true)
+		return ctx.Reply("🛠 维护模式已开启，非所有者的命令将暂停处理")
+	case "off":
+		h.maintenance.SetActive(false)
+		return ctx.Reply("✅ 维护模式已关闭，恢复正常处理")
+	default:
+		return ctx.Reply("❌ 用法: /maintenance on|off")
+	}
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	owner := user.NewUser(1, "owner", "Owner", "")
+	other := user.NewUser(2, "alice", "Alice", "")
+	other.SetPermission(chatID, user.PermissionOwner) // 群组内 Owner，但不在配置列表中
+
+	maintenance := handler.NewMaintenance([]int64{1}, false)
+	h := NewMaintenanceHandler(new(MockGroupRepository), maintenance, []int64{1})
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, other, "/maintenance on")))
+	assert.False(t, maintenance.Active())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/maintenance on")))
+	assert.True(t, maintenance.Active())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/maintenance")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/maintenance off")))
+	assert.False(t, maintenance.Active())
+
+	replies := tb.Replies()
+	require.Len(t, replies, 4)
+	assert.Equal(t, "❌ 此命令仅限机器人所有者使用", replies[0])
+	assert.Contains(t, replies[1], "维护模式已开启")
+	assert.Contains(t, replies[2], "✅ 已开启")
+	assert.Contains(t, replies[3], "维护模式已关闭")
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+// minAccountAgeMaxDays 最小账号年龄的上限（Telegram 的限制最长 366 天）
+const minAccountAgeMaxDays = 365
+
+// MinAccountAgeHandler 设置新成员最小账号年龄命令处理器
+// /minaccountage <天数> 估算注册时间不足该天数的新成员入群后被禁言，直到账号满龄；/minaccountage off 关闭
+// 注册时间由用户 ID 估算，只是近似值（见 user.EstimateRegistration）
+type MinAccountAgeHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewMinAccountAgeHandler 创建设置新成员最小账号年龄命令处理器
+func NewMinAccountAgeHandler(groupRepo GroupRepository) *MinAccountAgeHandler {
+	return &MinAccountAgeHandler{
+		BaseCommand: NewBaseCommand(
+			"minaccountage",
+			"禁言注册时间过短的新账号（按用户 ID 估算）",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *MinAccountAgeHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前设置
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		status := "未开启"
+		if minAge := g.MinAccountAge(); minAge > 0 {
+			status = fmt.Sprintf("%d 天", int(minAge/(24*time.Hour)))
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("🆕 新成员最小账号年龄: <b>%s</b>\n用法: <code>/minaccountage 天数|off</code>\n"+
+			"<i>注册时间由用户 ID 估算，可能有数月误差</i>", status))
+	}
+
+	// 4. 更新设置
+	var days int64
+	if !strings.EqualFold(args[0], "off") {
+		days, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil || days < 0 || days > minAccountAgeMaxDays {
+			return ctx.Reply(fmt.Sprintf("❌ 请输入 0-%d 之间的天数，或 off 关闭", minAccountAgeMaxDays))
+		}
+	}
+
+	if days == 0 {
+		g.DeleteSetting(group.SettingMinAccountAgeDays)
+	} else {
+		g.SetSetting(group.SettingMinAccountAgeDays, days)
+	}
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	if days == 0 {
+		return ctx.Reply("✅ 已关闭新账号入群限制")
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 注册不足 <b>%d 天</b>的新成员入群后将被禁言，直到账号满龄", days))
+}
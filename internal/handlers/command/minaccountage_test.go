@@ -0,0 +1,44 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinAccountAgeHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Twice()
+
+	h := NewMinAccountAgeHandler(groupRepo)
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/minaccountage 400")))
+	assert.Zero(t, g.MinAccountAge())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/minaccountage 7")))
+	assert.Equal(t, 7*24*time.Hour, g.MinAccountAge())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/minaccountage off")))
+	assert.Zero(t, g.MinAccountAge())
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "请输入 0-365 之间的天数")
+	assert.Contains(t, replies[1], "7 天")
+	assert.Equal(t, "✅ 已关闭新账号入群限制", replies[2])
+	groupRepo.AssertExpectations(t)
+}
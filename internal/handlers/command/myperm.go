@@ -1,15 +1,20 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"html"
+	"sort"
 	"strings"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
 )
 
 // MyPermHandler 查看自己权限命令处理器
+// /myperm all 在私聊中列出自己在所有群组的权限
 type MyPermHandler struct {
 	*BaseCommand
+	groupRepo GroupRepository
 }
 
 // NewMyPermHandler 创建查看自己权限命令处理器
@@ -22,6 +27,7 @@ func NewMyPermHandler(groupRepo GroupRepository) *MyPermHandler {
 			[]string{"group", "supergroup", "private"},
 			groupRepo,
 		),
+		groupRepo: groupRepo,
 	}
 }
 
@@ -32,6 +38,14 @@ func (h *MyPermHandler) Handle(ctx *handler.Context) error {
 		return err
 	}
 
+	// 所有群组的权限会暴露用户加入了哪些群组，只在私聊中显示
+	if args := ParseArgs(ctx.Text); len(args) > 0 && args[0] == "all" {
+		if !ctx.IsPrivate() {
+			return ctx.Reply("ℹ️ 请在私聊中使用 /myperm all 查看所有群组的权限")
+		}
+		return ctx.ReplyHTML(h.formatAllPermissions(ctx.RequestContext(), ctx.User))
+	}
+
 	// 2. 获取当前群组/私聊的权限
 	// 私聊使用全局权限（groupID = 0），群组使用群组 ID
 	groupID := ctx.ChatID
@@ -83,3 +97,47 @@ func (h *MyPermHandler) Handle(ctx *handler.Context) error {
 
 	return ctx.ReplyHTML(sb.String())
 }
+
+// formatAllPermissions 列出用户在各群组的权限（不含 None），全局权限排在最前
+func (h *MyPermHandler) formatAllPermissions(reqCtx context.Context, u *user.User) string {
+	groupIDs := make([]int64, 0, len(u.Permissions))
+	for groupID, perm := range u.Permissions {
+		if perm > user.PermissionNone {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	sort.Slice(groupIDs, func(i, j int) bool {
+		if (groupIDs[i] == 0) != (groupIDs[j] == 0) {
+			return groupIDs[i] == 0
+		}
+		return groupIDs[i] < groupIDs[j]
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("👤 <b>%s 在各群组的权限</b>\n\n", html.EscapeString(FormatUsername(u))))
+
+	if len(groupIDs) == 0 {
+		sb.WriteString("<i>没有单独设置过的权限，所有群组中均为默认的 User</i>")
+		return sb.String()
+	}
+
+	for _, groupID := range groupIDs {
+		perm := u.Permissions[groupID]
+		sb.WriteString(fmt.Sprintf("• %s: <b>%s</b> %s\n", h.groupName(reqCtx, groupID), perm.String(), GetPermIcon(perm)))
+	}
+	sb.WriteString("\n<i>未列出的群组中为默认的 User（全局权限更高时以全局为准）</i>")
+	return sb.String()
+}
+
+// groupName 返回群组名称，全局权限显示为"全局"，找不到群组时显示 ID
+func (h *MyPermHandler) groupName(reqCtx context.Context, groupID int64) string {
+	if groupID == 0 {
+		return "全局"
+	}
+	if h.groupRepo != nil {
+		if g, err := h.groupRepo.FindByID(reqCtx, groupID); err == nil && g.Title != "" {
+			return html.EscapeString(g.Title)
+		}
+	}
+	return fmt.Sprintf("群组 %d", groupID)
+}
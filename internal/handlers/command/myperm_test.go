@@ -0,0 +1,51 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMyPermHandler_All(t *testing.T) {
+	tb := newTestBot(t)
+
+	u := user.NewUser(1, "alice", "Alice", "")
+	u.SetPermission(0, user.PermissionAdmin)
+	u.SetPermission(-100, user.PermissionSuperAdmin)
+	u.SetPermission(-200, user.PermissionNone)
+	u.SetPermission(-300, user.PermissionUser)
+
+	groupRepo := new(MockGroupRepository)
+	groupRepo.On("FindByID", mock.Anything, int64(-100)).Return(group.NewGroup(-100, "Gophers", "supergroup"), nil)
+	groupRepo.On("FindByID", mock.Anything, int64(-300)).Return(nil, group.ErrGroupNotFound)
+
+	h := NewMyPermHandler(groupRepo)
+	ctx := newTestContext(tb, 1, u, "/myperm all")
+	ctx.ChatType = "private"
+	require.NoError(t, h.Handle(ctx))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "• 全局: <b>Admin</b>")
+	assert.Contains(t, replies[0], "• Gophers: <b>SuperAdmin</b>")
+	assert.Contains(t, replies[0], "• 群组 -300: <b>User</b>")
+	assert.NotContains(t, replies[0], "-200")
+	assert.NotContains(t, replies[0], "None")
+}
+
+func TestMyPermHandler_AllOnlyInPrivate(t *testing.T) {
+	tb := newTestBot(t)
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	h := NewMyPermHandler(new(MockGroupRepository))
+	require.NoError(t, h.Handle(newTestContext(tb, -100, u, "/myperm all")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "请在私聊中使用")
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuditLog 记录追加的权限变更
+type fakeAuditLog struct {
+	changes []*user.PermissionChange
+	err     error
+}
+
+func (f *fakeAuditLog) Append(ctx context.Context, c *user.PermissionChange) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.changes = append(f.changes, c)
+	return nil
+}
+
+const auditTestChatID = int64(-100123)
+
+func newAuditTestUsers() (actor, target *user.User) {
+	actor = user.NewUser(1, "boss", "Boss", "")
+	actor.SetPermission(auditTestChatID, user.PermissionOwner)
+	target = user.NewUser(2, "member", "Member", "")
+	return actor, target
+}
+
+func TestPromoteHandler_AppendsPermissionChange(t *testing.T) {
+	tb := newTestBot(t)
+	actor, target := newAuditTestUsers()
+	target.SetPermission(auditTestChatID, user.PermissionAdmin)
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "member").Return(target, nil)
+	userRepo.On("UpdatePermission", mock.Anything, int64(2), auditTestChatID, user.PermissionSuperAdmin).Return(nil)
+	auditLog := &fakeAuditLog{}
+
+	h := NewPromoteHandler(nil, userRepo, auditLog)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/promote @member")))
+
+	require.Len(t, auditLog.changes, 1)
+	c := auditLog.changes[0]
+	assert.Equal(t, auditTestChatID, c.GroupID)
+	assert.Equal(t, int64(2), c.UserID)
+	assert.Equal(t, user.PermissionAdmin, c.OldPerm)
+	assert.Equal(t, user.PermissionSuperAdmin, c.NewPerm)
+	assert.Equal(t, int64(1), c.ActorID)
+	assert.False(t, c.CreatedAt.IsZero())
+	userRepo.AssertExpectations(t)
+}
+
+func TestDemoteHandler_AppendsPermissionChange(t *testing.T) {
+	tb := newTestBot(t)
+	actor, target := newAuditTestUsers()
+	target.SetPermission(auditTestChatID, user.PermissionAdmin)
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "member").Return(target, nil)
+	userRepo.On("UpdatePermission", mock.Anything, int64(2), auditTestChatID, user.PermissionUser).Return(nil)
+	auditLog := &fakeAuditLog{}
+
+	h := NewDemoteHandler(nil, userRepo, auditLog)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/demote @member")))
+
+	require.Len(t, auditLog.changes, 1)
+	assert.Equal(t, user.PermissionAdmin, auditLog.changes[0].OldPerm)
+	assert.Equal(t, user.PermissionUser, auditLog.changes[0].NewPerm)
+	assert.Equal(t, int64(1), auditLog.changes[0].ActorID)
+}
+
+func TestPromoteHandler_NoChangeRecordedWhenUpdateFails(t *testing.T) {
+	tb := newTestBot(t)
+	actor, target := newAuditTestUsers()
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "member").Return(target, nil)
+	userRepo.On("UpdatePermission", mock.Anything, int64(2), auditTestChatID, user.PermissionAdmin).Return(errors.New("db down"))
+	auditLog := &fakeAuditLog{}
+
+	h := NewPromoteHandler(nil, userRepo, auditLog)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/promote @member")))
+
+	assert.Empty(t, auditLog.changes)
+	assert.Equal(t, []string{"❌ 权限更新失败，请稍后重试"}, tb.Replies())
+}
+
+func TestPromoteHandler_ReportsAuditFailure(t *testing.T) {
+	tb := newTestBot(t)
+	actor, target := newAuditTestUsers()
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "member").Return(target, nil)
+	userRepo.On("UpdatePermission", mock.Anything, int64(2), auditTestChatID, user.PermissionAdmin).Return(nil)
+	auditLog := &fakeAuditLog{err: errors.New("db down")}
+
+	h := NewPromoteHandler(nil, userRepo, auditLog)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/promote @member")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "权限已提升")
+	assert.Contains(t, replies[0], "权限变更记录保存失败")
+}
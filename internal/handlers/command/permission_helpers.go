@@ -3,42 +3,54 @@ package command
 import (
 	"context"
 	"fmt"
-	"strings"
+	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
 )
 
-// GetTargetUser 从参数或回复消息中获取目标用户
-func GetTargetUser(reqCtx context.Context, ctx *handler.Context, userRepo UserRepository) (*user.User, error) {
-	// 方式 1: 从参数获取 @username
-	args := ParseArgs(ctx.Text)
-	if len(args) > 0 {
-		username := strings.TrimPrefix(args[0], "@")
-		u, err := userRepo.FindByUsername(reqCtx, username)
-		if err != nil {
-			// 包装数据库错误，避免暴露内部细节
-			if err == user.ErrUserNotFound {
-				return nil, fmt.Errorf("用户 @%s 不存在或未使用过此机器人", username)
-			}
-			return nil, fmt.Errorf("查询用户失败，请稍后重试")
-		}
-		return u, nil
+// PermissionAuditLog 权限变更记录接口
+type PermissionAuditLog interface {
+	Append(ctx context.Context, c *user.PermissionChange) error
+}
+
+// recordPermissionChange 追加权限变更记录
+// 权限已生效，记录保存失败不回滚，只返回附加到回复中的提示
+func recordPermissionChange(reqCtx context.Context, auditLog PermissionAuditLog, ctx *handler.Context, target *user.User, oldPerm, newPerm user.Permission) string {
+	change := user.NewPermissionChange(ctx.ChatID, target.ID, oldPerm, newPerm, ctx.UserID)
+	if err := auditLog.Append(reqCtx, change); err != nil {
+		return "\n⚠️ 权限变更记录保存失败"
 	}
+	return ""
+}
 
-	// 方式 2: 从回复消息获取
-	if ctx.ReplyTo != nil {
-		u, err := userRepo.FindByID(reqCtx, ctx.ReplyTo.UserID)
-		if err != nil {
-			// 包装数据库错误
-			if err == user.ErrUserNotFound {
-				return nil, fmt.Errorf("回复的用户不存在或未使用过此机器人")
-			}
-			return nil, fmt.Errorf("查询用户失败，请稍后重试")
+// errorText 获取展示给用户的错误文本
+// 带错误码的错误只展示消息部分，不展示错误码和原始错误
+func errorText(err error) string {
+	var appErr errors.Error
+	if errors.As(err, &appErr) {
+		return appErr.Message()
+	}
+	return err.Error()
+}
+
+// isOwnerID 检查用户ID是否在配置的Owner列表中（BOT_OWNER_IDS）
+func isOwnerID(ownerIDs []int64, userID int64) bool {
+	for _, id := range ownerIDs {
+		if id == userID {
+			return true
 		}
-		return u, nil
 	}
+	return false
+}
 
-	return nil, fmt.Errorf("未指定目标用户，请使用 @username 或回复用户消息")
+// parsePermission 解析可分配的权限等级名称（user/admin/superadmin/owner，不区分大小写）
+func parsePermission(s string) (user.Permission, bool) {
+	perm, ok := user.ParsePermission(s)
+	if !ok || perm == user.PermissionNone {
+		return 0, false
+	}
+	return perm, true
 }
 
 // GetPermIcon 获取权限图标
@@ -52,6 +64,8 @@ func GetPermIcon(perm user.Permission) string {
 		return "🛡"
 	case user.PermissionUser:
 		return "👤"
+	case user.PermissionNone:
+		return "🚷"
 	default:
 		return "❓"
 	}
@@ -67,3 +81,31 @@ func FormatUsername(u *user.User) string {
 	}
 	return fmt.Sprintf("User#%d", u.ID)
 }
+
+// MemberLookup 查询群组成员实时信息接口（由 telegram.API 实现）
+type MemberLookup interface {
+	GetChatMember(ctx context.Context, chatID, userID int64) (*group.Member, error)
+}
+
+// lookupDisplayName 返回群组成员的显示名称
+// 数据库中没有该用户或没有用户名和名字时查询 Telegram（members 可为 nil），
+// 并顺便把查到的名字更新到已有的用户记录中；都失败时显示用户 ID
+func lookupDisplayName(reqCtx context.Context, userRepo UserRepository, members MemberLookup, chatID, userID int64) string {
+	u, err := userRepo.FindByID(reqCtx, userID)
+	if err == nil && (u.Username != "" || u.FirstName != "") {
+		return FormatUsername(u)
+	}
+
+	if members != nil {
+		if m, lookupErr := members.GetChatMember(reqCtx, chatID, userID); lookupErr == nil && (m.Username != "" || m.FirstName != "") {
+			if err == nil {
+				u.Username, u.FirstName, u.LastName = m.Username, m.FirstName, m.LastName
+				_ = userRepo.Update(reqCtx, u) // 尽力而为，失败不影响显示
+				return FormatUsername(u)
+			}
+			return FormatUsername(user.NewUser(userID, m.Username, m.FirstName, m.LastName))
+		}
+	}
+
+	return fmt.Sprintf("ID %d", userID)
+}
@@ -2,12 +2,16 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetPermIcon(t *testing.T) {
@@ -126,3 +130,88 @@ func TestGetTargetUser_NoTarget(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "未指定目标用户")
 }
+
+func TestGetTargetUser_ErrorCodes(t *testing.T) {
+	reqCtx := context.TODO()
+
+	t.Run("unknown username", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "ghost").Return(nil, user.ErrUserNotFound).Once()
+
+		_, err := GetTargetUser(reqCtx, &handler.Context{Text: "/promote @ghost"}, userRepo)
+		assert.True(t, errors.HasCode(err, errors.CodeUserNotFound))
+		assert.Equal(t, "用户 @ghost 不存在或未使用过此机器人", errorText(err))
+	})
+
+	t.Run("unknown reply user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByID", reqCtx, int64(456)).Return(nil, user.ErrUserNotFound).Once()
+
+		ctx := &handler.Context{Text: "/promote", ReplyTo: &handler.ReplyInfo{UserID: 456}}
+		_, err := GetTargetUser(reqCtx, ctx, userRepo)
+		assert.True(t, errors.HasCode(err, errors.CodeUserNotFound))
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "target").Return(nil, assert.AnError).Once()
+
+		_, err := GetTargetUser(reqCtx, &handler.Context{Text: "/promote @target"}, userRepo)
+		assert.True(t, errors.HasCode(err, errors.CodeInternal))
+		assert.Equal(t, "查询用户失败，请稍后重试", errorText(err))
+		assert.ErrorIs(t, errors.Unwrap(err), assert.AnError)
+	})
+
+	t.Run("no target", func(t *testing.T) {
+		_, err := GetTargetUser(reqCtx, &handler.Context{Text: "/promote"}, new(MockUserRepository))
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+	})
+}
+
+func TestErrorText(t *testing.T) {
+	assert.Equal(t, "plain", errorText(fmt.Errorf("plain")))
+	assert.Equal(t, "用户不存在", errorText(errors.NotFound(errors.CodeUserNotFound, "用户不存在")))
+}
+
+// fakeMemberLookup 按用户返回预设的群组成员信息
+type fakeMemberLookup map[int64]*group.Member
+
+func (f fakeMemberLookup) GetChatMember(ctx context.Context, chatID, userID int64) (*group.Member, error) {
+	if m, ok := f[userID]; ok {
+		return m, nil
+	}
+	return nil, assert.AnError
+}
+
+func TestLookupDisplayName(t *testing.T) {
+	reqCtx := context.TODO()
+	chatID := int64(-100)
+
+	stale := user.NewUser(2, "", "", "")
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByID", reqCtx, int64(1)).Return(user.NewUser(1, "known", "", ""), nil)
+	userRepo.On("FindByID", reqCtx, int64(2)).Return(stale, nil)
+	userRepo.On("FindByID", reqCtx, int64(3)).Return(nil, user.ErrUserNotFound)
+	userRepo.On("FindByID", reqCtx, int64(4)).Return(nil, user.ErrUserNotFound)
+	userRepo.On("Update", reqCtx, mock.MatchedBy(func(u *user.User) bool {
+		return u.ID == 2 && u.Username == "renamed"
+	})).Return(nil).Once()
+
+	members := fakeMemberLookup{
+		1: {UserID: 1, Username: "ignored"},
+		2: {UserID: 2, Username: "renamed", FirstName: "Bob"},
+		3: {UserID: 3, FirstName: "Carol"},
+	}
+
+	// 数据库中有名字时不查询 Telegram
+	assert.Equal(t, "@known", lookupDisplayName(reqCtx, userRepo, members, chatID, 1))
+	// 数据库记录缺少名字时用 Telegram 的资料补全
+	assert.Equal(t, "@renamed", lookupDisplayName(reqCtx, userRepo, members, chatID, 2))
+	// 数据库中没有的用户直接显示 Telegram 的名字
+	assert.Equal(t, "Carol", lookupDisplayName(reqCtx, userRepo, members, chatID, 3))
+	// 都查不到时显示 ID
+	assert.Equal(t, "ID 4", lookupDisplayName(reqCtx, userRepo, members, chatID, 4))
+	assert.Equal(t, "ID 4", lookupDisplayName(reqCtx, userRepo, nil, chatID, 4))
+
+	userRepo.AssertExpectations(t)
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+// permLogLimit /permlog 展示的最大记录数
+const permLogLimit = 10
+
+// PermissionChangeReader 权限变更记录查询接口
+type PermissionChangeReader interface {
+	FindByUser(ctx context.Context, groupID, userID int64, limit int) ([]*user.PermissionChange, error)
+	ListRecent(ctx context.Context, groupID int64, limit int, before time.Time) ([]*user.PermissionChange, error)
+}
+
+// PermLogHandler 查看权限变更记录命令处理器
+// /permlog <用户> 查看用户的变更记录；/permlog recent [游标] 分页查看本群所有变更记录
+type PermLogHandler struct {
+	*BaseCommand
+	userRepo   UserRepository
+	changeRepo PermissionChangeReader
+}
+
+// NewPermLogHandler 创建查看权限变更记录命令处理器
+func NewPermLogHandler(groupRepo GroupRepository, userRepo UserRepository, changeRepo PermissionChangeReader) *PermLogHandler {
+	return &PermLogHandler{
+		BaseCommand: NewBaseCommand(
+			"permlog",
+			"查看用户的权限变更记录",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		userRepo:   userRepo,
+		changeRepo: changeRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *PermLogHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	args := ParseArgs(ctx.Text)
+	if len(args) >= 1 && len(args) <= 2 && strings.EqualFold(args[0], "recent") {
+		var before time.Time
+		if len(args) == 2 {
+			var ok bool
+			if before, ok = parseCursor(args[1]); !ok {
+				return ctx.Reply("❌ 无效的分页游标")
+			}
+		}
+		return h.handleRecent(ctx, before)
+	}
+
+	// 2. 获取目标用户
+	targetUser, err := GetTargetUser(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+
+	// 3. 查询变更记录
+	changes, err := h.changeRepo.FindByUser(reqCtx, ctx.ChatID, targetUser.ID, permLogLimit)
+	if err != nil {
+		return ctx.Reply("❌ 查询权限变更记录失败，请稍后重试")
+	}
+
+	return ctx.ReplyHTML(h.formatChanges(reqCtx, ctx.Group, targetUser, changes))
+}
+
+// handleRecent 查看本群早于 before 的变更记录（before 为零值时从最新开始）
+func (h *PermLogHandler) handleRecent(ctx *handler.Context, before time.Time) error {
+	reqCtx := ctx.RequestContext()
+
+	changes, err := h.changeRepo.ListRecent(reqCtx, ctx.ChatID, permLogLimit, before)
+	if err != nil {
+		return ctx.Reply("❌ 查询权限变更记录失败，请稍后重试")
+	}
+	if len(changes) == 0 {
+		return ctx.Reply("📜 本群没有更多权限变更记录")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 <b>本群权限变更记录</b>（%d 条）\n\n", len(changes)))
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("• %s  %s: <b>%s</b> → <b>%s</b>  操作人: %s\n",
+			formatTime(ctx.Group, c.CreatedAt, time.Now()),
+			html.EscapeString(h.userName(reqCtx, c.UserID)),
+			c.OldPerm.String(),
+			c.NewPerm.String(),
+			html.EscapeString(h.userName(reqCtx, c.ActorID))))
+	}
+	sb.WriteString(nextPageHint("/permlog recent", len(changes), permLogLimit, changes[len(changes)-1].CreatedAt))
+
+	return ctx.ReplyHTML(sb.String())
+}
+
+// formatChanges 格式化变更记录
+func (h *PermLogHandler) formatChanges(reqCtx context.Context, g *group.Group, target *user.User, changes []*user.PermissionChange) string {
+	name := html.EscapeString(FormatUsername(target))
+	if len(changes) == 0 {
+		return fmt.Sprintf("📜 用户 <b>%s</b> 在本群没有权限变更记录", name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 <b>%s 的权限变更记录</b>（最近 %d 条）\n\n", name, len(changes)))
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("• %s  <b>%s</b> → <b>%s</b>  操作人: %s\n",
+			formatTime(g, c.CreatedAt, time.Now()),
+			c.OldPerm.String(),
+			c.NewPerm.String(),
+			html.EscapeString(h.userName(reqCtx, c.ActorID))))
+	}
+	return sb.String()
+}
+
+// userName 获取用户（目标或操作人）显示名称，查询失败时显示用户 ID
+func (h *PermLogHandler) userName(reqCtx context.Context, userID int64) string {
+	u, err := h.userRepo.FindByID(reqCtx, userID)
+	if err != nil {
+		return fmt.Sprintf("User#%d", userID)
+	}
+	return FormatUsername(u)
+}
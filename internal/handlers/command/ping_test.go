@@ -142,12 +142,13 @@ func TestPingHandler_Match(t *testing.T) {
 			expected: true,
 		},
 		{
-			name: "does not match in channel",
+			// 频道由 Router 通过 AllowedChatTypes 拦截
+			name: "matches in channel",
 			ctx: &handler.Context{
 				Text:     "/ping",
 				ChatType: "channel",
 			},
-			expected: false,
+			expected: true,
 		},
 	}
 
@@ -166,6 +167,8 @@ func TestPingHandler_Match(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
+
+	assert.False(t, handler.AllowsChatType(h, "channel"))
 }
 
 // TestPingHandler_Handle is skipped because it requires a real Telegram Bot
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// ProtectHandler 保护用户命令处理器
+// /protect 将用户加入群组保护列表，自动管理（如防突袭禁言）会跳过该用户；/unprotect 移出
+type ProtectHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+	userRepo  UserRepository
+	protect   bool // true: /protect，false: /unprotect
+}
+
+// NewProtectHandler 创建保护用户命令处理器
+func NewProtectHandler(groupRepo GroupRepository, userRepo UserRepository) *ProtectHandler {
+	return &ProtectHandler{
+		BaseCommand: NewBaseCommand(
+			"protect",
+			"保护用户不受自动管理影响",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		protect:   true,
+	}
+}
+
+// NewUnprotectHandler 创建取消保护用户命令处理器
+func NewUnprotectHandler(groupRepo GroupRepository, userRepo UserRepository) *ProtectHandler {
+	return &ProtectHandler{
+		BaseCommand: NewBaseCommand(
+			"unprotect",
+			"取消用户的保护",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		protect:   false,
+	}
+}
+
+// Handle 处理命令
+func (h *ProtectHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取目标用户
+	target, err := GetTargetUser(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+	name := html.EscapeString(FormatUsername(target))
+
+	// 3. 更新保护列表
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	var changed bool
+	if h.protect {
+		changed = g.Protect(target.ID)
+	} else {
+		changed = g.Unprotect(target.ID)
+	}
+	if !changed {
+		if h.protect {
+			return ctx.ReplyHTML(fmt.Sprintf("ℹ️ <b>%s</b> 已在保护列表中", name))
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("ℹ️ <b>%s</b> 不在保护列表中", name))
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存失败，请稍后重试")
+	}
+
+	// 4. 成功反馈
+	if h.protect {
+		return ctx.ReplyHTML(fmt.Sprintf("🛡 <b>%s</b> 已加入保护列表，自动管理将跳过该用户", name))
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 已将 <b>%s</b> 移出保护列表", name))
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectHandler_Handle(t *testing.T) {
+	const chatID = int64(-100123)
+
+	tb := newTestBot(t)
+	actor := user.NewUser(1, "mod", "Mod", "")
+	actor.SetPermission(chatID, user.PermissionAdmin)
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+
+	groupRepo := new(MockGroupRepository)
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "partnerbot").Return(user.NewUser(2, "partnerbot", "", ""), nil)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Twice()
+
+	protect := NewProtectHandler(groupRepo, userRepo)
+	unprotect := NewUnprotectHandler(groupRepo, userRepo)
+
+	require.NoError(t, protect.Handle(newTestContext(tb, chatID, actor, "/protect @partnerbot")))
+	assert.True(t, g.IsProtected(2))
+
+	// 重复添加不保存
+	require.NoError(t, protect.Handle(newTestContext(tb, chatID, actor, "/protect @partnerbot")))
+
+	require.NoError(t, unprotect.Handle(newTestContext(tb, chatID, actor, "/unprotect @partnerbot")))
+	assert.False(t, g.IsProtected(2))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "已加入保护列表")
+	assert.Contains(t, replies[1], "已在保护列表中")
+	assert.Contains(t, replies[2], "移出保护列表")
+	groupRepo.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"unicode/utf8"
+)
+
+const (
+	reasonTemplatePrefix    = "#" // 原因以 #名称 开头时展开为模板
+	reasonTemplateMaxLength = 200 // 模板文本最大字数
+	reasonTemplateMaxCount  = 50  // 每个群组最多保存的模板数
+)
+
+// reasonTemplateNamePattern 模板名称：字母、数字、下划线，最长 32 个字符
+var reasonTemplateNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,32}$`)
+
+// ReasonsHandler 管理操作原因模板命令处理器
+// /reasons 列出模板；/reasons add <名称> <原因> 添加或覆盖；/reasons del <名称> 删除
+// 管理命令（如 /tempban）的原因写成 #名称 时展开为模板文本
+type ReasonsHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewReasonsHandler 创建原因模板命令处理器
+func NewReasonsHandler(groupRepo GroupRepository) *ReasonsHandler {
+	return &ReasonsHandler{
+		BaseCommand: NewBaseCommand(
+			"reasons",
+			"管理封禁等操作的原因模板",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *ReasonsHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时列出模板
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		return ctx.ReplyHTML(formatReasonTemplates(g))
+	}
+
+	// 4. 添加或删除
+	var reply string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 3 {
+			return handler.Usage(reasonsUsage())
+		}
+		name := strings.ToLower(args[1])
+		text := strings.Trim(trimLeadingWords(ctx.Text, 3), `"“”`)
+		if !reasonTemplateNamePattern.MatchString(name) {
+			return ctx.Reply("❌ 模板名称只能包含字母、数字和下划线，最长 32 个字符")
+		}
+		if text == "" || utf8.RuneCountInString(text) > reasonTemplateMaxLength {
+			return ctx.Reply(fmt.Sprintf("❌ 原因不能为空，最多 %d 字", reasonTemplateMaxLength))
+		}
+		if _, exists := g.ReasonTemplate(name); !exists && len(g.GetStringMapSetting(group.SettingReasonTemplates)) >= reasonTemplateMaxCount {
+			return ctx.Reply(fmt.Sprintf("❌ 每个群组最多保存 %d 个模板", reasonTemplateMaxCount))
+		}
+		g.SetReasonTemplate(name, text)
+		reply = fmt.Sprintf("✅ 已保存模板 <code>%s%s</code>: %s",
+			reasonTemplatePrefix, html.EscapeString(name), html.EscapeString(text))
+
+	case "del":
+		if len(args) != 2 {
+			return handler.Usage(reasonsUsage())
+		}
+		name := strings.ToLower(args[1])
+		if !g.DeleteReasonTemplate(name) {
+			return ctx.ReplyHTML(fmt.Sprintf("❌ 模板 <code>%s</code> 不存在", html.EscapeString(name)))
+		}
+		reply = fmt.Sprintf("✅ 已删除模板 <code>%s%s</code>", reasonTemplatePrefix, html.EscapeString(name))
+
+	default:
+		return handler.Usage(reasonsUsage())
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+	return ctx.ReplyHTML(reply)
+}
+
+// formatReasonTemplates 按名称排序列出模板
+func formatReasonTemplates(g *group.Group) string {
+	templates := g.GetStringMapSetting(group.SettingReasonTemplates)
+	if len(templates) == 0 {
+		return "📭 本群还没有原因模板\n\n" + reasonsUsage()
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📝 <b>原因模板</b>（%d）\n", len(names)))
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("\n<code>%s%s</code> — %s",
+			reasonTemplatePrefix, html.EscapeString(name), html.EscapeString(templates[name])))
+	}
+	return sb.String()
+}
+
+// reasonsUsage 返回命令用法说明
+func reasonsUsage() string {
+	return "用法: <code>/reasons add 名称 原因</code>、<code>/reasons del 名称</code>\n" +
+		"在封禁等命令中用 <code>#名称</code> 作为原因，例如 <code>/tempban @user 1d #spam</code>"
+}
+
+// expandReason 展开原因中的模板
+// 原因的第一个词为 #名称 且模板存在时替换为模板文本，其余文字作为补充说明保留；
+// 模板不存在时原样返回（可能只是普通的话题标签）
+func expandReason(g *group.Group, reason string) string {
+	if g == nil || !strings.HasPrefix(reason, reasonTemplatePrefix) {
+		return reason
+	}
+
+	name := strings.Fields(reason)[0]
+	text, ok := g.ReasonTemplate(strings.TrimPrefix(name, reasonTemplatePrefix))
+	if !ok {
+		return reason
+	}
+
+	if extra := trimLeadingWords(reason, 1); extra != "" {
+		return text + " " + extra
+	}
+	return text
+}
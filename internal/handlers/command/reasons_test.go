@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReasonsHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Times(3)
+
+	h := NewReasonsHandler(groupRepo)
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, `/reasons add spam "发送广告，永久封禁"`)))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/reasons add Flood 刷屏")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/reasons add bad-name 原因")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/reasons")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/reasons del flood")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/reasons del flood")))
+
+	text, ok := g.ReasonTemplate("spam")
+	require.True(t, ok)
+	assert.Equal(t, "发送广告，永久封禁", text)
+	_, ok = g.ReasonTemplate("flood")
+	assert.False(t, ok)
+
+	replies := tb.Replies()
+	require.Len(t, replies, 6)
+	assert.Contains(t, replies[0], "已保存模板 <code>#spam</code>")
+	assert.Contains(t, replies[1], "<code>#flood</code>")
+	assert.Contains(t, replies[2], "模板名称只能包含")
+	assert.Contains(t, replies[3], "<code>#flood</code> — 刷屏\n<code>#spam</code> — 发送广告，永久封禁")
+	assert.Contains(t, replies[4], "已删除模板 <code>#flood</code>")
+	assert.Contains(t, replies[5], "不存在")
+	groupRepo.AssertExpectations(t)
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// RestartHandler 重启命令处理器（仅限配置的 Owner）
+// 回复确认后触发与 SIGTERM 相同的优雅关闭流程，由 systemd/k8s 等进程管理器重新拉起
+type RestartHandler struct {
+	*BaseCommand
+	ownerIDs []int64
+	restart  func() // 触发优雅关闭，不等待关闭完成
+}
+
+// NewRestartHandler 创建重启命令处理器
+func NewRestartHandler(groupRepo GroupRepository, ownerIDs []int64, restart func()) *RestartHandler {
+	return &RestartHandler{
+		BaseCommand: NewBaseCommand(
+			"restart",
+			"重启机器人",
+			user.PermissionOwner, // 需要 Owner 权限
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		ownerIDs: ownerIDs,
+		restart:  restart,
+	}
+}
+
+// Handle 处理命令
+// 先发送确认再触发关闭：关闭流程会等待本条消息处理完成，确认消息不会丢失
+func (h *RestartHandler) Handle(ctx *handler.Context) error {
+	// 群组内被设置为 Owner 的用户也无法使用，仅限 BOT_OWNER_IDS 中配置的用户
+	if !isOwnerID(h.ownerIDs, ctx.UserID) {
+		return ctx.Reply("❌ 此命令仅限机器人所有者使用")
+	}
+
+	err := ctx.Reply("🔄 正在重启，处理中的消息完成后退出，稍后由进程管理器重新启动")
+	h.restart()
+	return err
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	restarts := 0
+	h := NewRestartHandler(new(MockGroupRepository), []int64{1}, func() { restarts++ })
+
+	other := user.NewUser(2, "alice", "Alice", "")
+	other.SetPermission(chatID, user.PermissionOwner) // 群组内 Owner，但不在配置列表中
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, other, "/restart")))
+	assert.Equal(t, 0, restarts)
+
+	owner := user.NewUser(1, "owner", "Owner", "")
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, owner, "/restart")))
+	assert.Equal(t, 1, restarts)
+
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+	assert.Equal(t, "❌ 此命令仅限机器人所有者使用", replies[0])
+	assert.Contains(t, replies[1], "正在重启")
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"unicode/utf8"
+)
+
+// maxRulesLength 群规最大长度（字符），留出余量避免超过 Telegram 4096 字符限制
+const maxRulesLength = 3000
+
+// RulesHandler 群规命令处理器
+// /rules 查看群规（所有人），/rules set <内容> 设置、/rules clear 清除（Admin）
+type RulesHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewRulesHandler 创建群规命令处理器
+func NewRulesHandler(groupRepo GroupRepository) *RulesHandler {
+	return &RulesHandler{
+		BaseCommand: NewBaseCommand(
+			"rules",
+			"查看群规（管理员可设置）",
+			user.PermissionUser, // 查看无需权限，设置在 Handle 中检查
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *RulesHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	args := ParseArgs(ctx.Text)
+	editing := len(args) > 0 && (args[0] == "set" || args[0] == "clear")
+
+	// 1. 修改群规需要 Admin 权限
+	if editing {
+		if err := ctx.RequirePermission(user.PermissionAdmin); err != nil {
+			return err
+		}
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	if !editing {
+		return ctx.ReplyHTML(formatRules(g))
+	}
+
+	// 3. 修改并保存
+	reply, changed := applyRulesChange(g, args[0], trimLeadingWords(ctx.Text, 2))
+	if changed {
+		if err := h.groupRepo.Update(reqCtx, g); err != nil {
+			return ctx.Reply("❌ 保存群规失败，请稍后重试")
+		}
+	}
+
+	return ctx.ReplyHTML(reply)
+}
+
+// formatRules 格式化群规，未设置时提示管理员设置
+func formatRules(g *group.Group) string {
+	rules := g.Rules()
+	if rules == "" {
+		return "📜 本群尚未设置群规\n\n" +
+			"<i>管理员可使用 <code>/rules set 群规内容</code> 设置</i>"
+	}
+	return "📜 <b>群规</b>\n\n" + html.EscapeString(rules)
+}
+
+// applyRulesChange 执行 set/clear，返回回复内容和群组是否被修改
+func applyRulesChange(g *group.Group, action, text string) (string, bool) {
+	if action == "clear" {
+		if _, ok := g.GetStringSetting(group.SettingRules); !ok {
+			return "ℹ️ 本群尚未设置群规", false
+		}
+		g.DeleteSetting(group.SettingRules)
+		return "✅ 群规已清除", true
+	}
+
+	if text == "" {
+		return "❌ 请提供群规内容\n\n<b>用法:</b> <code>/rules set 群规内容</code>", false
+	}
+	if n := utf8.RuneCountInString(text); n > maxRulesLength {
+		return fmt.Sprintf("❌ 群规过长（%d 字），最多 %d 字", n, maxRulesLength), false
+	}
+
+	g.SetSetting(group.SettingRules, text)
+	return "✅ 群规已更新\n\n" + formatRules(g), true
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRules_Unset(t *testing.T) {
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+
+	text := formatRules(g)
+	assert.Contains(t, text, "尚未设置群规")
+	assert.Contains(t, text, "/rules set")
+}
+
+func TestApplyRulesChange_SetAndGet(t *testing.T) {
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+
+	reply, changed := applyRulesChange(g, "set", "1. Be nice\n2. No <spam>")
+	assert.True(t, changed)
+	assert.Contains(t, reply, "群规已更新")
+
+	rules, ok := g.GetStringSetting(group.SettingRules)
+	assert.True(t, ok)
+	assert.Equal(t, "1. Be nice\n2. No <spam>", rules)
+
+	// 显示时转义 HTML，保留换行
+	assert.Equal(t, "📜 <b>群规</b>\n\n1. Be nice\n2. No &lt;spam&gt;", formatRules(g))
+}
+
+func TestApplyRulesChange_Clear(t *testing.T) {
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+
+	_, changed := applyRulesChange(g, "clear", "")
+	assert.False(t, changed, "clearing unset rules is a no-op")
+
+	applyRulesChange(g, "set", "Be nice")
+	reply, changed := applyRulesChange(g, "clear", "")
+	assert.True(t, changed)
+	assert.Contains(t, reply, "群规已清除")
+	assert.Contains(t, formatRules(g), "尚未设置群规")
+}
+
+func TestApplyRulesChange_Validation(t *testing.T) {
+	g := group.NewGroup(-100, "Test Group", "supergroup")
+
+	_, changed := applyRulesChange(g, "set", "")
+	assert.False(t, changed)
+
+	// 按字符而不是字节计算长度
+	_, changed = applyRulesChange(g, "set", strings.Repeat("规", maxRulesLength))
+	assert.True(t, changed)
+
+	reply, changed := applyRulesChange(g, "set", strings.Repeat("a", maxRulesLength+1))
+	assert.False(t, changed)
+	assert.Contains(t, reply, "群规过长")
+
+	// 超长时保留原有群规
+	rules, _ := g.GetStringSetting(group.SettingRules)
+	assert.Equal(t, strings.Repeat("规", maxRulesLength), rules)
+}
+
+func TestTrimLeadingWords_RulesText(t *testing.T) {
+	assert.Equal(t, "1. Be nice\n2. No spam", trimLeadingWords("/rules set 1. Be nice\n2. No spam", 2))
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// rulesAckMaxMinutes 新成员同意群规时限的上限（一天）
+const rulesAckMaxMinutes = 24 * 60
+
+// RulesGateHandler 设置新成员同意群规命令处理器
+// /rulesgate <分钟> 新成员入群后被禁言，需在时限内点击按钮同意群规（/rules）才能发言，超时移出群组；
+// /rulesgate off 关闭
+type RulesGateHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewRulesGateHandler 创建设置新成员同意群规命令处理器
+func NewRulesGateHandler(groupRepo GroupRepository) *RulesGateHandler {
+	return &RulesGateHandler{
+		BaseCommand: NewBaseCommand(
+			"rulesgate",
+			"要求新成员在时限内同意群规，否则移出群组",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *RulesGateHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前设置
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		status := "未开启"
+		if timeout := g.RulesAckTimeout(); timeout > 0 {
+			status = fmt.Sprintf("%d 分钟内同意", int(timeout.Minutes()))
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("📜 新成员同意群规: <b>%s</b>\n用法: <code>/rulesgate 分钟|off</code>", status))
+	}
+
+	// 4. 更新设置
+	var minutes int64
+	if !strings.EqualFold(args[0], "off") {
+		minutes, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil || minutes < 0 || minutes > rulesAckMaxMinutes {
+			return ctx.Reply(fmt.Sprintf("❌ 请输入 0-%d 之间的分钟数，或 off 关闭", rulesAckMaxMinutes))
+		}
+	}
+	if minutes > 0 && g.Rules() == "" {
+		return ctx.ReplyHTML("❌ 本群尚未设置群规，请先使用 <code>/rules set 群规内容</code> 设置")
+	}
+
+	if minutes == 0 {
+		g.DeleteSetting(group.SettingRulesAckMinutes)
+	} else {
+		g.SetSetting(group.SettingRulesAckMinutes, minutes)
+	}
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	if minutes == 0 {
+		return ctx.Reply("✅ 已关闭新成员同意群规")
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 新成员入群后将被禁言，需在 <b>%d 分钟</b>内同意群规，否则将被移出群组", minutes))
+}
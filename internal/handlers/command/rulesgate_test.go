@@ -0,0 +1,48 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesGateHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Twice()
+
+	h := NewRulesGateHandler(groupRepo)
+
+	// 未设置群规时不能开启
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/rulesgate 5")))
+	assert.Zero(t, g.RulesAckTimeout())
+
+	g.SetSetting(group.SettingRules, "不要刷屏")
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/rulesgate 2000")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/rulesgate 5")))
+	assert.Equal(t, 5*time.Minute, g.RulesAckTimeout())
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/rulesgate off")))
+	assert.Zero(t, g.RulesAckTimeout())
+
+	replies := tb.Replies()
+	require.Len(t, replies, 4)
+	assert.Contains(t, replies[0], "尚未设置群规")
+	assert.Contains(t, replies[1], "请输入 0-1440 之间的分钟数")
+	assert.Contains(t, replies[2], "5 分钟")
+	assert.Equal(t, "✅ 已关闭新成员同意群规", replies[3])
+	groupRepo.AssertExpectations(t)
+}
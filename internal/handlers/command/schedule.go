@@ -0,0 +1,180 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/schedule"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/timeutil"
+	"time"
+	"unicode"
+)
+
+const (
+	minScheduleInterval       = time.Minute // 定时任务每分钟检查一次，更短的间隔没有意义
+	maxScheduledMessagesGroup = 10          // 每个群组最多的定时消息数量
+)
+
+// ScheduleRepository 定时消息命令所需的仓储接口
+type ScheduleRepository interface {
+	Save(ctx context.Context, m *schedule.Message) error
+	FindByGroup(ctx context.Context, groupID int64) ([]*schedule.Message, error)
+	Delete(ctx context.Context, groupID int64, id string) error
+}
+
+// ScheduleHandler 定时消息命令处理器
+// /schedule <间隔> <内容>  创建定时消息
+// /schedule list            查看本群定时消息
+// /schedule delete <ID>     删除定时消息
+type ScheduleHandler struct {
+	*BaseCommand
+	scheduleRepo ScheduleRepository
+	subcommands  *Subcommands
+}
+
+// NewScheduleHandler 创建定时消息命令处理器
+func NewScheduleHandler(groupRepo GroupRepository, scheduleRepo ScheduleRepository) *ScheduleHandler {
+	h := &ScheduleHandler{
+		BaseCommand: NewBaseCommand(
+			"schedule",
+			"管理群组定时消息",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		scheduleRepo: scheduleRepo,
+	}
+	h.subcommands = NewSubcommands("schedule", "📅 <b>定时消息</b>",
+		Subcommand{Args: "<间隔> <内容>", Description: "创建定时消息", Handle: h.handleCreate},
+		Subcommand{Name: "list", Description: "查看本群定时消息", Handle: h.handleList},
+		Subcommand{Name: "delete", Args: "<ID>", Description: "删除定时消息", Handle: h.handleDelete},
+	).WithNote("<i>间隔格式: 30m、2h、1d（最小 1 分钟）</i>")
+	return h
+}
+
+// Handle 处理命令
+func (h *ScheduleHandler) Handle(ctx *handler.Context) error {
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 分发子命令
+	return h.subcommands.Dispatch(ctx, ParseArgs(ctx.Text))
+}
+
+// handleCreate 创建定时消息
+func (h *ScheduleHandler) handleCreate(ctx *handler.Context, args []string) error {
+	reqCtx := ctx.RequestContext()
+
+	interval, err := timeutil.ParseInterval(args[0])
+	if err != nil {
+		return handler.Usage(h.subcommands.Help())
+	}
+	if interval < minScheduleInterval {
+		return ctx.Reply("❌ 间隔不能小于 1 分钟")
+	}
+
+	text := trimLeadingWords(ctx.Text, 2)
+	if text == "" {
+		return ctx.Reply("❌ 消息内容不能为空")
+	}
+
+	existing, err := h.scheduleRepo.FindByGroup(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 查询定时消息失败，请稍后重试")
+	}
+	if len(existing) >= maxScheduledMessagesGroup {
+		return ctx.Reply(fmt.Sprintf("❌ 每个群组最多 %d 条定时消息，请先删除不需要的消息", maxScheduledMessagesGroup))
+	}
+
+	m := schedule.NewMessage(ctx.ChatID, text, interval, ctx.UserID)
+	if err := h.scheduleRepo.Save(reqCtx, m); err != nil {
+		return ctx.Reply("❌ 保存定时消息失败，请稍后重试")
+	}
+
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 定时消息已创建\n\nID: <code>%s</code>\n间隔: <b>%s</b>\n首次发送: %s",
+		m.ID, formatInterval(m.Interval), formatTime(ctx.Group, m.NextRunAt, time.Now())))
+}
+
+// handleList 列出本群定时消息
+func (h *ScheduleHandler) handleList(ctx *handler.Context, args []string) error {
+	messages, err := h.scheduleRepo.FindByGroup(ctx.RequestContext(), ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 查询定时消息失败，请稍后重试")
+	}
+
+	if len(messages) == 0 {
+		return ctx.Reply("📭 本群暂无定时消息")
+	}
+
+	return ctx.ReplyHTML(formatScheduleList(messages, groupLocation(ctx.Group)))
+}
+
+// handleDelete 删除定时消息
+func (h *ScheduleHandler) handleDelete(ctx *handler.Context, args []string) error {
+	if len(args) == 0 {
+		return handler.Usage("❌ 请指定要删除的消息 ID\n\n用法: " + h.subcommands.Usage("delete"))
+	}
+
+	id := args[0]
+	if err := h.scheduleRepo.Delete(ctx.RequestContext(), ctx.ChatID, id); err != nil {
+		if err == schedule.ErrMessageNotFound {
+			return ctx.ReplyHTML(fmt.Sprintf("❌ 未找到 ID 为 <code>%s</code> 的定时消息", html.EscapeString(id)))
+		}
+		return ctx.Reply("❌ 删除定时消息失败，请稍后重试")
+	}
+
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 定时消息 <code>%s</code> 已删除", html.EscapeString(id)))
+}
+
+// formatScheduleList 格式化定时消息列表，下次发送时间按 loc 显示
+func formatScheduleList(messages []*schedule.Message, loc *time.Location) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📅 <b>本群定时消息</b>（%d）\n", len(messages)))
+
+	for _, m := range messages {
+		preview := []rune(m.Text)
+		if len(preview) > 30 {
+			preview = append(preview[:30], []rune("…")...)
+		}
+		sb.WriteString(fmt.Sprintf("\n<code>%s</code>\n  每 <b>%s</b> · 下次 %s\n  %s\n",
+			m.ID,
+			formatInterval(m.Interval),
+			m.NextRunAt.In(loc).Format("01-02 15:04"),
+			html.EscapeString(string(preview))))
+	}
+
+	return sb.String()
+}
+
+// formatInterval 以创建时的格式显示间隔（1d、2h、30m）
+func formatInterval(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
+
+// trimLeadingWords 去掉文本开头的 n 个词，保留剩余部分的原始格式（包括换行）
+// "/schedule 1h 第一行\n第二行" (n=2) -> "第一行\n第二行"
+func trimLeadingWords(text string, n int) string {
+	rest := strings.TrimSpace(text)
+	for i := 0; i < n; i++ {
+		idx := strings.IndexFunc(rest, unicode.IsSpace)
+		if idx == -1 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[idx:])
+	}
+	return rest
+}
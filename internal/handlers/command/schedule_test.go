@@ -0,0 +1,53 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/schedule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimLeadingWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		n        int
+		expected string
+	}{
+		{"keeps newlines", "/schedule 1h 第一行\n第二行", 2, "第一行\n第二行"},
+		{"extra spaces", "/schedule   30m   hello world", 2, "hello world"},
+		{"no content", "/schedule 1h", 2, ""},
+		{"no args", "/schedule", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, trimLeadingWords(tt.text, tt.n))
+		})
+	}
+}
+
+func TestFormatInterval(t *testing.T) {
+	assert.Equal(t, "1d", formatInterval(24*time.Hour))
+	assert.Equal(t, "2h", formatInterval(2*time.Hour))
+	assert.Equal(t, "90m", formatInterval(90*time.Minute))
+	assert.Equal(t, "1m30s", formatInterval(90*time.Second))
+}
+
+func TestFormatScheduleList(t *testing.T) {
+	messages := []*schedule.Message{
+		{
+			ID:        "abc123",
+			Text:      "<b>请遵守群规</b>",
+			Interval:  24 * time.Hour,
+			NextRunAt: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	result := formatScheduleList(messages, time.UTC)
+	assert.Contains(t, result, "<code>abc123</code>")
+	assert.Contains(t, result, "每 <b>1d</b>")
+	assert.Contains(t, result, "&lt;b&gt;请遵守群规&lt;/b&gt;")
+}
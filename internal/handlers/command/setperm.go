@@ -1,21 +1,24 @@
 package command
 
 import (
-	"context"
 	"fmt"
-	"strings"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
 )
 
+// setPermUsage 命令用法说明
+const setPermUsage = "❌ 用法: /setperm @username|用户ID <user|admin|superadmin|owner>，或回复消息 /setperm <等级>"
+
 // SetPermHandler 设置用户权限命令处理器
 type SetPermHandler struct {
 	*BaseCommand
 	userRepo UserRepository
+	auditLog PermissionAuditLog
 }
 
 // NewSetPermHandler 创建设置权限命令处理器
-func NewSetPermHandler(groupRepo GroupRepository, userRepo UserRepository) *SetPermHandler {
+func NewSetPermHandler(groupRepo GroupRepository, userRepo UserRepository, auditLog PermissionAuditLog) *SetPermHandler {
 	return &SetPermHandler{
 		BaseCommand: NewBaseCommand(
 			"setperm",
@@ -25,52 +28,37 @@ func NewSetPermHandler(groupRepo GroupRepository, userRepo UserRepository) *SetP
 			groupRepo,
 		),
 		userRepo: userRepo,
+		auditLog: auditLog,
 	}
 }
 
 // Handle 处理命令
 func (h *SetPermHandler) Handle(ctx *handler.Context) error {
-	reqCtx := context.TODO()
+	reqCtx := ctx.RequestContext()
 
 	// 1. 检查权限（必须是 Owner）
 	if err := h.CheckPermission(ctx); err != nil {
 		return err
 	}
 
-	// 2. 解析参数
-	args := ParseArgs(ctx.Text)
-	if len(args) < 2 {
-		return ctx.Reply("❌ 用法: /setperm @username <user|admin|superadmin|owner>")
+	// 2. 获取目标用户和权限等级
+	targetUser, rest, err := resolveTargetUser(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		if errors.HasCode(err, errors.CodeValidation) {
+			return ctx.Reply(setPermUsage)
+		}
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+	if len(rest) < 1 {
+		return ctx.Reply(setPermUsage)
 	}
-
-	username := strings.TrimPrefix(args[0], "@")
-	permStr := strings.ToLower(args[1])
 
 	// 3. 解析权限等级
-	var newPerm user.Permission
-	switch permStr {
-	case "user":
-		newPerm = user.PermissionUser
-	case "admin":
-		newPerm = user.PermissionAdmin
-	case "superadmin":
-		newPerm = user.PermissionSuperAdmin
-	case "owner":
-		newPerm = user.PermissionOwner
-	default:
+	newPerm, ok := parsePermission(rest[0])
+	if !ok {
 		return ctx.Reply("❌ 无效的权限等级，可选: user, admin, superadmin, owner")
 	}
 
-	// 4. 查找用户
-	targetUser, err := h.userRepo.FindByUsername(reqCtx, username)
-	if err != nil {
-		// 包装错误，避免暴露数据库细节
-		if err == user.ErrUserNotFound {
-			return ctx.Reply(fmt.Sprintf("❌ 用户 @%s 不存在或未使用过此机器人", username))
-		}
-		return ctx.Reply("❌ 查询用户失败，请稍后重试")
-	}
-
 	// 5. 获取当前权限
 	currentPerm := targetUser.GetPermission(ctx.ChatID)
 	executorPerm := ctx.User.GetPermission(ctx.ChatID)
@@ -96,7 +84,7 @@ func (h *SetPermHandler) Handle(ctx *handler.Context) error {
 	// 7. 更新本地对象（用于显示）
 	targetUser.SetPermission(ctx.ChatID, newPerm)
 
-	// 8. 成功反馈
+	// 8. 成功反馈（权限未变化时不记录变更）
 	if currentPerm == newPerm {
 		return ctx.ReplyHTML(fmt.Sprintf("✅ 用户 <b>%s</b> 权限保持不变:\n<b>%s</b> %s",
 			FormatUsername(targetUser),
@@ -104,9 +92,10 @@ func (h *SetPermHandler) Handle(ctx *handler.Context) error {
 			GetPermIcon(newPerm)))
 	}
 
+	auditNote := recordPermissionChange(reqCtx, h.auditLog, ctx, targetUser, currentPerm, newPerm)
 	return ctx.ReplyHTML(fmt.Sprintf("✅ 用户 <b>%s</b> 权限已设置:\n<b>%s</b> → <b>%s</b> %s",
 		FormatUsername(targetUser),
 		currentPerm.String(),
 		newPerm.String(),
-		GetPermIcon(newPerm)))
+		GetPermIcon(newPerm)) + auditNote)
 }
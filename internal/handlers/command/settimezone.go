@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+// SetTimezoneHandler 设置群组时区命令处理器
+// 封禁到期、定时消息、权限记录等时间按群组时区显示，默认 UTC
+type SetTimezoneHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+	now       func() time.Time
+}
+
+// NewSetTimezoneHandler 创建设置群组时区命令处理器
+func NewSetTimezoneHandler(groupRepo GroupRepository) *SetTimezoneHandler {
+	return &SetTimezoneHandler{
+		BaseCommand: NewBaseCommand(
+			"settimezone",
+			"设置本群显示时间使用的时区",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		now:       time.Now,
+	}
+}
+
+// Handle 处理命令
+func (h *SetTimezoneHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前时区
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		return ctx.ReplyHTML(fmt.Sprintf("🕒 本群时区: <b>%s</b>（当前时间 %s）\n用法: <code>/settimezone Asia/Shanghai</code>",
+			html.EscapeString(g.Location().String()), formatAbsoluteTime(g, h.now())))
+	}
+
+	// 4. 校验并保存
+	loc, err := group.LoadTimezone(args[0])
+	if err != nil {
+		return ctx.ReplyHTML(fmt.Sprintf("❌ 无效的时区 <code>%s</code>\n请使用 IANA 时区名称，如 <code>Asia/Shanghai</code>、<code>Europe/London</code>、<code>UTC</code>",
+			html.EscapeString(args[0])))
+	}
+
+	g.SetSetting(group.SettingTimezone, loc.String())
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 本群时区已设为 <b>%s</b>（当前时间 %s）",
+		html.EscapeString(loc.String()), formatAbsoluteTime(g, h.now())))
+}
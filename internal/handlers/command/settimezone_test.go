@@ -0,0 +1,46 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTimezoneHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Once()
+
+	h := NewSetTimezoneHandler(groupRepo)
+	h.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	// 无效时区不保存
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/settimezone Mars/Olympus")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/settimezone Local")))
+	_, ok := g.GetStringSetting(group.SettingTimezone)
+	assert.False(t, ok)
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/settimezone Asia/Tokyo")))
+	assert.Equal(t, "Asia/Tokyo", g.Location().String())
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "无效的时区")
+	assert.Contains(t, replies[1], "无效的时区")
+	assert.Contains(t, replies[2], "Asia/Tokyo")
+	assert.Contains(t, replies[2], "2025-01-01 09:00")
+	groupRepo.AssertExpectations(t)
+}
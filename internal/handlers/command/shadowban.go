@@ -0,0 +1,102 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// ShadowBanHandler 影子封禁命令处理器
+// /shadowban 将用户加入影子封禁列表，之后其消息会被静默删除；/unshadowban 解除
+type ShadowBanHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+	userRepo  UserRepository
+	ban       bool // true: /shadowban，false: /unshadowban
+}
+
+// NewShadowBanHandler 创建影子封禁命令处理器
+func NewShadowBanHandler(groupRepo GroupRepository, userRepo UserRepository) *ShadowBanHandler {
+	return &ShadowBanHandler{
+		BaseCommand: NewBaseCommand(
+			"shadowban",
+			"影子封禁用户（静默删除其消息）",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		ban:       true,
+	}
+}
+
+// NewUnshadowBanHandler 创建解除影子封禁命令处理器
+func NewUnshadowBanHandler(groupRepo GroupRepository, userRepo UserRepository) *ShadowBanHandler {
+	return &ShadowBanHandler{
+		BaseCommand: NewBaseCommand(
+			"unshadowban",
+			"解除影子封禁",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		ban:       false,
+	}
+}
+
+// Handle 处理命令
+func (h *ShadowBanHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取目标用户
+	target, err := GetTargetUser(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+	name := html.EscapeString(FormatUsername(target))
+
+	// 2.1. 管理员不能被影子封禁
+	if h.ban && target.IsAdmin(ctx.ChatID) {
+		return ctx.ReplyHTML(fmt.Sprintf("❌ <b>%s</b> 是管理员，不能影子封禁", name))
+	}
+
+	// 3. 更新封禁列表
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	var changed bool
+	if h.ban {
+		changed = g.ShadowBan(target.ID)
+	} else {
+		changed = g.LiftShadowBan(target.ID)
+	}
+	if !changed {
+		if h.ban {
+			return ctx.ReplyHTML(fmt.Sprintf("ℹ️ <b>%s</b> 已处于影子封禁状态", name))
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("ℹ️ <b>%s</b> 未被影子封禁", name))
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存失败，请稍后重试")
+	}
+
+	ctx.RecordAction(handler.ActionResult{Action: h.GetName(), TargetID: target.ID})
+
+	// 4. 成功反馈
+	if h.ban {
+		return ctx.ReplyHTML(fmt.Sprintf("👻 <b>%s</b> 已被影子封禁，其消息将被静默删除", name))
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 已解除 <b>%s</b> 的影子封禁", name))
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowBanHandler_Handle(t *testing.T) {
+	const chatID = int64(-100123)
+
+	newFixtures := func() (*user.User, *group.Group, *MockGroupRepository, *MockUserRepository) {
+		actor := user.NewUser(1, "mod", "Mod", "")
+		actor.SetPermission(chatID, user.PermissionAdmin)
+		g := group.NewGroup(chatID, "Test Group", "supergroup")
+		return actor, g, new(MockGroupRepository), new(MockUserRepository)
+	}
+
+	t.Run("shadowban adds the user", func(t *testing.T) {
+		tb := newTestBot(t)
+		actor, g, groupRepo, userRepo := newFixtures()
+		userRepo.On("FindByUsername", mock.Anything, "troll").Return(user.NewUser(2, "troll", "", ""), nil)
+		groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+		groupRepo.On("Update", mock.Anything, g).Return(nil)
+
+		h := NewShadowBanHandler(groupRepo, userRepo)
+		require.NoError(t, h.Handle(newTestContext(tb, chatID, actor, "/shadowban @troll")))
+
+		assert.True(t, g.IsShadowBanned(2))
+		groupRepo.AssertExpectations(t)
+		assert.Contains(t, tb.Replies()[0], "已被影子封禁")
+	})
+
+	t.Run("admins cannot be shadow-banned", func(t *testing.T) {
+		tb := newTestBot(t)
+		actor, g, groupRepo, userRepo := newFixtures()
+		other := user.NewUser(3, "othermod", "", "")
+		other.SetPermission(chatID, user.PermissionAdmin)
+		userRepo.On("FindByUsername", mock.Anything, "othermod").Return(other, nil)
+
+		h := NewShadowBanHandler(groupRepo, userRepo)
+		require.NoError(t, h.Handle(newTestContext(tb, chatID, actor, "/shadowban @othermod")))
+
+		assert.False(t, g.IsShadowBanned(3))
+		groupRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		assert.Contains(t, tb.Replies()[0], "不能影子封禁")
+	})
+
+	t.Run("unshadowban removes the user", func(t *testing.T) {
+		tb := newTestBot(t)
+		actor, g, groupRepo, userRepo := newFixtures()
+		g.ShadowBan(2)
+		userRepo.On("FindByUsername", mock.Anything, "troll").Return(user.NewUser(2, "troll", "", ""), nil)
+		groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+		groupRepo.On("Update", mock.Anything, g).Return(nil)
+
+		h := NewUnshadowBanHandler(groupRepo, userRepo)
+		require.NoError(t, h.Handle(newTestContext(tb, chatID, actor, "/unshadowban @troll")))
+
+		assert.False(t, g.IsShadowBanned(2))
+		assert.Contains(t, tb.Replies()[0], "已解除")
+	})
+}
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/internal/scheduler"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/timeutil"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	minSilenceDuration = time.Minute
+	maxSilenceDuration = 24 * time.Hour
+)
+
+// ChatPermissionsManager 读取和设置群组默认权限接口（由 telegram.API 实现）
+type ChatPermissionsManager interface {
+	GetChat(ctx context.Context, chatID int64) (*models.ChatFullInfo, error)
+	SetChatPermissions(ctx context.Context, chatID int64, permissions models.ChatPermissions) error
+}
+
+// SilenceRepository 全群禁言记录仓储接口
+type SilenceRepository interface {
+	Save(ctx context.Context, r *restriction.Record) error
+	FindActiveByGroup(ctx context.Context, groupID int64, kind restriction.Kind, now time.Time) ([]*restriction.Record, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// SilenceHandler 全群禁言命令处理器
+// /silence <时长> 禁止所有成员发言，到期后由 SilenceRestoreJob 恢复禁言前的群组权限；
+// 管理员不受群组默认权限限制，仍可发言
+type SilenceHandler struct {
+	*BaseCommand
+	repo  SilenceRepository
+	chats ChatPermissionsManager
+	now   func() time.Time
+}
+
+// NewSilenceHandler 创建全群禁言命令处理器
+func NewSilenceHandler(groupRepo GroupRepository, repo SilenceRepository, chats ChatPermissionsManager) *SilenceHandler {
+	return &SilenceHandler{
+		BaseCommand: NewBaseCommand(
+			"silence",
+			"临时禁止全体成员发言",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		repo:  repo,
+		chats: chats,
+		now:   time.Now,
+	}
+}
+
+// Handle 处理命令
+// 用法：/silence <时长>，如 /silence 10m；禁言期间再次执行只修改到期时间
+func (h *SilenceHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析时长
+	args := ParseArgs(ctx.Text)
+	if len(args) != 1 {
+		return ctx.Reply("❌ 用法: /silence <时长>，如 /silence 10m")
+	}
+	duration, err := timeutil.ParseInterval(args[0])
+	if err != nil || duration < minSilenceDuration || duration > maxSilenceDuration {
+		return ctx.Reply("❌ 禁言时长需在 1 分钟到 24 小时之间，如 10m、1h")
+	}
+
+	// 3. 保存禁言前的权限（已在禁言中时沿用之前保存的权限）
+	// 查询包括已到期但尚未被定时任务恢复的记录，否则会把禁言中的权限当作禁言前的权限保存
+	now := h.now()
+	active, err := h.repo.FindActiveByGroup(reqCtx, ctx.ChatID, restriction.KindSilence, time.Time{})
+	if err != nil {
+		return ctx.Reply("❌ 查询禁言状态失败，请稍后重试")
+	}
+
+	var state string
+	if len(active) > 0 {
+		state = active[0].State
+	} else {
+		chat, err := h.chats.GetChat(reqCtx, ctx.ChatID)
+		if err != nil {
+			return ctx.Reply("❌ 获取群组权限失败，请稍后重试")
+		}
+		state = scheduler.EncodeChatPermissions(chat.Permissions)
+	}
+
+	// 4. 禁言并记录，由定时任务负责到期恢复
+	if err := h.chats.SetChatPermissions(reqCtx, ctx.ChatID, models.ChatPermissions{}); err != nil {
+		return ctx.Reply(permissionsFailureMessage(err))
+	}
+
+	until := now.Add(duration)
+	record := restriction.NewSilence(ctx.ChatID, until, state, ctx.UserID)
+	if err := h.repo.Save(reqCtx, record); err != nil {
+		// 没有记录就不会自动恢复，立即撤销禁言
+		_ = scheduler.RestoreSilence(reqCtx, h.chats, record)
+		return ctx.Reply("❌ 保存禁言记录失败，已撤销禁言")
+	}
+	ctx.RecordAction(handler.ActionResult{Action: "silence", Message: formatInterval(duration)})
+
+	return ctx.ReplyHTML(fmt.Sprintf("🔇 全群禁言 <b>%s</b>\n恢复时间: %s\n提前解除: <code>/unsilence</code>",
+		formatInterval(duration), formatTime(ctx.Group, until, now)))
+}
+
+// UnsilenceHandler 解除全群禁言命令处理器
+type UnsilenceHandler struct {
+	*BaseCommand
+	repo  SilenceRepository
+	chats ChatPermissionsManager
+}
+
+// NewUnsilenceHandler 创建解除全群禁言命令处理器
+func NewUnsilenceHandler(groupRepo GroupRepository, repo SilenceRepository, chats ChatPermissionsManager) *UnsilenceHandler {
+	return &UnsilenceHandler{
+		BaseCommand: NewBaseCommand(
+			"unsilence",
+			"提前解除全群禁言",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		repo:  repo,
+		chats: chats,
+	}
+}
+
+// Handle 处理命令
+func (h *UnsilenceHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 查找禁言记录（包括已到期但尚未被定时任务恢复的记录）
+	active, err := h.repo.FindActiveByGroup(reqCtx, ctx.ChatID, restriction.KindSilence, time.Time{})
+	if err != nil {
+		return ctx.Reply("❌ 查询禁言状态失败，请稍后重试")
+	}
+	if len(active) == 0 {
+		return ctx.Reply("ℹ️ 当前没有全群禁言")
+	}
+
+	// 3. 恢复禁言前的权限并删除记录
+	record := active[0]
+	if err := scheduler.RestoreSilence(reqCtx, h.chats, record); err != nil {
+		return ctx.Reply(permissionsFailureMessage(err))
+	}
+	ctx.RecordAction(handler.ActionResult{Action: "unsilence"})
+	if err := h.repo.Delete(reqCtx, record.ID); err != nil {
+		return ctx.Reply("⚠️ 已恢复发言，但删除禁言记录失败")
+	}
+
+	return ctx.Reply("🔊 已解除全群禁言")
+}
+
+// permissionsFailureMessage 修改群组权限失败时的提示
+func permissionsFailureMessage(err error) string {
+	if errors.HasCode(err, errors.CodeBotPermission) {
+		return handler.BotPermissionMessage
+	}
+	return "❌ 修改群组权限失败，请稍后重试"
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/scheduler"
+	"telegram-bot/pkg/logger"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSilenceRepo 内存中的限制记录仓储（同一群组只保留一条全群禁言）
+type fakeSilenceRepo struct {
+	records map[string]*restriction.Record
+	nextID  int
+}
+
+func (r *fakeSilenceRepo) Save(ctx context.Context, rec *restriction.Record) error {
+	for id, existing := range r.records {
+		if existing.GroupID == rec.GroupID && existing.Kind == rec.Kind {
+			rec.ID = id
+			r.records[id] = rec
+			return nil
+		}
+	}
+	r.nextID++
+	rec.ID = fmt.Sprintf("rec%d", r.nextID)
+	r.records[rec.ID] = rec
+	return nil
+}
+
+func (r *fakeSilenceRepo) FindActiveByGroup(ctx context.Context, groupID int64, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	var active []*restriction.Record
+	for _, rec := range r.records {
+		if rec.GroupID == groupID && rec.Kind == kind && rec.Until.After(now) {
+			active = append(active, rec)
+		}
+	}
+	return active, nil
+}
+
+func (r *fakeSilenceRepo) FindExpired(ctx context.Context, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	var expired []*restriction.Record
+	for _, rec := range r.records {
+		if rec.Kind == kind && rec.IsExpired(now) {
+			expired = append(expired, rec)
+		}
+	}
+	return expired, nil
+}
+
+func (r *fakeSilenceRepo) Delete(ctx context.Context, id string) error {
+	delete(r.records, id)
+	return nil
+}
+
+// fakeChatPermissions 内存中的群组默认权限
+type fakeChatPermissions struct {
+	permissions models.ChatPermissions
+}
+
+func (f *fakeChatPermissions) GetChat(ctx context.Context, chatID int64) (*models.ChatFullInfo, error) {
+	p := f.permissions
+	return &models.ChatFullInfo{ID: chatID, Permissions: &p}, nil
+}
+
+func (f *fakeChatPermissions) SetChatPermissions(ctx context.Context, chatID int64, permissions models.ChatPermissions) error {
+	f.permissions = permissions
+	return nil
+}
+
+func newSilenceTest(t *testing.T) (*testBot, *fakeSilenceRepo, *fakeChatPermissions, *user.User) {
+	tb := newTestBot(t)
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(auditTestChatID, user.PermissionAdmin)
+	repo := &fakeSilenceRepo{records: make(map[string]*restriction.Record)}
+	chats := &fakeChatPermissions{permissions: models.ChatPermissions{CanSendMessages: true, CanSendPolls: true}}
+	return tb, repo, chats, admin
+}
+
+func TestSilenceHandler_SilenceAndUnsilence(t *testing.T) {
+	tb, repo, chats, admin := newSilenceTest(t)
+	prior := chats.permissions
+
+	silence := NewSilenceHandler(nil, repo, chats)
+	require.NoError(t, silence.Handle(newTestContext(tb, auditTestChatID, admin, "/silence 10m")))
+	assert.Equal(t, models.ChatPermissions{}, chats.permissions)
+	require.Len(t, repo.records, 1)
+
+	// 禁言中再次执行只延长时间，仍保留禁言前的权限
+	require.NoError(t, silence.Handle(newTestContext(tb, auditTestChatID, admin, "/silence 1h")))
+	require.Len(t, repo.records, 1)
+
+	unsilence := NewUnsilenceHandler(nil, repo, chats)
+	require.NoError(t, unsilence.Handle(newTestContext(tb, auditTestChatID, admin, "/unsilence")))
+	assert.Equal(t, prior, chats.permissions)
+	assert.Empty(t, repo.records)
+
+	require.NoError(t, unsilence.Handle(newTestContext(tb, auditTestChatID, admin, "/unsilence")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 4)
+	assert.Contains(t, replies[0], "全群禁言 <b>10m</b>")
+	assert.Contains(t, replies[1], "全群禁言 <b>1h</b>")
+	assert.Equal(t, "🔊 已解除全群禁言", replies[2])
+	assert.Equal(t, "ℹ️ 当前没有全群禁言", replies[3])
+}
+
+func TestSilenceHandler_AutoRestore(t *testing.T) {
+	tb, repo, chats, admin := newSilenceTest(t)
+	prior := chats.permissions
+
+	// 禁言在一小时前开始，已经到期
+	silence := NewSilenceHandler(nil, repo, chats)
+	silence.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	require.NoError(t, silence.Handle(newTestContext(tb, auditTestChatID, admin, "/silence 5m")))
+	assert.Equal(t, models.ChatPermissions{}, chats.permissions)
+
+	job := scheduler.NewSilenceRestoreJob(repo, chats, logger.NewWithLevel(logger.LevelError))
+	require.NoError(t, job.Run(context.Background()))
+
+	assert.Equal(t, prior, chats.permissions)
+	assert.Empty(t, repo.records)
+}
+
+func TestSilenceHandler_InvalidDuration(t *testing.T) {
+	tb, repo, chats, admin := newSilenceTest(t)
+
+	h := NewSilenceHandler(nil, repo, chats)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, admin, "/silence 2d")))
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, admin, "/silence")))
+
+	assert.Empty(t, repo.records)
+	assert.True(t, chats.permissions.CanSendMessages)
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+	assert.Contains(t, replies[0], "1 分钟到 24 小时")
+	assert.Contains(t, replies[1], "用法")
+}
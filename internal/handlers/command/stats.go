@@ -1,20 +1,57 @@
 package command
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"time"
 )
 
+// topCommandsLimit /stats 展示的常用命令数
+const topCommandsLimit = 5
+
+// CommandUsageReader 命令使用次数查询接口
+type CommandUsageReader interface {
+	TopCommands(ctx context.Context, groupID int64, n int) ([]*activity.CommandUsage, error)
+}
+
+// GroupStats 群组统计数据
+// /stats 格式化为中文展示，/stats json 直接序列化（字段名是供监控脚本使用的稳定接口）
+type GroupStats struct {
+	GroupID     int64             `json:"group_id"`
+	Title       string            `json:"title"`
+	CreatedAt   time.Time         `json:"created_at"`
+	TopCommands []StatsCommandRow `json:"top_commands"`
+	// TopCommandsError 获取常用命令失败（此时 TopCommands 为空）
+	TopCommandsError bool `json:"top_commands_error,omitempty"`
+}
+
+// StatsCommandRow 命令使用次数
+type StatsCommandRow struct {
+	Command string `json:"command"`
+	Count   int64  `json:"count"`
+}
+
 // StatsHandler Stats 命令处理器
+// /stats 查看群组信息，/stats json 以 JSON 输出，/stats export 导出按天统计
 type StatsHandler struct {
 	*BaseCommand
-	userRepo  UserRepository
-	groupRepo GroupRepository
+	userRepo   UserRepository
+	groupRepo  GroupRepository
+	bucketRepo StatsExportRepository
+	sender     DocumentSender
+	commands   CommandUsageReader
 }
 
 // NewStatsHandler 创建 Stats 命令处理器
-func NewStatsHandler(groupRepo GroupRepository, userRepo UserRepository) *StatsHandler {
+func NewStatsHandler(groupRepo GroupRepository, userRepo UserRepository, bucketRepo StatsExportRepository, sender DocumentSender, commands CommandUsageReader) *StatsHandler {
 	return &StatsHandler{
 		BaseCommand: NewBaseCommand(
 			"stats",
@@ -23,8 +60,11 @@ func NewStatsHandler(groupRepo GroupRepository, userRepo UserRepository) *StatsH
 			[]string{"group", "supergroup"},
 			groupRepo,
 		),
-		userRepo:  userRepo,
-		groupRepo: groupRepo,
+		userRepo:   userRepo,
+		groupRepo:  groupRepo,
+		bucketRepo: bucketRepo,
+		sender:     sender,
+		commands:   commands,
 	}
 }
 
@@ -35,21 +75,76 @@ func (h *StatsHandler) Handle(ctx *handler.Context) error {
 		return err
 	}
 
+	args := ParseArgs(ctx.Text)
+	if len(args) > 0 && args[0] == "export" {
+		return h.handleExport(ctx, args[1:])
+	}
+
 	// 群组信息由 GroupMiddleware 自动注入
 	if ctx.Group == nil {
-		return fmt.Errorf("❌ 无法获取群组信息，请稍后重试")
+		return errors.Internal("", "无法获取群组信息")
+	}
+
+	stats := h.collectStats(ctx)
+	if len(args) > 0 && args[0] == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return ctx.Reply("❌ 生成统计数据失败")
+		}
+		return ctx.ReplyHTML(fmt.Sprintf("<pre><code class=\"language-json\">%s</code></pre>", html.EscapeString(string(data))))
 	}
 
-	// 构建统计信息
+	return ctx.ReplyHTML(formatGroupStats(ctx.Group, stats))
+}
+
+// collectStats 收集群组统计数据
+func (h *StatsHandler) collectStats(ctx *handler.Context) *GroupStats {
+	stats := &GroupStats{
+		GroupID:     ctx.ChatID,
+		Title:       ctx.ChatTitle,
+		CreatedAt:   ctx.Group.CreatedAt,
+		TopCommands: []StatsCommandRow{},
+	}
+
+	top, err := h.commands.TopCommands(ctx.RequestContext(), ctx.ChatID, topCommandsLimit)
+	if err != nil {
+		stats.TopCommandsError = true
+		return stats
+	}
+	for _, u := range top {
+		stats.TopCommands = append(stats.TopCommands, StatsCommandRow{Command: u.Command, Count: u.Count})
+	}
+	return stats
+}
+
+// formatGroupStats 格式化群组统计（中文展示）
+func formatGroupStats(g *group.Group, stats *GroupStats) string {
 	response := fmt.Sprintf(
 		"📊 <b>群组统计</b>\n\n"+
 			"🏷️ 群组名称: <b>%s</b>\n"+
 			"🆔 群组 ID: <code>%d</code>\n"+
 			"📅 创建时间: %s\n",
-		ctx.ChatTitle,
-		ctx.ChatID,
-		ctx.Group.CreatedAt.Format("2006-01-02 15:04:05"),
+		stats.Title,
+		stats.GroupID,
+		formatTime(g, stats.CreatedAt, time.Now()),
 	)
 
-	return ctx.ReplyHTML(response)
+	if stats.TopCommandsError {
+		return response + "\n🔥 常用命令: <i>获取失败</i>\n"
+	}
+	return response + "\n" + formatTopCommands(stats.TopCommands)
+}
+
+// formatTopCommands 格式化常用命令排行
+func formatTopCommands(top []StatsCommandRow) string {
+	if len(top) == 0 {
+		return "🔥 常用命令: <i>暂无</i>\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔥 <b>常用命令</b>\n")
+	for i, u := range top {
+		sb.WriteString(fmt.Sprintf("%d. <code>/%s</code> — %d 次\n", i+1, html.EscapeString(u.Command), u.Count))
+	}
+	return sb.String()
 }
@@ -0,0 +1,188 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+const (
+	defaultExportDays = 30  // 未指定日期范围时导出最近 30 天
+	maxExportDays     = 366 // 单次最多导出的天数
+)
+
+// StatsExportRepository 按天统计查询接口
+type StatsExportRepository interface {
+	FindBuckets(ctx context.Context, groupID int64, from, to string) ([]*activity.Bucket, error)
+}
+
+// DocumentSender 发送文件接口
+type DocumentSender interface {
+	SendDocument(ctx context.Context, chatID int64, filename string, data []byte, caption string) (int, error)
+}
+
+// statsExportRequest 解析后的导出参数
+type statsExportRequest struct {
+	from   time.Time
+	to     time.Time
+	format string // "csv" 或 "json"
+}
+
+// StatsExportRow 导出数据的一行（一天）
+type StatsExportRow struct {
+	Date              string `json:"date"`
+	Messages          int64  `json:"messages"`
+	Commands          int64  `json:"commands"`
+	ModerationActions int64  `json:"moderation_actions"`
+}
+
+// StatsExport 导出文档（JSON 格式）
+type StatsExport struct {
+	GroupID int64            `json:"group_id"`
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Days    []StatsExportRow `json:"days"`
+}
+
+// statsExportCSVHeader CSV 表头
+var statsExportCSVHeader = []string{"date", "group_id", "messages", "commands", "moderation_actions"}
+
+// handleExport 处理 /stats export
+func (h *StatsHandler) handleExport(ctx *handler.Context, args []string) error {
+	req, err := parseStatsExportArgs(args, time.Now())
+	if err != nil {
+		return ctx.ReplyHTML(fmt.Sprintf("❌ %s\n\n%s", err.Error(), statsExportUsage()))
+	}
+
+	reqCtx := ctx.RequestContext()
+	from, to := req.from.Format(activity.DayLayout), req.to.Format(activity.DayLayout)
+
+	buckets, err := h.bucketRepo.FindBuckets(reqCtx, ctx.ChatID, from, to)
+	if err != nil {
+		return ctx.Reply("❌ 查询统计数据失败，请稍后重试")
+	}
+
+	export := buildStatsExport(ctx.ChatID, req.from, req.to, buckets)
+
+	var data []byte
+	if req.format == "json" {
+		data, err = json.MarshalIndent(export, "", "  ")
+	} else {
+		data, err = formatStatsCSV(export)
+	}
+	if err != nil {
+		return ctx.Reply("❌ 生成导出文件失败")
+	}
+
+	filename := fmt.Sprintf("stats_%d_%s_%s.%s", ctx.ChatID, from, to, req.format)
+	caption := fmt.Sprintf("📊 群组统计导出 %s ~ %s", from, to)
+	if _, err := h.sender.SendDocument(reqCtx, ctx.ChatID, filename, data, caption); err != nil {
+		return ctx.Reply("❌ 发送导出文件失败")
+	}
+
+	return nil
+}
+
+// parseStatsExportArgs 解析导出参数
+// 格式: [<开始日期> <结束日期>] [csv|json]，日期格式 YYYY-MM-DD（UTC，包含首尾）
+func parseStatsExportArgs(args []string, now time.Time) (*statsExportRequest, error) {
+	req := &statsExportRequest{format: "csv"}
+
+	// 末尾的格式参数
+	if n := len(args); n > 0 && (args[n-1] == "csv" || args[n-1] == "json") {
+		req.format = args[n-1]
+		args = args[:n-1]
+	}
+
+	switch len(args) {
+	case 0:
+		req.to = now.UTC().Truncate(24 * time.Hour)
+		req.from = req.to.AddDate(0, 0, -(defaultExportDays - 1))
+		return req, nil
+	case 2:
+	default:
+		return nil, fmt.Errorf("参数错误")
+	}
+
+	var err error
+	if req.from, err = time.Parse(activity.DayLayout, args[0]); err != nil {
+		return nil, fmt.Errorf("无效的开始日期: %s", args[0])
+	}
+	if req.to, err = time.Parse(activity.DayLayout, args[1]); err != nil {
+		return nil, fmt.Errorf("无效的结束日期: %s", args[1])
+	}
+	if req.to.Before(req.from) {
+		return nil, fmt.Errorf("结束日期不能早于开始日期")
+	}
+	if days := int(req.to.Sub(req.from).Hours()/24) + 1; days > maxExportDays {
+		return nil, fmt.Errorf("日期范围不能超过 %d 天", maxExportDays)
+	}
+
+	return req, nil
+}
+
+// buildStatsExport 按日期生成导出数据，没有统计的日期补 0
+func buildStatsExport(groupID int64, from, to time.Time, buckets []*activity.Bucket) *StatsExport {
+	byDay := make(map[string]*activity.Bucket, len(buckets))
+	for _, b := range buckets {
+		byDay[b.Day] = b
+	}
+
+	export := &StatsExport{
+		GroupID: groupID,
+		From:    from.Format(activity.DayLayout),
+		To:      to.Format(activity.DayLayout),
+		Days:    []StatsExportRow{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		row := StatsExportRow{Date: day.Format(activity.DayLayout)}
+		if b, ok := byDay[row.Date]; ok {
+			row.Messages = b.Messages
+			row.Commands = b.Commands
+			row.ModerationActions = b.ModerationActions
+		}
+		export.Days = append(export.Days, row)
+	}
+
+	return export
+}
+
+// formatStatsCSV 生成 CSV 文档
+func formatStatsCSV(export *StatsExport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(statsExportCSVHeader); err != nil {
+		return nil, err
+	}
+
+	groupID := strconv.FormatInt(export.GroupID, 10)
+	for _, row := range export.Days {
+		record := []string{
+			row.Date,
+			groupID,
+			strconv.FormatInt(row.Messages, 10),
+			strconv.FormatInt(row.Commands, 10),
+			strconv.FormatInt(row.ModerationActions, 10),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// statsExportUsage 导出命令用法
+func statsExportUsage() string {
+	return "<b>用法:</b>\n" +
+		"<code>/stats export</code> - 导出最近 30 天（CSV）\n" +
+		"<code>/stats export 2025-01-01 2025-01-31 [csv|json]</code> - 导出指定日期范围"
+}
@@ -0,0 +1,174 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(activity.DayLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseStatsExportArgs(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	t.Run("defaults to last 30 days as csv", func(t *testing.T) {
+		req, err := parseStatsExportArgs(nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, day("2025-02-09"), req.from)
+		assert.Equal(t, day("2025-03-10"), req.to)
+		assert.Equal(t, "csv", req.format)
+	})
+
+	t.Run("date range with format", func(t *testing.T) {
+		req, err := parseStatsExportArgs([]string{"2025-01-01", "2025-01-31", "json"}, now)
+		require.NoError(t, err)
+		assert.Equal(t, day("2025-01-01"), req.from)
+		assert.Equal(t, day("2025-01-31"), req.to)
+		assert.Equal(t, "json", req.format)
+	})
+
+	t.Run("format only", func(t *testing.T) {
+		req, err := parseStatsExportArgs([]string{"json"}, now)
+		require.NoError(t, err)
+		assert.Equal(t, "json", req.format)
+	})
+
+	for name, args := range map[string][]string{
+		"single date":      {"2025-01-01"},
+		"invalid from":     {"2025-13-01", "2025-01-31"},
+		"invalid to":       {"2025-01-01", "tomorrow"},
+		"reversed range":   {"2025-02-01", "2025-01-01"},
+		"range too long":   {"2024-01-01", "2025-01-31"},
+		"unknown argument": {"2025-01-01", "2025-01-31", "xml"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseStatsExportArgs(args, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBuildStatsExport_FillsMissingDays(t *testing.T) {
+	buckets := []*activity.Bucket{
+		{GroupID: -100, Day: "2025-01-01", Messages: 120, Commands: 8, ModerationActions: 1},
+		{GroupID: -100, Day: "2025-01-03", Messages: 45, Commands: 2},
+	}
+
+	export := buildStatsExport(-100, day("2025-01-01"), day("2025-01-03"), buckets)
+
+	assert.Equal(t, "2025-01-01", export.From)
+	assert.Equal(t, "2025-01-03", export.To)
+	assert.Equal(t, []StatsExportRow{
+		{Date: "2025-01-01", Messages: 120, Commands: 8, ModerationActions: 1},
+		{Date: "2025-01-02"},
+		{Date: "2025-01-03", Messages: 45, Commands: 2},
+	}, export.Days)
+}
+
+func TestFormatStatsCSV(t *testing.T) {
+	buckets := []*activity.Bucket{
+		{GroupID: -100, Day: "2025-01-01", Messages: 120, Commands: 8, ModerationActions: 1},
+		{GroupID: -100, Day: "2025-01-03", Messages: 45, Commands: 2},
+	}
+	export := buildStatsExport(-100, day("2025-01-01"), day("2025-01-03"), buckets)
+
+	data, err := formatStatsCSV(export)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"date,group_id,messages,commands,moderation_actions\n"+
+			"2025-01-01,-100,120,8,1\n"+
+			"2025-01-02,-100,0,0,0\n"+
+			"2025-01-03,-100,45,2,0\n",
+		string(data))
+}
+
+func TestStatsExport_JSONSchema(t *testing.T) {
+	export := buildStatsExport(-100, day("2025-01-01"), day("2025-01-01"), []*activity.Bucket{
+		{GroupID: -100, Day: "2025-01-01", Messages: 3, Commands: 1},
+	})
+
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"group_id": -100,
+		"from": "2025-01-01",
+		"to": "2025-01-01",
+		"days": [{"date": "2025-01-01", "messages": 3, "commands": 1, "moderation_actions": 0}]
+	}`, string(data))
+}
+
+func TestFormatTopCommands(t *testing.T) {
+	assert.Contains(t, formatTopCommands(nil), "暂无")
+
+	text := formatTopCommands([]StatsCommandRow{
+		{Command: "ping", Count: 12},
+		{Command: "rules", Count: 3},
+	})
+	assert.Contains(t, text, "1. <code>/ping</code> — 12 次")
+	assert.Contains(t, text, "2. <code>/rules</code> — 3 次")
+}
+
+// fakeCommandUsage 返回固定的常用命令
+type fakeCommandUsage struct {
+	top []*activity.CommandUsage
+	err error
+}
+
+func (f *fakeCommandUsage) TopCommands(ctx context.Context, groupID int64, n int) ([]*activity.CommandUsage, error) {
+	return f.top, f.err
+}
+
+func TestStatsHandler_JSON(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.CreatedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	commands := &fakeCommandUsage{top: []*activity.CommandUsage{{Command: "ping", Count: 12}}}
+	h := NewStatsHandler(nil, nil, nil, nil, commands)
+
+	for _, text := range []string{"/stats json", "/stats"} {
+		ctx := newTestContext(tb, chatID, admin, text)
+		ctx.ChatTitle = "Test <Group>"
+		ctx.Group = g
+		require.NoError(t, h.Handle(ctx))
+	}
+
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+
+	body := strings.TrimPrefix(replies[0], `<pre><code class="language-json">`)
+	body = html.UnescapeString(strings.TrimSuffix(body, "</code></pre>"))
+	assert.JSONEq(t, `{
+		"group_id": -100123,
+		"title": "Test <Group>",
+		"created_at": "2025-01-02T03:04:05Z",
+		"top_commands": [{"command": "ping", "count": 12}]
+	}`, body)
+
+	// 默认输出保持中文展示
+	assert.Contains(t, replies[1], "📊 <b>群组统计</b>")
+	assert.Contains(t, replies[1], "1. <code>/ping</code> — 12 次")
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/handler"
+)
+
+// SubcommandFunc 子命令处理函数，args 为子命令名之后的参数（默认子命令为全部参数）
+type SubcommandFunc func(ctx *handler.Context, args []string) error
+
+// Subcommand 子命令
+type Subcommand struct {
+	Name        string // 子命令名，为空表示默认子命令（第一个参数不是子命令名时执行）
+	Args        string // 参数说明，如 "<ID>"，用于生成用法
+	Description string
+	Handle      SubcommandFunc
+}
+
+// Subcommands 子命令表
+// 命令声明子命令后，按第一个参数分发，用法说明由子命令表生成，不再单独维护
+type Subcommands struct {
+	command string
+	title   string
+	note    string
+	list    []Subcommand
+}
+
+// NewSubcommands 创建子命令表，title 为用法说明的标题（HTML）
+func NewSubcommands(command, title string, subcommands ...Subcommand) *Subcommands {
+	return &Subcommands{
+		command: command,
+		title:   title,
+		list:    subcommands,
+	}
+}
+
+// WithNote 设置用法说明末尾的补充说明（HTML）
+func (s *Subcommands) WithNote(note string) *Subcommands {
+	s.note = note
+	return s
+}
+
+// Dispatch 按第一个参数执行子命令（不区分大小写）
+// 没有参数、或第一个参数不是子命令名且没有默认子命令时返回携带用法说明的 handler.ErrUsage
+func (s *Subcommands) Dispatch(ctx *handler.Context, args []string) error {
+	if len(args) == 0 {
+		return handler.Usage(s.Help())
+	}
+
+	name := strings.ToLower(args[0])
+	for _, sub := range s.list {
+		if sub.Name != "" && sub.Name == name {
+			return sub.Handle(ctx, args[1:])
+		}
+	}
+	for _, sub := range s.list {
+		if sub.Name == "" {
+			return sub.Handle(ctx, args)
+		}
+	}
+	return handler.Usage(s.Help())
+}
+
+// Usage 返回子命令的用法，如 <code>/schedule delete &lt;ID&gt;</code>
+func (s *Subcommands) Usage(name string) string {
+	for _, sub := range s.list {
+		if sub.Name == name {
+			return s.usage(sub)
+		}
+	}
+	return ""
+}
+
+// Help 返回所有子命令的用法说明
+func (s *Subcommands) Help() string {
+	var sb strings.Builder
+	sb.WriteString(s.title)
+	sb.WriteString("\n\n")
+	for _, sub := range s.list {
+		sb.WriteString(fmt.Sprintf("%s - %s\n", s.usage(sub), sub.Description))
+	}
+	if s.note != "" {
+		sb.WriteString("\n")
+		sb.WriteString(s.note)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// usage 格式化子命令的用法
+func (s *Subcommands) usage(sub Subcommand) string {
+	parts := []string{"/" + s.command}
+	if sub.Name != "" {
+		parts = append(parts, sub.Name)
+	}
+	if sub.Args != "" {
+		parts = append(parts, sub.Args)
+	}
+	return "<code>" + html.EscapeString(strings.Join(parts, " ")) + "</code>"
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubcommands(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	var called string
+	var gotArgs []string
+	record := func(name string) SubcommandFunc {
+		return func(ctx *handler.Context, args []string) error {
+			called, gotArgs = name, args
+			return nil
+		}
+	}
+
+	subs := NewSubcommands("schedule", "📅 <b>定时消息</b>",
+		Subcommand{Args: "<间隔> <内容>", Description: "创建定时消息", Handle: record("create")},
+		Subcommand{Name: "list", Description: "查看本群定时消息", Handle: record("list")},
+		Subcommand{Name: "delete", Args: "<ID>", Description: "删除定时消息", Handle: record("delete")},
+	).WithNote("<i>间隔格式: 30m、2h、1d</i>")
+
+	// 生成的用法说明包含所有子命令
+	assert.Equal(t, "📅 <b>定时消息</b>\n\n"+
+		"<code>/schedule &lt;间隔&gt; &lt;内容&gt;</code> - 创建定时消息\n"+
+		"<code>/schedule list</code> - 查看本群定时消息\n"+
+		"<code>/schedule delete &lt;ID&gt;</code> - 删除定时消息\n\n"+
+		"<i>间隔格式: 30m、2h、1d</i>", subs.Help())
+	assert.Equal(t, "<code>/schedule delete &lt;ID&gt;</code>", subs.Usage("delete"))
+
+	// 按子命令名分发（不区分大小写）
+	require.NoError(t, subs.Dispatch(newTestContext(tb, chatID, u, "/schedule DELETE abc"), []string{"DELETE", "abc"}))
+	assert.Equal(t, "delete", called)
+	assert.Equal(t, []string{"abc"}, gotArgs)
+
+	// 其他参数交给默认子命令
+	require.NoError(t, subs.Dispatch(newTestContext(tb, chatID, u, "/schedule 1h hi"), []string{"1h", "hi"}))
+	assert.Equal(t, "create", called)
+	assert.Equal(t, []string{"1h", "hi"}, gotArgs)
+
+	// 没有参数时返回用法说明，由 Router 回复
+	called = ""
+	err := subs.Dispatch(newTestContext(tb, chatID, u, "/schedule"), nil)
+	assert.Empty(t, called)
+	assert.ErrorIs(t, err, handler.ErrUsage)
+	help, ok := handler.UsageHelp(err)
+	require.True(t, ok)
+	assert.Equal(t, subs.Help(), help)
+	assert.Empty(t, tb.Replies())
+}
+
+func TestSubcommands_NoDefault(t *testing.T) {
+	tb := newTestBot(t)
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	subs := NewSubcommands("reasons", "📝 <b>原因模板</b>",
+		Subcommand{Name: "del", Args: "<名称>", Description: "删除模板", Handle: func(ctx *handler.Context, args []string) error {
+			t.Fatal("unexpected dispatch")
+			return nil
+		}},
+	)
+
+	help, ok := handler.UsageHelp(subs.Dispatch(newTestContext(tb, -100123, u, "/reasons foo"), []string{"foo"}))
+	require.True(t, ok)
+	assert.Contains(t, help, "<code>/reasons del &lt;名称&gt;</code> - 删除模板")
+	assert.Empty(t, tb.Replies())
+}
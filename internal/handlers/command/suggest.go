@@ -0,0 +1,109 @@
+package command
+
+import (
+	"fmt"
+	"html"
+	"telegram-bot/internal/handler"
+)
+
+// SuggestHandler 未知命令提示
+// 命令类处理器都没有匹配的命令消息会走到这里：按编辑距离找出最接近的已注册命令，
+// 足够接近时回复"你是不是想输入 /xxx"；差距太大时不回复，避免对其他机器人的命令产生噪音
+type SuggestHandler struct {
+	router *handler.Router // 用于获取已注册的命令
+}
+
+// NewSuggestHandler 创建未知命令提示处理器
+func NewSuggestHandler(router *handler.Router) *SuggestHandler {
+	return &SuggestHandler{router: router}
+}
+
+// Match 未被任何命令处理器处理的命令消息，且存在足够接近的命令
+func (h *SuggestHandler) Match(ctx *handler.Context) bool {
+	if ctx.IsEdit {
+		return false
+	}
+	cmdName, ok := handler.ParseCommand(ctx.Text)
+	if !ok {
+		return false
+	}
+	_, ok = h.suggest(cmdName, ctx.ChatType)
+	return ok
+}
+
+// Handle 回复建议的命令
+func (h *SuggestHandler) Handle(ctx *handler.Context) error {
+	cmdName, _ := handler.ParseCommand(ctx.Text)
+	suggestion, ok := h.suggest(cmdName, ctx.ChatType)
+	if !ok {
+		return nil
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("❓ 未知命令 <code>/%s</code>，你是不是想输入 <code>/%s</code>？",
+		html.EscapeString(cmdName), suggestion))
+}
+
+// Priority 在命令（100）之后、关键词（200）之前；
+// 命令匹配后不再继续链，因此只有未匹配的命令会走到这里
+func (h *SuggestHandler) Priority() int {
+	return 150
+}
+
+// ContinueChain 继续执行后续处理器（消息记录、计数等监听器）
+func (h *SuggestHandler) ContinueChain() bool {
+	return true
+}
+
+// suggest 返回当前聊天类型中与 cmdName 最接近的命令
+// 命令名完全相同（例如命令已在本群禁用）时不提示
+func (h *SuggestHandler) suggest(cmdName, chatType string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, cmd := range h.router.Commands() {
+		if !cmd.AllowsChatType(chatType) {
+			continue
+		}
+		name := cmd.Name
+		if name == cmdName {
+			return "", false
+		}
+		if d := levenshtein(cmdName, name); bestDistance < 0 || d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+
+	if bestDistance < 0 || bestDistance > suggestThreshold(cmdName) {
+		return "", false
+	}
+	return best, true
+}
+
+// suggestThreshold 允许的最大编辑距离：3 个字符以内的命令只容忍 1 处差异
+// （相邻字符颠倒如 /hepl 的编辑距离为 2，因此 4 个字符起容忍 2 处差异）
+func suggestThreshold(cmdName string) int {
+	if len([]rune(cmdName)) <= 3 {
+		return 1
+	}
+	return 2
+}
+
+// levenshtein 计算两个字符串的编辑距离（插入、删除、替换各计 1）
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
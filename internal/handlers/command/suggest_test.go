@@ -0,0 +1,81 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"ban", "ban", 0},
+		{"bam", "ban", 1},
+		{"tempbna", "tempban", 2},
+		{"", "ping", 4},
+		{"kitten", "sitting", 3},
+		{"帮助", "帮忙", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, levenshtein(tt.a, tt.b))
+			assert.Equal(t, tt.expected, levenshtein(tt.b, tt.a))
+		})
+	}
+}
+
+func TestSuggestHandler_Threshold(t *testing.T) {
+	router := handler.NewRouter()
+	router.Register(NewPingHandler(nil))
+	router.Register(NewHelpHandler(nil, router))
+	router.Register(NewTempBanHandler(nil, nil, nil, nil, nil, nil))
+	h := NewSuggestHandler(router)
+
+	tests := []struct {
+		input    string
+		chatType string
+		expected string // 空表示不提示
+	}{
+		{"pi", "supergroup", ""},             // 短命令只容忍 1 处差异
+		{"pig", "supergroup", "ping"},        // 1 处差异
+		{"pign", "supergroup", "ping"},       // 相邻字符颠倒
+		{"tempbna", "supergroup", "tempban"}, // 2 处差异
+		{"tempxyz", "supergroup", ""},        // 3 处差异
+		{"tempbam", "private", ""},           // 私聊中不提示群组专用命令
+		{"ping", "supergroup", ""},           // 完全相同（如命令已禁用）不提示
+		{"start", "supergroup", ""},          // 与所有命令都差距很大
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := h.suggest(tt.input, tt.chatType)
+			assert.Equal(t, tt.expected != "", ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSuggestHandler_RepliesToUnmatchedCommand(t *testing.T) {
+	tb := newTestBot(t)
+	router := handler.NewRouter()
+	router.Register(NewHelpHandler(nil, router))
+	router.Register(NewSuggestHandler(router))
+	u := user.NewUser(1, "alice", "Alice", "")
+
+	// 已匹配的命令不提示
+	require.NoError(t, router.Route(newPrivateTestContext(tb, u, "/help")))
+	require.NoError(t, router.Route(newPrivateTestContext(tb, u, "/hepl")))
+	require.NoError(t, router.Route(newPrivateTestContext(tb, u, "hepl")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+	assert.Contains(t, replies[1], "你是不是想输入 <code>/help</code>")
+}
@@ -0,0 +1,249 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// AdminLister 查询群组 Telegram 管理员列表接口（由 telegram.API 实现）
+type AdminLister interface {
+	GetChatAdministrators(ctx context.Context, chatID int64) ([]*group.Member, error)
+}
+
+// adminSyncChange 一条需要应用的权限调整
+type adminSyncChange struct {
+	UserID  int64
+	User    *user.User    // 数据库中的用户，不存在时为 nil（需要新建）
+	Member  *group.Member // Telegram 成员信息，降级时为 nil
+	OldPerm user.Permission
+	NewPerm user.Permission
+}
+
+// adminSyncPlan 权限同步计划
+type adminSyncPlan struct {
+	Promote []adminSyncChange // Telegram 管理员，在本机器人中低于 Admin
+	Demote  []adminSyncChange // 群组级 Admin，但已不是 Telegram 管理员
+	Kept    []*user.User      // 不是 Telegram 管理员，但权限受保护不自动降级（仅报告）
+}
+
+// Empty 是否没有任何差异
+func (p *adminSyncPlan) Empty() bool {
+	return len(p.Promote) == 0 && len(p.Demote) == 0 && len(p.Kept) == 0
+}
+
+// planAdminSync 对比 Telegram 管理员列表和已保存的权限，生成同步计划
+// known 包含已保存的管理员以及能在数据库中找到的 Telegram 管理员。
+// 只在 Admin 这一级自动调整：Telegram 管理员至少为 Admin，
+// 在本群被单独设为 Admin 但已不是 Telegram 管理员的用户降为 User；
+// SuperAdmin/Owner、来自全局权限的管理员和 BOT_OWNER_IDS 中的用户从不降级，只报告差异
+func planAdminSync(chatID int64, tgAdmins []*group.Member, known map[int64]*user.User, ownerIDs []int64) *adminSyncPlan {
+	plan := &adminSyncPlan{}
+	isTGAdmin := make(map[int64]bool, len(tgAdmins))
+
+	for _, m := range tgAdmins {
+		if m.IsBot || !m.IsAdmin() {
+			continue
+		}
+		isTGAdmin[m.UserID] = true
+
+		u := known[m.UserID]
+		oldPerm := user.PermissionUser
+		if u != nil {
+			oldPerm = u.GetPermission(chatID)
+		}
+		if oldPerm >= user.PermissionAdmin {
+			continue
+		}
+		plan.Promote = append(plan.Promote, adminSyncChange{
+			UserID:  m.UserID,
+			User:    u,
+			Member:  m,
+			OldPerm: oldPerm,
+			NewPerm: user.PermissionAdmin,
+		})
+	}
+
+	for id, u := range known {
+		if isTGAdmin[id] || !u.IsAdmin(chatID) {
+			continue
+		}
+		if u.Permissions[chatID] == user.PermissionAdmin && u.GetPermission(chatID) == user.PermissionAdmin && !isOwnerID(ownerIDs, id) {
+			plan.Demote = append(plan.Demote, adminSyncChange{
+				UserID:  id,
+				User:    u,
+				OldPerm: user.PermissionAdmin,
+				NewPerm: user.PermissionUser,
+			})
+			continue
+		}
+		plan.Kept = append(plan.Kept, u)
+	}
+
+	sort.Slice(plan.Promote, func(i, j int) bool { return plan.Promote[i].UserID < plan.Promote[j].UserID })
+	sort.Slice(plan.Demote, func(i, j int) bool { return plan.Demote[i].UserID < plan.Demote[j].UserID })
+	sort.Slice(plan.Kept, func(i, j int) bool { return plan.Kept[i].ID < plan.Kept[j].ID })
+	return plan
+}
+
+// SyncAdminsHandler 同步 Telegram 管理员权限命令处理器
+type SyncAdminsHandler struct {
+	*BaseCommand
+	userRepo UserRepository
+	auditLog PermissionAuditLog
+	admins   AdminLister
+	ownerIDs []int64
+}
+
+// NewSyncAdminsHandler 创建同步 Telegram 管理员权限命令处理器
+func NewSyncAdminsHandler(groupRepo GroupRepository, userRepo UserRepository, auditLog PermissionAuditLog, admins AdminLister, ownerIDs []int64) *SyncAdminsHandler {
+	return &SyncAdminsHandler{
+		BaseCommand: NewBaseCommand(
+			"syncadmins",
+			"按 Telegram 群管理员同步管理员权限",
+			user.PermissionSuperAdmin, // 需要 SuperAdmin 权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		userRepo: userRepo,
+		auditLog: auditLog,
+		admins:   admins,
+		ownerIDs: ownerIDs,
+	}
+}
+
+// Handle 处理命令
+// 用法：/syncadmins [preview]，preview 只报告差异不修改权限
+func (h *SyncAdminsHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析参数
+	args := ParseArgs(ctx.Text)
+	preview := false
+	if len(args) > 0 {
+		if len(args) > 1 || strings.ToLower(args[0]) != "preview" {
+			return ctx.Reply("❌ 用法: /syncadmins [preview]")
+		}
+		preview = true
+	}
+
+	// 3. 查询 Telegram 管理员和已保存的管理员
+	tgAdmins, err := h.admins.GetChatAdministrators(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取 Telegram 管理员列表失败，请确认机器人在本群中")
+	}
+
+	stored, err := h.userRepo.FindAdminsByGroup(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 查询管理员列表失败，请稍后重试")
+	}
+
+	known := make(map[int64]*user.User, len(stored)+len(tgAdmins))
+	for _, u := range stored {
+		known[u.ID] = u
+	}
+	for _, m := range tgAdmins {
+		if m.IsBot || known[m.UserID] != nil {
+			continue
+		}
+		u, err := h.userRepo.FindByID(reqCtx, m.UserID)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				continue
+			}
+			return ctx.Reply("❌ 查询用户失败，请稍后重试")
+		}
+		known[u.ID] = u
+	}
+
+	// 4. 生成并应用同步计划
+	plan := planAdminSync(ctx.ChatID, tgAdmins, known, h.ownerIDs)
+	if plan.Empty() {
+		return ctx.Reply("✅ 管理员权限与 Telegram 群管理员一致")
+	}
+
+	failed := 0
+	auditNote := ""
+	if !preview {
+		changes := make([]adminSyncChange, 0, len(plan.Promote)+len(plan.Demote))
+		changes = append(append(changes, plan.Promote...), plan.Demote...)
+		for _, c := range changes {
+			target, err := h.applyChange(reqCtx, ctx.ChatID, c)
+			if err != nil {
+				failed++
+				continue
+			}
+			if note := recordPermissionChange(reqCtx, h.auditLog, ctx, target, c.OldPerm, c.NewPerm); note != "" {
+				auditNote = note
+			}
+		}
+	}
+
+	return ctx.ReplyHTML(formatAdminSync(plan, ctx.ChatID, preview, failed) + auditNote)
+}
+
+// applyChange 应用一条权限调整，返回调整后的用户
+func (h *SyncAdminsHandler) applyChange(reqCtx context.Context, chatID int64, c adminSyncChange) (*user.User, error) {
+	if c.User == nil {
+		u := user.NewUser(c.UserID, c.Member.Username, c.Member.FirstName, c.Member.LastName)
+		u.SetPermission(chatID, c.NewPerm)
+		return u, h.userRepo.Save(reqCtx, u)
+	}
+
+	if err := h.userRepo.UpdatePermission(reqCtx, c.UserID, chatID, c.NewPerm); err != nil {
+		return nil, err
+	}
+	c.User.SetPermission(chatID, c.NewPerm)
+	return c.User, nil
+}
+
+// formatAdminSync 格式化同步结果
+func formatAdminSync(plan *adminSyncPlan, chatID int64, preview bool, failed int) string {
+	var sb strings.Builder
+	if preview {
+		sb.WriteString("🔍 <b>管理员权限差异（预览，未修改）</b>\n")
+	} else {
+		sb.WriteString("🔄 <b>管理员权限已同步</b>\n")
+	}
+
+	if len(plan.Promote) > 0 {
+		sb.WriteString(fmt.Sprintf("\n⬆️ <b>提升为 Admin</b> (%d人):\n", len(plan.Promote)))
+		for _, c := range plan.Promote {
+			name := fmt.Sprintf("ID %d", c.UserID)
+			if c.Member != nil && (c.Member.Username != "" || c.Member.FirstName != "") {
+				name = FormatUsername(user.NewUser(c.UserID, c.Member.Username, c.Member.FirstName, c.Member.LastName))
+			}
+			sb.WriteString(fmt.Sprintf("  • %s（%s → %s）\n", html.EscapeString(name), c.OldPerm.String(), c.NewPerm.String()))
+		}
+	}
+
+	if len(plan.Demote) > 0 {
+		sb.WriteString(fmt.Sprintf("\n⬇️ <b>降为 User</b> (%d人):\n", len(plan.Demote)))
+		for _, c := range plan.Demote {
+			sb.WriteString(fmt.Sprintf("  • %s\n", html.EscapeString(FormatUsername(c.User))))
+		}
+	}
+
+	if len(plan.Kept) > 0 {
+		sb.WriteString(fmt.Sprintf("\n🔒 <b>不是 Telegram 管理员，权限受保护未调整</b> (%d人):\n", len(plan.Kept)))
+		for _, u := range plan.Kept {
+			sb.WriteString(fmt.Sprintf("  • %s（%s）\n", html.EscapeString(FormatUsername(u)), u.GetPermission(chatID).String()))
+		}
+	}
+
+	if failed > 0 {
+		sb.WriteString(fmt.Sprintf("\n⚠️ %d 项权限更新失败，请稍后重试", failed))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdminLister 返回固定的 Telegram 管理员列表
+type fakeAdminLister []*group.Member
+
+func (f fakeAdminLister) GetChatAdministrators(ctx context.Context, chatID int64) ([]*group.Member, error) {
+	return f, nil
+}
+
+func tgAdmin(id int64, username string, status group.MemberStatus) *group.Member {
+	return &group.Member{UserID: id, Username: username, Status: status, IsMember: true}
+}
+
+func TestPlanAdminSync(t *testing.T) {
+	chatID := int64(-100123)
+
+	withPerm := func(id int64, name string, groupID int64, perm user.Permission) *user.User {
+		u := user.NewUser(id, name, "", "")
+		u.SetPermission(groupID, perm)
+		return u
+	}
+
+	known := map[int64]*user.User{
+		1: withPerm(1, "still_admin", chatID, user.PermissionAdmin),
+		2: withPerm(2, "stale_admin", chatID, user.PermissionAdmin),
+		3: withPerm(3, "superadmin", chatID, user.PermissionSuperAdmin),
+		4: withPerm(4, "global_admin", 0, user.PermissionAdmin),
+		5: withPerm(5, "bot_owner", chatID, user.PermissionAdmin),
+		6: withPerm(6, "plain_member", chatID, user.PermissionUser),
+	}
+	tgAdmins := []*group.Member{
+		tgAdmin(1, "still_admin", group.MemberStatusAdministrator),
+		tgAdmin(6, "plain_member", group.MemberStatusCreator),
+		tgAdmin(7, "new_admin", group.MemberStatusAdministrator),
+		{UserID: 8, Username: "some_bot", Status: group.MemberStatusAdministrator, IsBot: true},
+	}
+
+	plan := planAdminSync(chatID, tgAdmins, known, []int64{5})
+
+	// Telegram 管理员提升为 Admin，数据库中不存在的用户需要新建，机器人忽略
+	require.Len(t, plan.Promote, 2)
+	assert.Equal(t, int64(6), plan.Promote[0].UserID)
+	assert.Equal(t, user.PermissionUser, plan.Promote[0].OldPerm)
+	assert.Equal(t, user.PermissionAdmin, plan.Promote[0].NewPerm)
+	assert.Equal(t, int64(7), plan.Promote[1].UserID)
+	assert.Nil(t, plan.Promote[1].User)
+
+	// 只有群组级 Admin 会被降级
+	require.Len(t, plan.Demote, 1)
+	assert.Equal(t, int64(2), plan.Demote[0].UserID)
+	assert.Equal(t, user.PermissionUser, plan.Demote[0].NewPerm)
+
+	// SuperAdmin、全局 Admin 和 BOT_OWNER_IDS 中的用户只报告
+	var kept []int64
+	for _, u := range plan.Kept {
+		kept = append(kept, u.ID)
+	}
+	assert.Equal(t, []int64{3, 4, 5}, kept)
+}
+
+func TestSyncAdminsHandler_Apply(t *testing.T) {
+	tb := newTestBot(t)
+	actor, _ := newAuditTestUsers()
+
+	stale := user.NewUser(2, "stale_admin", "Stale", "")
+	stale.SetPermission(auditTestChatID, user.PermissionAdmin)
+	member := user.NewUser(3, "member", "Member", "")
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindAdminsByGroup", mock.Anything, auditTestChatID).Return([]*user.User{actor, stale}, nil)
+	userRepo.On("FindByID", mock.Anything, int64(3)).Return(member, nil)
+	userRepo.On("FindByID", mock.Anything, int64(4)).Return(nil, user.ErrUserNotFound)
+	userRepo.On("UpdatePermission", mock.Anything, int64(3), auditTestChatID, user.PermissionAdmin).Return(nil)
+	userRepo.On("UpdatePermission", mock.Anything, int64(2), auditTestChatID, user.PermissionUser).Return(nil)
+	userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u *user.User) bool {
+		return u.ID == 4 && u.Username == "newcomer" && u.GetPermission(auditTestChatID) == user.PermissionAdmin
+	})).Return(nil)
+	auditLog := &fakeAuditLog{}
+
+	admins := fakeAdminLister{
+		tgAdmin(1, "boss", group.MemberStatusCreator),
+		tgAdmin(3, "member", group.MemberStatusAdministrator),
+		tgAdmin(4, "newcomer", group.MemberStatusAdministrator),
+	}
+	h := NewSyncAdminsHandler(nil, userRepo, auditLog, admins, nil)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/syncadmins")))
+
+	userRepo.AssertExpectations(t)
+	require.Len(t, auditLog.changes, 3)
+	assert.Equal(t, user.PermissionUser, auditLog.changes[2].NewPerm)
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "管理员权限已同步")
+	assert.Contains(t, replies[0], "@newcomer")
+	assert.Contains(t, replies[0], "@stale_admin")
+}
+
+func TestSyncAdminsHandler_Preview(t *testing.T) {
+	tb := newTestBot(t)
+	actor, _ := newAuditTestUsers()
+
+	stale := user.NewUser(2, "stale_admin", "Stale", "")
+	stale.SetPermission(auditTestChatID, user.PermissionAdmin)
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindAdminsByGroup", mock.Anything, auditTestChatID).Return([]*user.User{actor, stale}, nil)
+	auditLog := &fakeAuditLog{}
+
+	admins := fakeAdminLister{tgAdmin(1, "boss", group.MemberStatusCreator)}
+	h := NewSyncAdminsHandler(nil, userRepo, auditLog, admins, nil)
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, actor, "/syncadmins preview")))
+
+	// 预览不修改权限
+	userRepo.AssertNotCalled(t, "UpdatePermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Empty(t, auditLog.changes)
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "预览")
+	assert.Contains(t, replies[0], "@stale_admin")
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+)
+
+// ResolveTarget 解析命令的目标用户，返回目标用户 ID 和目标之后的剩余参数
+// 支持三种方式（按优先级）：
+//   - 第一个参数为 @username：从数据库按用户名查找
+//   - 第一个参数为数字：直接作为用户 ID
+//   - 回复消息：使用被回复消息的发送者，所有参数都是剩余参数
+//
+// 没有回复消息时，不带 @ 的第一个参数也按用户名处理。
+// 返回的错误带有错误码（USER_NOT_FOUND / INTERNAL_ERROR / VALIDATION_ERROR），
+// 消息部分可直接展示给用户（见 errorText）
+func ResolveTarget(reqCtx context.Context, ctx *handler.Context, userRepo UserRepository) (int64, []string, error) {
+	userID, _, rest, err := resolveTarget(reqCtx, ctx, userRepo)
+	return userID, rest, err
+}
+
+// GetTargetUser 从参数或回复消息中获取目标用户（见 ResolveTarget）
+// 目标用户必须已保存在数据库中
+func GetTargetUser(reqCtx context.Context, ctx *handler.Context, userRepo UserRepository) (*user.User, error) {
+	u, _, err := resolveTargetUser(reqCtx, ctx, userRepo)
+	return u, err
+}
+
+// resolveTargetUser 获取目标用户和目标之后的剩余参数
+func resolveTargetUser(reqCtx context.Context, ctx *handler.Context, userRepo UserRepository) (*user.User, []string, error) {
+	userID, found, rest, err := resolveTarget(reqCtx, ctx, userRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if found != nil {
+		return found, rest, nil
+	}
+
+	u, err := userRepo.FindByID(reqCtx, userID)
+	if err != nil {
+		if err == user.ErrUserNotFound {
+			if ctx.ReplyTo != nil && ctx.ReplyTo.UserID == userID {
+				return nil, nil, errors.NotFound(errors.CodeUserNotFound, "回复的用户不存在或未使用过此机器人")
+			}
+			return nil, nil, errors.NotFound(errors.CodeUserNotFound,
+				fmt.Sprintf("用户 ID %d 不存在或未使用过此机器人", userID))
+		}
+		return nil, nil, errors.WrapWithCode(err, errors.CodeInternal, "查询用户失败，请稍后重试")
+	}
+	return u, rest, nil
+}
+
+// resolveTarget 解析目标用户
+// 按用户名解析时同时返回查到的用户，避免调用方重复查询；其他方式返回 nil
+func resolveTarget(reqCtx context.Context, ctx *handler.Context, userRepo UserRepository) (int64, *user.User, []string, error) {
+	args := ParseArgs(ctx.Text)
+
+	if len(args) > 0 {
+		arg := args[0]
+		switch {
+		case strings.HasPrefix(arg, "@"), ctx.ReplyTo == nil && !isUserID(arg):
+			username := strings.TrimPrefix(arg, "@")
+			u, err := userRepo.FindByUsername(reqCtx, username)
+			if err != nil {
+				// 包装数据库错误，避免暴露内部细节
+				if err == user.ErrUserNotFound {
+					return 0, nil, nil, errors.NotFound(errors.CodeUserNotFound,
+						fmt.Sprintf("用户 @%s 不存在或未使用过此机器人", username))
+				}
+				return 0, nil, nil, errors.WrapWithCode(err, errors.CodeInternal, "查询用户失败，请稍后重试")
+			}
+			return u.ID, u, args[1:], nil
+		case isUserID(arg):
+			id, _ := strconv.ParseInt(arg, 10, 64)
+			return id, nil, args[1:], nil
+		}
+	}
+
+	if ctx.ReplyTo != nil {
+		return ctx.ReplyTo.UserID, nil, args, nil
+	}
+
+	return 0, nil, nil, errors.Validation("", "未指定目标用户，请使用 @username、用户 ID 或回复用户消息")
+}
+
+// isUserID 参数是否为用户 ID（正整数）
+func isUserID(s string) bool {
+	id, err := strconv.ParseInt(s, 10, 64)
+	return err == nil && id > 0
+}
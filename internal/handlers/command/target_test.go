@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTarget(t *testing.T) {
+	reqCtx := context.TODO()
+	reply := &handler.ReplyInfo{UserID: 789, Username: "replied"}
+
+	t.Run("username", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "alice").Return(user.NewUser(42, "alice", "", ""), nil).Once()
+
+		// @username 优先于回复消息
+		id, rest, err := ResolveTarget(reqCtx, &handler.Context{Text: "/tempban @alice 1h flood", ReplyTo: reply}, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), id)
+		assert.Equal(t, []string{"1h", "flood"}, rest)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("bare username without reply", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "alice").Return(user.NewUser(42, "alice", "", ""), nil).Once()
+
+		id, rest, err := ResolveTarget(reqCtx, &handler.Context{Text: "/promote alice"}, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), id)
+		assert.Empty(t, rest)
+	})
+
+	t.Run("numeric ID", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+
+		id, rest, err := ResolveTarget(reqCtx, &handler.Context{Text: "/setperm 123456 admin"}, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, int64(123456), id)
+		assert.Equal(t, []string{"admin"}, rest)
+		userRepo.AssertNotCalled(t, "FindByUsername")
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+
+		// 回复消息时不带 @ 的参数都是剩余参数
+		id, rest, err := ResolveTarget(reqCtx, &handler.Context{Text: "/setperm admin", ReplyTo: reply}, userRepo)
+		require.NoError(t, err)
+		assert.Equal(t, int64(789), id)
+		assert.Equal(t, []string{"admin"}, rest)
+	})
+
+	t.Run("unknown username", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByUsername", reqCtx, "ghost").Return(nil, user.ErrUserNotFound).Once()
+
+		_, _, err := ResolveTarget(reqCtx, &handler.Context{Text: "/tempban @ghost 1h"}, userRepo)
+		assert.True(t, errors.HasCode(err, errors.CodeUserNotFound))
+		assert.Contains(t, errorText(err), "@ghost")
+	})
+
+	t.Run("no target", func(t *testing.T) {
+		_, _, err := ResolveTarget(reqCtx, &handler.Context{Text: "/tempban"}, new(MockUserRepository))
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+	})
+}
+
+func TestGetTargetUser_UnknownID(t *testing.T) {
+	reqCtx := context.TODO()
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByID", reqCtx, int64(555)).Return(nil, user.ErrUserNotFound).Once()
+
+	_, err := GetTargetUser(reqCtx, &handler.Context{Text: "/promote 555"}, userRepo)
+	assert.True(t, errors.HasCode(err, errors.CodeUserNotFound))
+	assert.Equal(t, "用户 ID 555 不存在或未使用过此机器人", errorText(err))
+}
@@ -0,0 +1,259 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/timeutil"
+	"time"
+)
+
+const (
+	minTempBanDuration = time.Minute
+	maxTempBanDuration = 366 * 24 * time.Hour // Telegram 对超过 366 天的封禁视为永久
+)
+
+// MemberBanner 封禁群组成员接口
+type MemberBanner interface {
+	BanChatMemberWithDuration(ctx context.Context, chatID, userID int64, until time.Time) error
+}
+
+// RestrictionRepository 限制记录仓储接口
+type RestrictionRepository interface {
+	Save(ctx context.Context, r *restriction.Record) error
+	FindActiveByGroup(ctx context.Context, groupID int64, kind restriction.Kind, now time.Time) ([]*restriction.Record, error)
+}
+
+// ActivityRecorder 统计记录接口（用于按天统计管理操作）
+type ActivityRecorder interface {
+	IncrementMetric(groupID int64, metric activity.Metric)
+}
+
+// TempBanHandler 临时封禁命令处理器
+// 封禁记录持久化到数据库，到期后由 TempBanReconcileJob 解封
+type TempBanHandler struct {
+	*BaseCommand
+	userRepo        UserRepository
+	restrictionRepo RestrictionRepository
+	banner          MemberBanner
+	recorder        ActivityRecorder
+	members         MemberLookup
+	now             func() time.Time
+}
+
+// NewTempBanHandler 创建临时封禁命令处理器
+// members 用于显示数据库中没有名字的被封禁用户（可为 nil）
+func NewTempBanHandler(groupRepo GroupRepository, userRepo UserRepository, restrictionRepo RestrictionRepository, banner MemberBanner, recorder ActivityRecorder, members MemberLookup) *TempBanHandler {
+	return &TempBanHandler{
+		BaseCommand: NewBaseCommand(
+			"tempban",
+			"临时封禁用户",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		userRepo:        userRepo,
+		restrictionRepo: restrictionRepo,
+		banner:          banner,
+		recorder:        recorder,
+		members:         members,
+		now:             time.Now,
+	}
+}
+
+// tempBanRequest 解析后的临时封禁参数（目标用户之后的部分）
+type tempBanRequest struct {
+	duration time.Duration
+	reason   string
+}
+
+// Handle 处理命令
+func (h *TempBanHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析参数
+	if args := ParseArgs(ctx.Text); len(args) == 1 && args[0] == "list" {
+		return h.handleList(reqCtx, ctx)
+	}
+
+	userID, found, rest, err := resolveTarget(reqCtx, ctx, h.userRepo)
+	if err != nil {
+		if errors.HasCode(err, errors.CodeValidation) {
+			return ctx.ReplyHTML(fmt.Sprintf("❌ %s\n\n%s", errorText(err), tempBanUsage()))
+		}
+		return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+	}
+
+	req, err := parseTempBanArgs(ctx.Text, len(ParseArgs(ctx.Text))-len(rest))
+	if err != nil {
+		return ctx.ReplyHTML(fmt.Sprintf("❌ %s\n\n%s", errorText(err), tempBanUsage()))
+	}
+	req.reason = expandReason(ctx.Group, req.reason)
+
+	// 3. 获取目标用户
+	target := found
+	if target == nil {
+		if target, err = h.loadTarget(reqCtx, ctx, userID); err != nil {
+			return ctx.Reply(fmt.Sprintf("❌ %s", errorText(err)))
+		}
+	}
+
+	// 3.1. 不能封禁自己，也不能封禁权限不低于自己的用户
+	if target.ID == ctx.UserID {
+		return ctx.Reply("❌ 不能封禁自己")
+	}
+	if target.GetPermission(ctx.ChatID) >= ctx.User.GetPermission(ctx.ChatID) {
+		return ctx.ReplyHTML(fmt.Sprintf("❌ 无法封禁 <b>%s</b>：对方权限不低于您",
+			html.EscapeString(FormatUsername(target))))
+	}
+
+	// 4. 执行封禁（同时设置 Telegram 的到期时间，作为双保险）
+	until := time.Now().Add(req.duration)
+	if err := h.banner.BanChatMemberWithDuration(reqCtx, ctx.ChatID, target.ID, until); err != nil {
+		return ctx.Reply(banFailureMessage(err))
+	}
+	h.recorder.IncrementMetric(ctx.ChatID, activity.MetricModeration)
+	action := handler.ActionResult{Action: "tempban", TargetID: target.ID, Message: formatInterval(req.duration)}
+	if req.reason != "" {
+		action.Message += ": " + req.reason
+	}
+	ctx.RecordAction(action)
+
+	// 5. 记录封禁，由定时任务负责到期解封
+	record := restriction.NewTempBan(ctx.ChatID, target.ID, until, req.reason, ctx.UserID)
+	if err := h.restrictionRepo.Save(reqCtx, record); err != nil {
+		return ctx.Reply("⚠️ 用户已封禁，但保存封禁记录失败，可能需要手动解封")
+	}
+
+	// 6. 成功反馈
+	text := fmt.Sprintf("🚫 用户 <b>%s</b> 已被临时封禁 <b>%s</b>\n解封时间: %s",
+		html.EscapeString(FormatUsername(target)),
+		formatInterval(req.duration),
+		formatTime(ctx.Group, until, h.now()))
+	if req.reason != "" {
+		text += fmt.Sprintf("\n原因: %s", html.EscapeString(req.reason))
+	}
+	return ctx.ReplyHTML(text)
+}
+
+// handleList 列出群组内尚未到期的临时封禁
+func (h *TempBanHandler) handleList(reqCtx context.Context, ctx *handler.Context) error {
+	now := h.now()
+	records, err := h.restrictionRepo.FindActiveByGroup(reqCtx, ctx.ChatID, restriction.KindBan, now)
+	if err != nil {
+		return ctx.Reply("❌ 查询封禁记录失败，请稍后重试")
+	}
+
+	var sb strings.Builder
+	count := 0
+	for _, r := range records {
+		if r.IsExpired(now) {
+			continue // 已到期但尚未被定时任务解封
+		}
+		count++
+
+		sb.WriteString(fmt.Sprintf("\n• <b>%s</b> — 剩余 %s",
+			html.EscapeString(lookupDisplayName(reqCtx, h.userRepo, h.members, ctx.ChatID, r.UserID)),
+			formatRemaining(r.Until.Sub(now))))
+		if r.Reason != "" {
+			sb.WriteString(fmt.Sprintf("\n  原因: %s", html.EscapeString(r.Reason)))
+		}
+	}
+
+	if count == 0 {
+		return ctx.Reply("✅ 当前没有被临时封禁的用户")
+	}
+	return ctx.ReplyHTML(fmt.Sprintf("🚫 <b>临时封禁中的用户</b>（%d）\n%s", count, sb.String()))
+}
+
+// formatRemaining 格式化剩余时间（向上取整到分钟，最多显示两个单位）
+// 例如 "2d 3h"、"5h 10m"、"1m"
+func formatRemaining(d time.Duration) string {
+	minutes := int64((d + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	days, hours, mins := minutes/(24*60), minutes/60%24, minutes%60
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && mins > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+// loadTarget 按用户 ID 获取目标用户
+// 目标用户可能从未使用过机器人，此时使用回复消息中的信息（或只有用户 ID）
+func (h *TempBanHandler) loadTarget(reqCtx context.Context, ctx *handler.Context, userID int64) (*user.User, error) {
+	u, err := h.userRepo.FindByID(reqCtx, userID)
+	if err != nil {
+		if err != user.ErrUserNotFound {
+			return nil, errors.WrapWithCode(err, errors.CodeInternal, "查询用户失败，请稍后重试")
+		}
+		username := ""
+		if ctx.ReplyTo != nil && ctx.ReplyTo.UserID == userID {
+			username = ctx.ReplyTo.Username
+		}
+		u = user.NewUser(userID, username, "", "")
+	}
+	return u, nil
+}
+
+// parseTempBanArgs 解析目标用户之后的临时封禁参数，targetWords 为目标用户占用的参数个数（见 ResolveTarget）
+// "/tempban @user 1h 刷屏" 或回复消息 "/tempban 1h 刷屏"
+func parseTempBanArgs(text string, targetWords int) (*tempBanRequest, error) {
+	args := ParseArgs(text)[targetWords:]
+	req := &tempBanRequest{}
+
+	if len(args) == 0 {
+		return nil, errors.Validation("", "未指定封禁时长")
+	}
+
+	duration, err := timeutil.ParseInterval(args[0])
+	if err != nil {
+		return nil, errors.Validation("", fmt.Sprintf("无效的封禁时长: %s", args[0]))
+	}
+	if duration < minTempBanDuration || duration > maxTempBanDuration {
+		return nil, errors.Validation("", "封禁时长需在 1 分钟到 366 天之间")
+	}
+	req.duration = duration
+
+	// 跳过命令本身、目标用户和时长
+	req.reason = trimLeadingWords(text, 1+targetWords+1)
+	return req, nil
+}
+
+// tempBanUsage 返回命令用法说明
+func tempBanUsage() string {
+	return "用法: <code>/tempban @username|用户ID &lt;时长&gt; [原因]</code>\n" +
+		"或回复消息: <code>/tempban &lt;时长&gt; [原因]</code>\n" +
+		"查看封禁中的用户: <code>/tempban list</code>\n" +
+		"<i>时长格式: 30m、2h、7d；原因可用 #名称 引用 /reasons 中的模板</i>"
+}
+
+// banFailureMessage 封禁失败时的提示
+// 机器人缺少管理员权限时给出明确说明，其他错误保持通用提示
+func banFailureMessage(err error) string {
+	if errors.HasCode(err, errors.CodeBotPermission) {
+		return handler.BotPermissionMessage
+	}
+	return "❌ 封禁失败，请稍后重试"
+}
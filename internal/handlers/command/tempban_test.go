@@ -0,0 +1,245 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTempBanArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		targetWords int
+		expected    *tempBanRequest
+		wantErr     string
+	}{
+		{
+			name:        "username with reason",
+			text:        "/tempban @spammer 2h 发广告 刷屏",
+			targetWords: 1,
+			expected:    &tempBanRequest{duration: 2 * time.Hour, reason: "发广告 刷屏"},
+		},
+		{
+			name:     "reply without reason",
+			text:     "/tempban 1d",
+			expected: &tempBanRequest{duration: 24 * time.Hour},
+		},
+		{
+			name:     "reply with reason",
+			text:     "/tempban 30m flood",
+			expected: &tempBanRequest{duration: 30 * time.Minute, reason: "flood"},
+		},
+		{
+			name:        "missing duration",
+			text:        "/tempban @spammer",
+			targetWords: 1,
+			wantErr:     "未指定封禁时长",
+		},
+		{
+			name:        "invalid duration",
+			text:        "/tempban @spammer soon",
+			targetWords: 1,
+			wantErr:     "无效的封禁时长",
+		},
+		{
+			name:        "duration too short",
+			text:        "/tempban 123456 10s",
+			targetWords: 1,
+			wantErr:     "封禁时长需在",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := parseTempBanArgs(tt.text, tt.targetWords)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.True(t, errors.HasCode(err, errors.CodeValidation))
+				assert.Contains(t, errorText(err), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, req)
+		})
+	}
+}
+
+func TestTempBanHandler_LoadTarget(t *testing.T) {
+	reqCtx := context.TODO()
+
+	t.Run("reply to unknown user falls back to reply info", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByID", reqCtx, int64(789)).Return(nil, user.ErrUserNotFound).Once()
+
+		h := NewTempBanHandler(new(MockGroupRepository), userRepo, nil, nil, nil, nil)
+		ctx := &handler.Context{ReplyTo: &handler.ReplyInfo{UserID: 789, Username: "newbie"}}
+
+		target, err := h.loadTarget(reqCtx, ctx, 789)
+		require.NoError(t, err)
+		assert.Equal(t, int64(789), target.ID)
+		assert.Equal(t, "newbie", target.Username)
+		assert.Equal(t, user.PermissionUser, target.GetPermission(-100))
+	})
+
+	t.Run("unknown user ID is banned by ID only", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByID", reqCtx, int64(555)).Return(nil, user.ErrUserNotFound).Once()
+
+		h := NewTempBanHandler(new(MockGroupRepository), userRepo, nil, nil, nil, nil)
+
+		target, err := h.loadTarget(reqCtx, &handler.Context{}, 555)
+		require.NoError(t, err)
+		assert.Equal(t, int64(555), target.ID)
+		assert.Empty(t, target.Username)
+	})
+}
+
+func TestBanFailureMessage(t *testing.T) {
+	rightsErr := errors.WrapWithCode(assert.AnError, errors.CodeBotPermission, "bot lacks admin rights")
+	assert.Equal(t, handler.BotPermissionMessage, banFailureMessage(rightsErr))
+
+	assert.Equal(t, "❌ 封禁失败，请稍后重试", banFailureMessage(assert.AnError))
+}
+
+// fakeRestrictionRepo 返回预设的封禁记录
+type fakeRestrictionRepo struct {
+	records []*restriction.Record
+}
+
+func (f *fakeRestrictionRepo) Save(ctx context.Context, r *restriction.Record) error {
+	f.records = append(f.records, r)
+	return nil
+}
+
+func (f *fakeRestrictionRepo) FindActiveByGroup(ctx context.Context, groupID int64, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	var records []*restriction.Record
+	for _, r := range f.records {
+		if r.GroupID == groupID && r.Kind == kind {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func TestTempBanHandler_List(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	// 返回的记录中包含一条已到期但尚未解封的记录，应被排除
+	repo := &fakeRestrictionRepo{records: []*restriction.Record{
+		restriction.NewTempBan(chatID, 10, now.Add(2*time.Hour+30*time.Minute), "刷屏", 1),
+		restriction.NewTempBan(chatID, 11, now.Add(3*24*time.Hour+5*time.Hour), "", 1),
+		restriction.NewTempBan(chatID, 12, now.Add(-time.Minute), "已到期", 1),
+	}}
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByID", mock.Anything, int64(10)).Return(user.NewUser(10, "spammer", "", ""), nil)
+	userRepo.On("FindByID", mock.Anything, int64(11)).Return(nil, user.ErrUserNotFound)
+
+	h := NewTempBanHandler(nil, userRepo, repo, nil, nil, nil)
+	h.now = func() time.Time { return now }
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/tempban list")))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 1)
+	assert.Contains(t, replies[0], "（2）")
+	assert.Contains(t, replies[0], "<b>@spammer</b> — 剩余 2h 30m")
+	assert.Contains(t, replies[0], "原因: 刷屏")
+	assert.Contains(t, replies[0], "<b>ID 11</b> — 剩余 3d 5h")
+	assert.NotContains(t, replies[0], "已到期")
+}
+
+func TestTempBanHandler_ListEmpty(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	h := NewTempBanHandler(nil, new(MockUserRepository), &fakeRestrictionRepo{}, nil, nil, nil)
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/tempban list")))
+
+	assert.Equal(t, []string{"✅ 当前没有被临时封禁的用户"}, tb.Replies())
+}
+
+// fakeBanner 记录封禁调用
+type fakeBanner struct {
+	banned []int64
+}
+
+func (f *fakeBanner) BanChatMemberWithDuration(ctx context.Context, chatID, userID int64, until time.Time) error {
+	f.banned = append(f.banned, userID)
+	return nil
+}
+
+// nopRecorder 忽略统计
+type nopRecorder struct{}
+
+func (nopRecorder) IncrementMetric(groupID int64, metric activity.Metric) {}
+
+func TestTempBanHandler_ReasonTemplate(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+	spammer := user.NewUser(10, "spammer", "", "")
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.SetReasonTemplate("spam", "发送广告，永久封禁")
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "spammer").Return(spammer, nil)
+
+	repo := &fakeRestrictionRepo{}
+	banner := &fakeBanner{}
+	h := NewTempBanHandler(nil, userRepo, repo, banner, nopRecorder{}, nil)
+
+	var actions []handler.ActionResult
+	for _, text := range []string{
+		"/tempban @spammer 1d #spam",
+		"/tempban @spammer 1d #SPAM 第二次",
+		"/tempban @spammer 1d #unknown",
+	} {
+		ctx := newTestContext(tb, chatID, admin, text)
+		ctx.Group = g
+		require.NoError(t, h.Handle(ctx))
+		actions = append(actions, ctx.Actions()...)
+	}
+
+	require.Len(t, repo.records, 3)
+	assert.Equal(t, "发送广告，永久封禁", repo.records[0].Reason)
+	assert.Equal(t, "发送广告，永久封禁 第二次", repo.records[1].Reason)
+	// 不存在的模板原样保留
+	assert.Equal(t, "#unknown", repo.records[2].Reason)
+	assert.Equal(t, []int64{10, 10, 10}, banner.banned)
+	assert.Contains(t, tb.Replies()[0], "原因: 发送广告，永久封禁")
+	require.Len(t, actions, 3)
+	assert.Equal(t, handler.ActionResult{Action: "tempban", TargetID: 10, Message: "1d: 发送广告，永久封禁"}, actions[0])
+}
+
+func TestFormatRemaining(t *testing.T) {
+	assert.Equal(t, "1m", formatRemaining(10*time.Second))
+	assert.Equal(t, "45m", formatRemaining(45*time.Minute))
+	assert.Equal(t, "2h", formatRemaining(2*time.Hour))
+	assert.Equal(t, "2h 1m", formatRemaining(2*time.Hour+30*time.Second))
+	assert.Equal(t, "1d", formatRemaining(24*time.Hour))
+	assert.Equal(t, "1d 1h", formatRemaining(25*time.Hour+10*time.Minute))
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/go-telegram/bot"
+	"github.com/stretchr/testify/require"
+)
+
+// botCall 测试 Bot 收到的一次 API 调用
+type botCall struct {
+	Method string
+	Params map[string]string
+}
+
+// testBot 指向本地假服务器的 Bot，记录所有 API 调用
+type testBot struct {
+	*bot.Bot
+	mu     sync.Mutex
+	calls  []botCall
+	nextID int // 发送类方法返回的消息 ID（从 1 递增）
+}
+
+// newTestBot 创建测试 Bot
+// 发送类方法返回一条消息（消息 ID 依次递增），其他方法返回 true
+func newTestBot(t *testing.T) *testBot {
+	tb := &testBot{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		params := make(map[string]string)
+		if r.MultipartForm != nil {
+			for k, v := range r.MultipartForm.Value {
+				params[k] = v[0]
+			}
+		}
+
+		method := path.Base(r.URL.Path)
+		tb.mu.Lock()
+		tb.calls = append(tb.calls, botCall{Method: method, Params: params})
+		tb.nextID++
+		messageID := tb.nextID
+		tb.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch method {
+		case "sendMessage", "sendDocument":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":%d}}`, messageID)
+		default:
+			_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	require.NoError(t, err)
+	tb.Bot = b
+	return tb
+}
+
+// Calls 返回指定方法的调用记录
+func (tb *testBot) Calls(method string) []botCall {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	var calls []botCall
+	for _, c := range tb.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Replies 返回所有 sendMessage 的文本
+func (tb *testBot) Replies() []string {
+	var texts []string
+	for _, c := range tb.Calls("sendMessage") {
+		texts = append(texts, c.Params["text"])
+	}
+	return texts
+}
+
+// newTestContext 创建群组消息上下文
+func newTestContext(tb *testBot, chatID int64, u *user.User, text string) *handler.Context {
+	return &handler.Context{
+		Ctx:       context.Background(),
+		Bot:       tb.Bot,
+		ChatType:  "supergroup",
+		ChatID:    chatID,
+		UserID:    u.ID,
+		Username:  u.Username,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		User:      u,
+		Text:      text,
+		MessageID: 10,
+	}
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/pkg/timeutil"
+	"time"
+)
+
+// timeLayout 面向用户的时间格式
+const timeLayout = "2006-01-02 15:04"
+
+// 时间显示方式（群组配置 group.SettingTimeFormat）
+const (
+	TimeFormatAbsolute = "absolute" // 绝对时间，如 2025-01-02 15:04（默认）
+	TimeFormatRelative = "relative" // 相对时间，如 3小时前、2天后
+)
+
+// groupLocation 群组时区，无法获取群组时为 UTC
+func groupLocation(g *group.Group) *time.Location {
+	if g == nil {
+		return time.UTC
+	}
+	return g.Location()
+}
+
+// groupTimeFormat 群组的时间显示方式，未设置或无法获取群组时为绝对时间
+func groupTimeFormat(g *group.Group) string {
+	if g == nil {
+		return TimeFormatAbsolute
+	}
+	if value, ok := g.GetStringSetting(group.SettingTimeFormat); ok && value == TimeFormatRelative {
+		return TimeFormatRelative
+	}
+	return TimeFormatAbsolute
+}
+
+// formatTime 按群组配置格式化时间：相对模式下相对于 now 显示，否则按群组时区显示绝对时间
+func formatTime(g *group.Group, t, now time.Time) string {
+	if groupTimeFormat(g) == TimeFormatRelative {
+		return formatRelativeTime(t, now)
+	}
+	return formatAbsoluteTime(g, t)
+}
+
+// formatAbsoluteTime 按群组时区格式化绝对时间（未设置时区时为 UTC）
+func formatAbsoluteTime(g *group.Group, t time.Time) string {
+	return t.In(groupLocation(g)).Format(timeLayout)
+}
+
+// formatRelativeTime 相对于 now 格式化时间，如 刚刚、5分钟前、3小时后、2天前
+func formatRelativeTime(t, now time.Time) string {
+	return timeutil.Humanize(now.Sub(t))
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTime_GroupTimezone(t *testing.T) {
+	instant := time.Date(2025, 1, 1, 23, 30, 0, 0, time.UTC)
+
+	shanghai := group.NewGroup(-100, "Shanghai", "supergroup")
+	shanghai.SetSetting(group.SettingTimezone, "Asia/Shanghai")
+	newYork := group.NewGroup(-200, "New York", "supergroup")
+	newYork.SetSetting(group.SettingTimezone, "America/New_York")
+
+	assert.Equal(t, "2025-01-02 07:30", formatAbsoluteTime(shanghai, instant))
+	assert.Equal(t, "2025-01-01 18:30", formatAbsoluteTime(newYork, instant))
+
+	// 未设置时区或无法获取群组时使用 UTC
+	assert.Equal(t, "2025-01-01 23:30", formatAbsoluteTime(group.NewGroup(-300, "Default", "supergroup"), instant))
+	assert.Equal(t, "2025-01-01 23:30", formatAbsoluteTime(nil, instant))
+}
+
+func TestFormatTime_Modes(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	g := group.NewGroup(-100, "Test", "supergroup")
+	g.SetSetting(group.SettingTimezone, "Asia/Shanghai")
+
+	// 默认绝对时间（按群组时区）
+	assert.Equal(t, "2025-01-10 17:00", formatTime(g, now.Add(-3*time.Hour), now))
+
+	g.SetSetting(group.SettingTimeFormat, TimeFormatRelative)
+	assert.Equal(t, "3小时前", formatTime(g, now.Add(-3*time.Hour), now))
+	assert.Equal(t, "2天后", formatTime(g, now.Add(50*time.Hour), now))
+	assert.Equal(t, "刚刚", formatTime(g, now.Add(-30*time.Second), now))
+	assert.Equal(t, "5分钟前", formatTime(g, now.Add(-5*time.Minute), now))
+
+	// 无效值按绝对时间处理
+	g.SetSetting(group.SettingTimeFormat, "fancy")
+	assert.Equal(t, "2025-01-10 17:00", formatTime(g, now.Add(-3*time.Hour), now))
+}
+
+func TestTimeFormatHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil).Twice()
+
+	h := NewTimeFormatHandler(groupRepo)
+	h.now = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/timeformat relative")))
+	assert.Equal(t, TimeFormatRelative, groupTimeFormat(g))
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/timeformat bogus")))
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/timeformat absolute")))
+	assert.Equal(t, TimeFormatAbsolute, groupTimeFormat(g))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 3)
+	assert.Contains(t, replies[0], "1小时前")
+	assert.Contains(t, replies[1], "用法")
+	assert.Contains(t, replies[2], "2025-01-01 11:00")
+	groupRepo.AssertExpectations(t)
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+)
+
+// TimeFormatHandler 设置时间显示方式命令处理器
+// /timeformat absolute 显示绝对时间（按群组时区），/timeformat relative 显示相对时间（如 3小时前）
+type TimeFormatHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+	now       func() time.Time
+}
+
+// NewTimeFormatHandler 创建设置时间显示方式命令处理器
+func NewTimeFormatHandler(groupRepo GroupRepository) *TimeFormatHandler {
+	return &TimeFormatHandler{
+		BaseCommand: NewBaseCommand(
+			"timeformat",
+			"设置本群时间显示方式（绝对/相对）",
+			user.PermissionAdmin, // 需要管理员权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+		now:       time.Now,
+	}
+}
+
+// Handle 处理命令
+func (h *TimeFormatHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 无参数时显示当前设置
+	args := ParseArgs(ctx.Text)
+	if len(args) == 0 {
+		return ctx.ReplyHTML(fmt.Sprintf("🕒 本群时间显示方式: <b>%s</b>\n用法: <code>/timeformat absolute|relative</code>",
+			timeFormatName(groupTimeFormat(g))))
+	}
+
+	// 4. 更新设置
+	switch mode := strings.ToLower(args[0]); mode {
+	case TimeFormatAbsolute:
+		g.DeleteSetting(group.SettingTimeFormat)
+	case TimeFormatRelative:
+		g.SetSetting(group.SettingTimeFormat, TimeFormatRelative)
+	default:
+		return ctx.Reply("❌ 用法: /timeformat absolute|relative")
+	}
+
+	if err := h.groupRepo.Update(reqCtx, g); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	// 以一小时前为例展示效果
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 本群时间显示方式已设为 <b>%s</b>\n示例: %s",
+		timeFormatName(groupTimeFormat(g)), formatTime(g, h.now().Add(-time.Hour), h.now())))
+}
+
+// timeFormatName 时间显示方式的显示名称
+func timeFormatName(mode string) string {
+	if mode == TimeFormatRelative {
+		return "相对时间"
+	}
+	return "绝对时间"
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+const (
+	FeatureAnonymousAdmins = "anonymous_admins" // 匿名管理员视为管理员（与 middleware/permission.go 保持一致）
+)
+
+// ToggleAnonHandler 切换匿名管理员权限命令处理器
+type ToggleAnonHandler struct {
+	*BaseCommand
+	groupRepo GroupRepository
+}
+
+// NewToggleAnonHandler 创建切换匿名管理员权限命令处理器
+func NewToggleAnonHandler(groupRepo GroupRepository) *ToggleAnonHandler {
+	return &ToggleAnonHandler{
+		BaseCommand: NewBaseCommand(
+			"toggleanon",
+			"开启/关闭匿名管理员的管理权限",
+			user.PermissionAdmin, // 需要 Admin 及以上权限
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		groupRepo: groupRepo,
+	}
+}
+
+// Handle 处理命令
+func (h *ToggleAnonHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 获取群组
+	group, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return ctx.Reply("❌ 获取群组信息失败，请稍后重试")
+	}
+
+	// 3. 切换状态
+	enabled := !group.IsFeatureEnabled(FeatureAnonymousAdmins)
+	statusText := "已关闭"
+	if enabled {
+		group.EnableFeature(FeatureAnonymousAdmins)
+		statusText = "已开启"
+	} else {
+		group.DisableFeature(FeatureAnonymousAdmins)
+	}
+
+	// 4. 保存到数据库
+	if err := h.groupRepo.Update(reqCtx, group); err != nil {
+		return ctx.Reply("❌ 保存设置失败，请稍后重试")
+	}
+
+	// 5. 返回结果
+	return ctx.ReplyHTML(fmt.Sprintf("✅ 匿名管理员权限%s\n\n"+
+		"<i>当前状态：%s</i>\n"+
+		"<i>提示：以群组身份发言的匿名管理员将%s被视为 Admin，可以使用管理命令。</i>",
+		statusText,
+		getStatusEmoji(enabled),
+		getActionText(enabled)))
+}
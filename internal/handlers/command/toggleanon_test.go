@@ -0,0 +1,40 @@
+package command
+
+import (
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleAnonHandler_Handle(t *testing.T) {
+	tb := newTestBot(t)
+	chatID := int64(-100123)
+
+	admin := user.NewUser(1, "admin", "Admin", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	groupRepo := new(MockGroupRepositoryWithUpdate)
+	groupRepo.On("FindByID", mock.Anything, chatID).Return(g, nil)
+	groupRepo.On("Update", mock.Anything, g).Return(nil)
+
+	h := NewToggleAnonHandler(groupRepo)
+
+	// 默认开启，第一次切换为关闭
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/toggleanon")))
+	assert.False(t, g.IsFeatureEnabled(FeatureAnonymousAdmins))
+
+	require.NoError(t, h.Handle(newTestContext(tb, chatID, admin, "/toggleanon")))
+	assert.True(t, g.IsFeatureEnabled(FeatureAnonymousAdmins))
+
+	replies := tb.Replies()
+	require.Len(t, replies, 2)
+	assert.Contains(t, replies[0], "已关闭")
+	assert.Contains(t, replies[1], "已开启")
+	groupRepo.AssertExpectations(t)
+}
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"fmt"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
@@ -33,7 +32,7 @@ func NewToggleCalcHandler(groupRepo GroupRepository, userRepo UserRepository) *T
 
 // Handle 处理命令
 func (h *ToggleCalcHandler) Handle(ctx *handler.Context) error {
-	reqCtx := context.TODO()
+	reqCtx := ctx.RequestContext()
 
 	// 1. 检查权限
 	if err := h.CheckPermission(ctx); err != nil {
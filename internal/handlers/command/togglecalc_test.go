@@ -65,14 +65,15 @@ func TestToggleCalcHandler_Match(t *testing.T) {
 			expected: true,
 		},
 		{
-			name: "does not match in private chat",
+			// 私聊由 Router 通过 AllowedChatTypes 拦截
+			name: "matches in private chat",
 			ctx: &handler.Context{
 				Text:     "/togglecalc",
 				ChatType: "private",
 				ChatID:   123456,
 			},
 			setupMock: func() {},
-			expected:  false,
+			expected:  true,
 		},
 		{
 			name: "does not match different command",
@@ -93,6 +94,8 @@ func TestToggleCalcHandler_Match(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
+
+	assert.False(t, handler.AllowsChatType(h, "private"))
 }
 
 // TestToggleCalcHandler_Handle is skipped because it requires a real Telegram Bot
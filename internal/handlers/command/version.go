@@ -0,0 +1,36 @@
+package command
+
+import (
+	"fmt"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+)
+
+// VersionHandler Version 命令处理器
+type VersionHandler struct {
+	*BaseCommand
+	version string
+}
+
+// NewVersionHandler 创建 Version 命令处理器
+func NewVersionHandler(groupRepo GroupRepository, version string) *VersionHandler {
+	return &VersionHandler{
+		BaseCommand: NewBaseCommand(
+			"version",
+			"显示机器人版本",
+			user.PermissionUser,
+			[]string{"private", "group", "supergroup"},
+			groupRepo,
+		),
+		version: version,
+	}
+}
+
+// Handle 处理命令
+func (h *VersionHandler) Handle(ctx *handler.Context) error {
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	return ctx.ReplyHTML(fmt.Sprintf("🤖 版本: <code>%s</code>", h.version))
+}
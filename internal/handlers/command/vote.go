@@ -0,0 +1,273 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// pollCallbackPrefix 投票按钮的回调数据前缀，回调数据为 "vote:<投票ID>:<选项序号>"
+	pollCallbackPrefix = "vote"
+	pollTTL            = 24 * time.Hour
+	minPollOptions     = 2
+	maxPollOptions     = 10
+	maxPollOptionLen   = 50
+	voteUsage          = "❌ 用法: /vote 问题 | 选项1 | 选项2 ...（2 到 10 个选项）"
+)
+
+// PollMessenger 发送和修改带按钮消息的接口（由 telegram.API 实现）
+type PollMessenger interface {
+	SendMessageWithButtons(ctx context.Context, chatID int64, text string, buttons [][]handler.Button) (int, error)
+	EditMessageWithButtons(ctx context.Context, chatID int64, messageID int, text string, buttons [][]handler.Button) error
+}
+
+// voteResult 一次投票的结果
+type voteResult int
+
+const (
+	voteNew       voteResult = iota // 第一次投票
+	voteChanged                     // 改投其他选项
+	voteUnchanged                   // 重复投给同一选项
+)
+
+// quickPoll 快速投票，每人一票，可以改投
+type quickPoll struct {
+	id        string
+	chatID    int64
+	messageID int
+	question  string
+	options   []string
+
+	mu    sync.Mutex
+	votes map[int64]int // userID -> 选项序号
+}
+
+func newQuickPoll(id string, chatID int64, question string, options []string) *quickPoll {
+	return &quickPoll{
+		id:       id,
+		chatID:   chatID,
+		question: question,
+		options:  options,
+		votes:    make(map[int64]int),
+	}
+}
+
+// vote 记录用户的投票，同一用户再次投票时覆盖之前的选项
+func (p *quickPoll) vote(userID int64, option int) voteResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous, voted := p.votes[userID]
+	switch {
+	case voted && previous == option:
+		return voteUnchanged
+	case voted:
+		p.votes[userID] = option
+		return voteChanged
+	default:
+		p.votes[userID] = option
+		return voteNew
+	}
+}
+
+// counts 每个选项的票数
+func (p *quickPoll) counts() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make([]int, len(p.options))
+	for _, option := range p.votes {
+		counts[option]++
+	}
+	return counts
+}
+
+// render 生成投票消息的文本和按钮，投票结束后不再显示按钮
+func (p *quickPoll) render(closed bool) (string, [][]handler.Button) {
+	counts := p.counts()
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📊 <b>%s</b>\n\n", html.EscapeString(p.question)))
+	keyboard := handler.NewKeyboard()
+	for i, option := range p.options {
+		percent := 0
+		if total > 0 {
+			percent = counts[i] * 100 / total
+		}
+		sb.WriteString(fmt.Sprintf("%s — %d 票（%d%%）\n", html.EscapeString(option), counts[i], percent))
+		keyboard.Row(handler.CallbackButton(
+			fmt.Sprintf("%s (%d)", option, counts[i]),
+			fmt.Sprintf("%s:%s:%d", pollCallbackPrefix, p.id, i),
+		))
+	}
+	sb.WriteString(fmt.Sprintf("\n共 %d 人投票", total))
+
+	if closed {
+		sb.WriteString("\n🔒 投票已结束")
+		return sb.String(), nil
+	}
+	return sb.String(), keyboard.Buttons()
+}
+
+// VoteHandler 快速投票命令处理器，同时处理投票按钮的回调
+// 投票只保存在内存中，24 小时后结束并显示最终结果；机器人重启后进行中的投票失效
+type VoteHandler struct {
+	*BaseCommand
+	messenger PollMessenger
+	polls     *ttlmap.Map[string, *quickPoll]
+	now       func() time.Time
+}
+
+// NewVoteHandler 创建快速投票命令处理器，并启动到期投票的清理任务
+func NewVoteHandler(groupRepo GroupRepository, messenger PollMessenger) *VoteHandler {
+	h := &VoteHandler{
+		BaseCommand: NewBaseCommand(
+			"vote",
+			"发起快速投票",
+			user.PermissionUser, // 所有人可用
+			[]string{"group", "supergroup"},
+			groupRepo,
+		),
+		messenger: messenger,
+		now:       time.Now,
+	}
+	h.polls = ttlmap.New(h.closePoll)
+	h.polls.StartSweeper(time.Minute)
+	return h
+}
+
+// Stop 停止到期投票的清理任务
+func (h *VoteHandler) Stop() {
+	h.polls.Stop()
+}
+
+// Handle 处理命令
+// 用法：/vote 问题 | 选项1 | 选项2 ...
+func (h *VoteHandler) Handle(ctx *handler.Context) error {
+	// 1. 检查权限
+	if err := h.CheckPermission(ctx); err != nil {
+		return err
+	}
+
+	// 2. 解析问题和选项
+	question, options, errMsg := parseVoteArgs(trimLeadingWords(ctx.Text, 1))
+	if errMsg != "" {
+		return ctx.Reply(errMsg)
+	}
+
+	// 3. 发送投票消息
+	poll := newQuickPoll(h.newPollID(), ctx.ChatID, question, options)
+	text, buttons := poll.render(false)
+	messageID, err := h.messenger.SendMessageWithButtons(ctx.RequestContext(), ctx.ChatID, text, buttons)
+	if err != nil {
+		return ctx.Reply("❌ 发起投票失败，请稍后重试")
+	}
+
+	poll.messageID = messageID
+	h.polls.Set(poll.id, poll, pollTTL)
+	return nil
+}
+
+// Prefix 实现 handler.CallbackHandler
+func (h *VoteHandler) Prefix() string {
+	return pollCallbackPrefix
+}
+
+// HandleCallback 记录投票并更新消息中的票数
+func (h *VoteHandler) HandleCallback(ctx context.Context, q *handler.CallbackQuery) (handler.CallbackAnswer, error) {
+	parts := strings.Split(q.Data, ":")
+	if len(parts) != 3 {
+		return handler.CallbackAnswer{}, nil
+	}
+
+	poll, ok := h.polls.Get(parts[1])
+	// 只接受投票消息本身的按钮
+	if !ok || poll.chatID != q.ChatID || poll.messageID != q.MessageID {
+		return handler.CallbackAnswer{Text: "投票已结束或已失效"}, nil
+	}
+
+	option, err := strconv.Atoi(parts[2])
+	if err != nil || option < 0 || option >= len(poll.options) {
+		return handler.CallbackAnswer{}, nil
+	}
+
+	name := poll.options[option]
+	var answer handler.CallbackAnswer
+	switch poll.vote(q.UserID, option) {
+	case voteUnchanged:
+		return handler.CallbackAnswer{Text: fmt.Sprintf("你已经投给了「%s」", name)}, nil
+	case voteChanged:
+		answer.Text = fmt.Sprintf("✅ 已改投「%s」", name)
+	default:
+		answer.Text = fmt.Sprintf("✅ 已投给「%s」", name)
+	}
+
+	text, buttons := poll.render(false)
+	if err := h.messenger.EditMessageWithButtons(ctx, poll.chatID, poll.messageID, text, buttons); err != nil {
+		// 投票已记录，下一次投票时会显示最新票数
+		return answer, err
+	}
+	return answer, nil
+}
+
+// closePoll 投票到期时显示最终结果并移除按钮（尽力而为，失败时按钮仍在但投票已失效）
+func (h *VoteHandler) closePoll(id string, poll *quickPoll) {
+	text, _ := poll.render(true)
+	_ = h.messenger.EditMessageWithButtons(context.Background(), poll.chatID, poll.messageID, text, nil)
+}
+
+// newPollID 生成投票 ID（基于时间，重启后不会与旧消息上的按钮冲突）
+func (h *VoteHandler) newPollID() string {
+	n := h.now().UnixNano()
+	for {
+		id := strconv.FormatInt(n, 36)
+		if _, exists := h.polls.Get(id); !exists {
+			return id
+		}
+		n++
+	}
+}
+
+// parseVoteArgs 解析 "问题 | 选项1 | 选项2 ..."，失败时返回错误提示
+func parseVoteArgs(text string) (string, []string, string) {
+	parts := strings.Split(text, "|")
+	question := strings.TrimSpace(parts[0])
+	if question == "" {
+		return "", nil, voteUsage
+	}
+
+	options := make([]string, 0, len(parts)-1)
+	seen := make(map[string]bool)
+	for _, part := range parts[1:] {
+		option := strings.TrimSpace(part)
+		if option == "" {
+			continue
+		}
+		if utf8.RuneCountInString(option) > maxPollOptionLen {
+			return "", nil, fmt.Sprintf("❌ 选项不能超过 %d 个字符", maxPollOptionLen)
+		}
+		if seen[option] {
+			return "", nil, fmt.Sprintf("❌ 选项「%s」重复", option)
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+
+	if len(options) < minPollOptions || len(options) > maxPollOptions {
+		return "", nil, voteUsage
+	}
+	return question, options, ""
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePollMessenger 记录发送和修改的投票消息
+type fakePollMessenger struct {
+	text    string
+	buttons [][]handler.Button
+	edits   int
+}
+
+func (f *fakePollMessenger) SendMessageWithButtons(ctx context.Context, chatID int64, text string, buttons [][]handler.Button) (int, error) {
+	f.text, f.buttons = text, buttons
+	return 77, nil
+}
+
+func (f *fakePollMessenger) EditMessageWithButtons(ctx context.Context, chatID int64, messageID int, text string, buttons [][]handler.Button) error {
+	f.text, f.buttons = text, buttons
+	f.edits++
+	return nil
+}
+
+func TestQuickPoll_Vote(t *testing.T) {
+	poll := newQuickPoll("p1", -100, "Lunch?", []string{"Pizza", "Sushi", "Salad"})
+
+	assert.Equal(t, voteNew, poll.vote(1, 0))
+	assert.Equal(t, voteNew, poll.vote(2, 0))
+	assert.Equal(t, voteNew, poll.vote(3, 1))
+
+	// 每人一票：重复投同一选项不计数，改投时从原选项移出
+	assert.Equal(t, voteUnchanged, poll.vote(1, 0))
+	assert.Equal(t, voteChanged, poll.vote(2, 1))
+
+	assert.Equal(t, []int{1, 2, 0}, poll.counts())
+}
+
+func TestVoteHandler(t *testing.T) {
+	tb := newTestBot(t)
+	messenger := &fakePollMessenger{}
+	h := NewVoteHandler(nil, messenger)
+	t.Cleanup(h.Stop)
+
+	alice := user.NewUser(1, "alice", "Alice", "")
+	require.NoError(t, h.Handle(newTestContext(tb, auditTestChatID, alice, "/vote 午饭吃什么 | 披萨 | 寿司")))
+	assert.Contains(t, messenger.text, "📊 <b>午饭吃什么</b>")
+	require.Len(t, messenger.buttons, 2)
+	assert.Equal(t, "披萨 (0)", messenger.buttons[0][0].Text)
+
+	data := messenger.buttons[1][0].Data
+	click := func(userID int64, data string) string {
+		answer, err := h.HandleCallback(context.Background(), &handler.CallbackQuery{
+			ID: "cb", Data: data, UserID: userID, ChatID: auditTestChatID, MessageID: 77,
+		})
+		require.NoError(t, err)
+		return answer.Text
+	}
+
+	assert.Equal(t, "✅ 已投给「寿司」", click(1, data))
+	assert.Equal(t, "你已经投给了「寿司」", click(1, data))
+	assert.Equal(t, "✅ 已投给「寿司」", click(2, data))
+	assert.Equal(t, "✅ 已改投「披萨」", click(2, messenger.buttons[0][0].Data))
+
+	assert.Equal(t, 3, messenger.edits)
+	assert.Contains(t, messenger.text, "披萨 — 1 票（50%）")
+	assert.Contains(t, messenger.text, "寿司 — 1 票（50%）")
+	assert.Contains(t, messenger.text, "共 2 人投票")
+
+	// 未知投票（已到期或重启前发起）
+	assert.Equal(t, "投票已结束或已失效", click(1, "vote:unknown:0"))
+
+	// 到期后显示最终结果并移除按钮
+	poll, ok := h.polls.Get(strings.Split(data, ":")[1])
+	require.True(t, ok)
+	h.closePoll(poll.id, poll)
+	assert.Contains(t, messenger.text, "🔒 投票已结束")
+	assert.Nil(t, messenger.buttons)
+}
+
+func TestVoteHandler_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", "用法"},
+		{"问题", "用法"},
+		{"问题 | 只有一个", "用法"},
+		{"| A | B", "用法"},
+		{"问题 | A | A", "重复"},
+		{"问题 | A | B | C | D | E | F | G | H | I | J | K", "用法"},
+	}
+	for _, tt := range tests {
+		_, _, errMsg := parseVoteArgs(tt.text)
+		assert.Contains(t, errMsg, tt.want, tt.text)
+	}
+
+	question, options, errMsg := parseVoteArgs(" 去哪 |北京| 上海 | ")
+	assert.Empty(t, errMsg)
+	assert.Equal(t, "去哪", question)
+	assert.Equal(t, []string{"北京", "上海"}, options)
+}
@@ -31,6 +31,11 @@ func (h *GreetingHandler) Match(ctx *handler.Context) bool {
 		return false
 	}
 
+	// 编辑后的消息不重复回复
+	if ctx.IsEdit {
+		return false
+	}
+
 	// 检查是否包含关键词
 	text := strings.ToLower(strings.TrimSpace(ctx.Text))
 	for _, keyword := range h.keywords {
@@ -0,0 +1,111 @@
+package listener
+
+import (
+	"fmt"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// AccountAgeHandler 新账号入群限制处理器
+// 群组设置了最小账号年龄（group.SettingMinAccountAgeDays）时，
+// 禁言估算注册时间不足该年龄的新成员，直到账号估计满龄。
+// 注册时间由用户 ID 估算（见 user.EstimateRegistration），只是近似值，默认不开启
+type AccountAgeHandler struct {
+	groupRepo  GroupReader
+	restrictor MemberRestrictor
+	sender     MessageSender
+	now        func() time.Time
+}
+
+// NewAccountAgeHandler 创建新账号入群限制处理器
+func NewAccountAgeHandler(groupRepo GroupReader, restrictor MemberRestrictor, sender MessageSender) *AccountAgeHandler {
+	return &AccountAgeHandler{
+		groupRepo:  groupRepo,
+		restrictor: restrictor,
+		sender:     sender,
+		now:        time.Now,
+	}
+}
+
+// Match 群组中的新成员入群消息，且群组设置了最小账号年龄
+func (h *AccountAgeHandler) Match(ctx *handler.Context) bool {
+	if !ctx.IsGroup() || ctx.Message == nil || len(ctx.Message.NewChatMembers) == 0 {
+		return false
+	}
+
+	g, err := h.groupRepo.FindByID(ctx.RequestContext(), ctx.ChatID)
+	if err != nil {
+		return false
+	}
+	return g.MinAccountAge() > 0
+}
+
+// Handle 禁言账号年龄不足的新成员
+// 机器人和群组保护列表中的用户（/protect）不受影响
+func (h *AccountAgeHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return nil
+	}
+	minAge := g.MinAccountAge()
+
+	var lastErr error
+	now := h.now()
+	for _, member := range ctx.Message.NewChatMembers {
+		if member.IsBot || g.IsProtected(member.ID) {
+			continue
+		}
+
+		remaining, young := accountAgeShortfall(member.ID, minAge, now)
+		if !young {
+			continue
+		}
+
+		if err := h.restrictor.RestrictChatMemberWithDuration(reqCtx, ctx.ChatID, member.ID, models.ChatPermissions{}, now.Add(remaining)); err != nil {
+			lastErr = err
+			continue
+		}
+		_, _ = h.sender.SendMessage(reqCtx, ctx.ChatID,
+			fmt.Sprintf("🆕 %s 的账号注册时间较短，已暂时禁言", memberName(member)))
+	}
+	return lastErr
+}
+
+// accountAgeShortfall 判断账号是否不足最小年龄，返回还差多久满龄
+// Telegram 把不足 30 秒的限制视为永久限制，因此至少返回 1 分钟
+func accountAgeShortfall(userID int64, minAge time.Duration, now time.Time) (time.Duration, bool) {
+	age := user.EstimateAccountAge(userID, now)
+	if age >= minAge {
+		return 0, false
+	}
+	if remaining := minAge - age; remaining > time.Minute {
+		return remaining, true
+	}
+	return time.Minute, true
+}
+
+// memberName 入群成员的显示名称
+func memberName(u models.User) string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	if u.FirstName != "" {
+		return u.FirstName
+	}
+	return fmt.Sprintf("ID %d", u.ID)
+}
+
+// Priority 在防突袭之后、命令之前执行
+func (h *AccountAgeHandler) Priority() int {
+	return 61
+}
+
+// ContinueChain 总是继续
+func (h *AccountAgeHandler) ContinueChain() bool {
+	return true
+}
@@ -0,0 +1,52 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountAgeShortfall(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	// 老账号
+	_, young := accountAgeShortfall(100_000_000, week, now)
+	assert.False(t, young)
+
+	// 估算注册时间晚于当前时间：禁言整个最小年龄
+	remaining, young := accountAgeShortfall(20_000_000_000, week, now)
+	assert.True(t, young)
+	assert.Equal(t, week, remaining)
+}
+
+func TestAccountAgeHandler(t *testing.T) {
+	const chatID = int64(-100123)
+	const (
+		oldUser   = int64(100_000_000)
+		newUser   = int64(20_000_000_000)
+		protected = int64(20_000_000_001)
+	)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.Protect(protected)
+	restrictor := &fakeRestrictor{}
+	sender := &fakeSender{}
+	h := NewAccountAgeHandler(&fakeGroupReader{group: g}, restrictor, sender)
+
+	// 默认不开启
+	assert.False(t, h.Match(newJoinContext(chatID, newUser)))
+
+	g.SetSetting(group.SettingMinAccountAgeDays, 7)
+	ctx := newJoinContext(chatID, oldUser, newUser, protected)
+	require.True(t, h.Match(ctx))
+	require.NoError(t, h.Handle(ctx))
+
+	assert.Equal(t, []int64{newUser}, restrictor.restricted)
+	require.Len(t, sender.texts, 1)
+	assert.Contains(t, sender.texts[0], "账号注册时间较短")
+}
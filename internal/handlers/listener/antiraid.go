@@ -0,0 +1,104 @@
+package listener
+
+import (
+	"context"
+	"telegram-bot/internal/handler"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	FeatureAntiRaid = "anti_raid" // 防突袭功能名称（与 command/antiraid.go 保持一致）
+)
+
+// MemberRestrictor 限制群组成员接口
+type MemberRestrictor interface {
+	RestrictChatMemberWithDuration(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions, until time.Time) error
+}
+
+// MessageSender 发送消息接口
+type MessageSender interface {
+	SendMessage(ctx context.Context, chatID int64, text string) (int, error)
+}
+
+// AntiRaidHandler 防突袭处理器
+// 短时间内大量新成员入群时自动进入防突袭模式，禁言期间入群的成员，
+// 禁言到期由 Telegram 自动解除；防突袭模式在静默期后由 RaidDetector 解除
+type AntiRaidHandler struct {
+	groupRepo        GroupReader
+	detector         *RaidDetector
+	restrictor       MemberRestrictor
+	sender           MessageSender
+	restrictDuration time.Duration
+}
+
+// NewAntiRaidHandler 创建防突袭处理器
+func NewAntiRaidHandler(groupRepo GroupReader, detector *RaidDetector, restrictor MemberRestrictor, sender MessageSender, restrictDuration time.Duration) *AntiRaidHandler {
+	return &AntiRaidHandler{
+		groupRepo:        groupRepo,
+		detector:         detector,
+		restrictor:       restrictor,
+		sender:           sender,
+		restrictDuration: restrictDuration,
+	}
+}
+
+// Match 群组中的新成员入群消息，且群组通过 /antiraid on 显式启用了防突袭功能
+// 防突袭会禁言新成员，默认关闭；群组不存在或查询失败时不匹配
+func (h *AntiRaidHandler) Match(ctx *handler.Context) bool {
+	if !ctx.IsGroup() || ctx.Message == nil || len(ctx.Message.NewChatMembers) == 0 {
+		return false
+	}
+
+	g, err := h.groupRepo.FindByID(ctx.RequestContext(), ctx.ChatID)
+	if err != nil {
+		return false
+	}
+	return g.IsFeatureOptedIn(FeatureAntiRaid)
+}
+
+// Handle 记录入群并在防突袭模式下禁言新成员
+// 机器人和群组保护列表中的用户（/protect）不计入入群统计，也不会被禁言
+func (h *AntiRaidHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	// 群组不存在或查询失败时没有受保护用户
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		g = nil
+	}
+
+	var restrict []int64
+	for _, member := range ctx.Message.NewChatMembers {
+		if member.IsBot || (g != nil && g.IsProtected(member.ID)) {
+			continue
+		}
+
+		users, triggered := h.detector.RecordJoin(ctx.ChatID, member.ID)
+		if triggered {
+			_, _ = h.sender.SendMessage(reqCtx, ctx.ChatID,
+				"🚨 检测到大量新成员加入，已开启防突袭模式，新成员将被暂时禁言")
+		}
+		restrict = append(restrict, users...)
+	}
+
+	var lastErr error
+	until := time.Now().Add(h.restrictDuration)
+	for _, userID := range restrict {
+		if err := h.restrictor.RestrictChatMemberWithDuration(reqCtx, ctx.ChatID, userID, models.ChatPermissions{}, until); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// Priority 在命令之前执行
+func (h *AntiRaidHandler) Priority() int {
+	return 60
+}
+
+// ContinueChain 总是继续
+func (h *AntiRaidHandler) ContinueChain() bool {
+	return true
+}
@@ -0,0 +1,108 @@
+package listener
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRestrictor struct {
+	restricted []int64
+}
+
+func (f *fakeRestrictor) RestrictChatMemberWithDuration(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions, until time.Time) error {
+	f.restricted = append(f.restricted, userID)
+	return nil
+}
+
+type fakeSender struct {
+	texts []string
+}
+
+func (f *fakeSender) SendMessage(ctx context.Context, chatID int64, text string) (int, error) {
+	f.texts = append(f.texts, text)
+	return len(f.texts), nil
+}
+
+func newJoinContext(chatID int64, userIDs ...int64) *handler.Context {
+	members := make([]models.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		members = append(members, models.User{ID: id})
+	}
+	return &handler.Context{
+		ChatType: "supergroup",
+		ChatID:   chatID,
+		UserID:   userIDs[0],
+		Message:  &models.Message{NewChatMembers: members},
+	}
+}
+
+func TestAntiRaidHandler(t *testing.T) {
+	const chatID = int64(-100123)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	detector := newTestRaidDetector(&now)
+	restrictor := &fakeRestrictor{}
+	sender := &fakeSender{}
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	h := NewAntiRaidHandler(&fakeGroupReader{group: g}, detector, restrictor, sender, time.Hour)
+
+	// 默认关闭，需要显式启用
+	assert.False(t, h.Match(newJoinContext(chatID, 1)))
+	g.EnableFeature(FeatureAntiRaid)
+
+	// 未记录的群组不匹配
+	assert.False(t, h.Match(newJoinContext(-100999, 1)))
+
+	// 不是入群消息
+	assert.False(t, h.Match(&handler.Context{ChatType: "supergroup", ChatID: chatID, Message: &models.Message{Text: "hi"}}))
+
+	// 阈值以下不限制
+	ctx := newJoinContext(chatID, 1, 2)
+	require.True(t, h.Match(ctx))
+	require.NoError(t, h.Handle(ctx))
+	assert.Empty(t, restrictor.restricted)
+	assert.Empty(t, sender.texts)
+
+	// 突增触发，之前入群的成员一并禁言
+	require.NoError(t, h.Handle(newJoinContext(chatID, 3)))
+	assert.Equal(t, []int64{1, 2, 3}, restrictor.restricted)
+	require.Len(t, sender.texts, 1)
+	assert.Contains(t, sender.texts[0], "防突袭模式")
+
+	// 静默期后解除，新成员不再禁言
+	now = now.Add(5 * time.Minute)
+	assert.Equal(t, []int64{chatID}, detector.Sweep())
+	restrictor.restricted = nil
+	require.NoError(t, h.Handle(newJoinContext(chatID, 4)))
+	assert.Empty(t, restrictor.restricted)
+
+	// 群组关闭防突袭功能后不匹配
+	g.DisableFeature(FeatureAntiRaid)
+	assert.False(t, h.Match(newJoinContext(chatID, 5)))
+}
+
+func TestAntiRaidHandler_SkipsProtectedUsers(t *testing.T) {
+	const chatID = int64(-100123)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	restrictor := &fakeRestrictor{}
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.Protect(2)
+	h := NewAntiRaidHandler(&fakeGroupReader{group: g}, newTestRaidDetector(&now), restrictor, &fakeSender{}, time.Hour)
+
+	// 受保护用户不计入入群统计：3 人入群中只有 2 人计数，未达到阈值
+	require.NoError(t, h.Handle(newJoinContext(chatID, 1, 2, 3)))
+	assert.Empty(t, restrictor.restricted)
+
+	// 触发后只禁言普通用户
+	require.NoError(t, h.Handle(newJoinContext(chatID, 4)))
+	assert.Equal(t, []int64{1, 3, 4}, restrictor.restricted)
+}
@@ -0,0 +1,245 @@
+package listener
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/middleware"
+	"time"
+)
+
+// CountRepository 消息计数持久化接口
+type CountRepository interface {
+	IncrementMessageCounts(ctx context.Context, counts map[activity.Key]int64) error
+	IncrementBuckets(ctx context.Context, counts map[activity.BucketKey]int64) error
+	IncrementCommandUsage(ctx context.Context, counts map[activity.CommandKey]int64) error
+	FindCommandUsage(ctx context.Context, groupID int64) ([]*activity.CommandUsage, error)
+}
+
+// BufferedCounter 缓冲消息计数器
+// 计数先在内存中累加，每隔 interval 或累计 threshold 次后批量写入仓储，
+// 避免每条消息都写一次数据库。写入失败时计数会合并回缓冲区，下次重试。
+// 同时维护用户累计消息数、群组按天统计（消息、命令、管理操作）和各命令的使用次数。
+type BufferedCounter struct {
+	repo      CountRepository
+	interval  time.Duration
+	threshold int
+	logger    middleware.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	pending  map[activity.Key]int64
+	buckets  map[activity.BucketKey]int64
+	commands map[activity.CommandKey]int64
+	total    int // 自上次写入以来的累加次数（用于判断阈值）
+
+	flushMu sync.Mutex // 保证同一时间只有一次写入
+	trigger chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewBufferedCounter 创建缓冲计数器
+// threshold <= 0 表示只按时间间隔写入
+func NewBufferedCounter(repo CountRepository, interval time.Duration, threshold int, logger middleware.Logger) *BufferedCounter {
+	return &BufferedCounter{
+		repo:      repo,
+		interval:  interval,
+		threshold: threshold,
+		logger:    logger,
+		now:       time.Now,
+		pending:   make(map[activity.Key]int64),
+		buckets:   make(map[activity.BucketKey]int64),
+		commands:  make(map[activity.CommandKey]int64),
+		trigger:   make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Increment 累加一条消息（同时计入用户累计和群组当天的消息数）
+func (c *BufferedCounter) Increment(key activity.Key) {
+	c.mu.Lock()
+	c.pending[key]++
+	c.buckets[c.bucketKey(key.GroupID, activity.MetricMessages)]++
+	reached := c.added()
+	c.mu.Unlock()
+
+	c.notify(reached)
+}
+
+// IncrementMetric 累加群组当天的某项指标
+func (c *BufferedCounter) IncrementMetric(groupID int64, metric activity.Metric) {
+	c.mu.Lock()
+	c.buckets[c.bucketKey(groupID, metric)]++
+	reached := c.added()
+	c.mu.Unlock()
+
+	c.notify(reached)
+}
+
+// IncrementCommand 累加一次命令使用（同时计入群组当天的命令数）
+func (c *BufferedCounter) IncrementCommand(groupID int64, command string) {
+	c.mu.Lock()
+	c.commands[activity.CommandKey{GroupID: groupID, Command: command}]++
+	c.buckets[c.bucketKey(groupID, activity.MetricCommands)]++
+	reached := c.added()
+	c.mu.Unlock()
+
+	c.notify(reached)
+}
+
+// TopCommands 返回群组内使用次数最多的 n 个命令（已写入的计数加上缓冲区中的计数）
+func (c *BufferedCounter) TopCommands(ctx context.Context, groupID int64, n int) ([]*activity.CommandUsage, error) {
+	persisted, err := c.repo.FindCommandUsage(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(persisted))
+	for _, u := range persisted {
+		counts[u.Command] += u.Count
+	}
+
+	c.mu.Lock()
+	for key, count := range c.commands {
+		if key.GroupID == groupID {
+			counts[key.Command] += count
+		}
+	}
+	c.mu.Unlock()
+
+	return topCommands(counts, n), nil
+}
+
+// topCommands 按使用次数降序选出前 n 个命令，次数相同时按命令名排序
+func topCommands(counts map[string]int64, n int) []*activity.CommandUsage {
+	usage := make([]*activity.CommandUsage, 0, len(counts))
+	for command, count := range counts {
+		usage = append(usage, &activity.CommandUsage{Command: command, Count: count})
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Command < usage[j].Command
+	})
+
+	if len(usage) > n {
+		usage = usage[:n]
+	}
+	return usage
+}
+
+// bucketKey 当天的统计键（调用方需持有锁）
+func (c *BufferedCounter) bucketKey(groupID int64, metric activity.Metric) activity.BucketKey {
+	return activity.BucketKey{GroupID: groupID, Day: activity.DayOf(c.now()), Metric: metric}
+}
+
+// added 记录一次累加，返回是否达到写入阈值（调用方需持有锁）
+func (c *BufferedCounter) added() bool {
+	c.total++
+	return c.threshold > 0 && c.total >= c.threshold
+}
+
+// notify 达到阈值时通知后台写入，已有待处理的通知时不重复发送
+func (c *BufferedCounter) notify(reached bool) {
+	if !reached {
+		return
+	}
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Start 启动后台写入循环
+func (c *BufferedCounter) Start() {
+	go c.run()
+}
+
+// run 后台写入循环
+func (c *BufferedCounter) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.flushAndLog()
+		case <-c.trigger:
+			c.flushAndLog()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// flushAndLog 后台写入，失败只记录日志
+func (c *BufferedCounter) flushAndLog() {
+	if err := c.Flush(context.Background()); err != nil {
+		c.logger.Error("message_count_flush_failed", "error", err)
+	}
+}
+
+// Flush 立即将缓冲区写入仓储
+func (c *BufferedCounter) Flush(ctx context.Context) error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	c.mu.Lock()
+	if len(c.pending) == 0 && len(c.buckets) == 0 && len(c.commands) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	pending, buckets, commands := c.pending, c.buckets, c.commands
+	c.pending = make(map[activity.Key]int64)
+	c.buckets = make(map[activity.BucketKey]int64)
+	c.commands = make(map[activity.CommandKey]int64)
+	c.total = 0
+	c.mu.Unlock()
+
+	// 各部分分别写入，失败的部分合并回缓冲区，成功的部分不会重复计数
+	var firstErr error
+	record := func(err error, mergeBack func()) {
+		if err == nil {
+			return
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		c.mu.Lock()
+		mergeBack()
+		c.mu.Unlock()
+	}
+
+	if len(pending) > 0 {
+		record(c.repo.IncrementMessageCounts(ctx, pending), func() { mergeCounts(c.pending, pending) })
+	}
+	if len(buckets) > 0 {
+		record(c.repo.IncrementBuckets(ctx, buckets), func() { mergeCounts(c.buckets, buckets) })
+	}
+	if len(commands) > 0 {
+		record(c.repo.IncrementCommandUsage(ctx, commands), func() { mergeCounts(c.commands, commands) })
+	}
+
+	return firstErr
+}
+
+// mergeCounts 将 src 的计数累加到 dst
+func mergeCounts[K comparable](dst, src map[K]int64) {
+	for key, count := range src {
+		dst[key] += count
+	}
+}
+
+// Stop 停止后台循环并写入剩余计数（关闭时调用）
+func (c *BufferedCounter) Stop(ctx context.Context) error {
+	close(c.stop)
+	<-c.done
+	return c.Flush(ctx)
+}
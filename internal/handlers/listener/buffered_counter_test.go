@@ -0,0 +1,319 @@
+package listener
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCountRepository 记录写入的计数
+type fakeCountRepository struct {
+	mu        sync.Mutex
+	totals    map[activity.Key]int64
+	buckets   map[activity.BucketKey]int64
+	commands  map[activity.CommandKey]int64
+	flushes   int
+	err       error
+	bucketErr error
+}
+
+func newFakeCountRepository() *fakeCountRepository {
+	return &fakeCountRepository{
+		totals:   make(map[activity.Key]int64),
+		buckets:  make(map[activity.BucketKey]int64),
+		commands: make(map[activity.CommandKey]int64),
+	}
+}
+
+func (r *fakeCountRepository) IncrementCommandUsage(ctx context.Context, counts map[activity.CommandKey]int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, count := range counts {
+		r.commands[key] += count
+	}
+	return nil
+}
+
+func (r *fakeCountRepository) FindCommandUsage(ctx context.Context, groupID int64) ([]*activity.CommandUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var usage []*activity.CommandUsage
+	for key, count := range r.commands {
+		if key.GroupID == groupID {
+			usage = append(usage, &activity.CommandUsage{Command: key.Command, Count: count})
+		}
+	}
+	return usage, nil
+}
+
+func (r *fakeCountRepository) IncrementBuckets(ctx context.Context, counts map[activity.BucketKey]int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.bucketErr != nil {
+		return r.bucketErr
+	}
+	for key, count := range counts {
+		r.buckets[key] += count
+	}
+	return nil
+}
+
+func (r *fakeCountRepository) bucketSnapshot() map[activity.BucketKey]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buckets := make(map[activity.BucketKey]int64, len(r.buckets))
+	for key, count := range r.buckets {
+		buckets[key] = count
+	}
+	return buckets
+}
+
+func (r *fakeCountRepository) IncrementMessageCounts(ctx context.Context, counts map[activity.Key]int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	r.flushes++
+	for key, count := range counts {
+		r.totals[key] += count
+	}
+	return nil
+}
+
+func (r *fakeCountRepository) snapshot() (map[activity.Key]int64, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	totals := make(map[activity.Key]int64, len(r.totals))
+	for key, count := range r.totals {
+		totals[key] = count
+	}
+	return totals, r.flushes
+}
+
+// nopLogger 丢弃所有日志
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, fields ...interface{}) {}
+func (nopLogger) Info(msg string, fields ...interface{})  {}
+func (nopLogger) Warn(msg string, fields ...interface{})  {}
+func (nopLogger) Error(msg string, fields ...interface{}) {}
+
+func TestBufferedCounter_FlushOnThreshold(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Hour, 3, nopLogger{})
+	counter.Start()
+	defer counter.Stop(context.Background())
+
+	key := activity.Key{GroupID: -100, UserID: 1}
+	counter.Increment(key)
+	counter.Increment(key)
+
+	// 未达到阈值，不写入
+	_, flushes := repo.snapshot()
+	assert.Zero(t, flushes)
+
+	counter.Increment(key)
+
+	assert.Eventually(t, func() bool {
+		totals, _ := repo.snapshot()
+		return totals[key] == 3
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBufferedCounter_FlushOnInterval(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, 10*time.Millisecond, 0, nopLogger{})
+	counter.Start()
+	defer counter.Stop(context.Background())
+
+	key := activity.Key{GroupID: -100, UserID: 1}
+	counter.Increment(key)
+
+	assert.Eventually(t, func() bool {
+		totals, _ := repo.snapshot()
+		return totals[key] == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBufferedCounter_ExplicitFlush(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Hour, 0, nopLogger{})
+
+	alice := activity.Key{GroupID: -100, UserID: 1}
+	bob := activity.Key{GroupID: -100, UserID: 2}
+	counter.Increment(alice)
+	counter.Increment(alice)
+	counter.Increment(bob)
+
+	require.NoError(t, counter.Flush(context.Background()))
+
+	totals, flushes := repo.snapshot()
+	assert.Equal(t, map[activity.Key]int64{alice: 2, bob: 1}, totals)
+	assert.Equal(t, 1, flushes)
+
+	// 缓冲区为空时不再写入
+	require.NoError(t, counter.Flush(context.Background()))
+	_, flushes = repo.snapshot()
+	assert.Equal(t, 1, flushes)
+}
+
+func TestBufferedCounter_FailedFlushKeepsCounts(t *testing.T) {
+	repo := newFakeCountRepository()
+	repo.err = assert.AnError
+	counter := NewBufferedCounter(repo, time.Hour, 0, nopLogger{})
+
+	key := activity.Key{GroupID: -100, UserID: 1}
+	counter.Increment(key)
+	assert.ErrorIs(t, counter.Flush(context.Background()), assert.AnError)
+
+	// 失败期间的新计数与旧计数合并
+	counter.Increment(key)
+	repo.mu.Lock()
+	repo.err = nil
+	repo.mu.Unlock()
+
+	require.NoError(t, counter.Flush(context.Background()))
+	totals, _ := repo.snapshot()
+	assert.Equal(t, int64(2), totals[key])
+}
+
+func TestBufferedCounter_StopFlushesRemaining(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Hour, 100, nopLogger{})
+	counter.Start()
+
+	key := activity.Key{GroupID: -100, UserID: 1}
+	counter.Increment(key)
+
+	require.NoError(t, counter.Stop(context.Background()))
+	totals, _ := repo.snapshot()
+	assert.Equal(t, int64(1), totals[key])
+}
+
+func TestBufferedCounter_DailyBuckets(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Hour, 0, nopLogger{})
+	now := time.Date(2025, 1, 2, 23, 59, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+
+	counter.Increment(activity.Key{GroupID: -100, UserID: 1})
+	counter.Increment(activity.Key{GroupID: -100, UserID: 2})
+	counter.IncrementMetric(-100, activity.MetricCommands)
+
+	// 跨天后计入新的日期
+	now = now.Add(2 * time.Minute)
+	counter.IncrementMetric(-100, activity.MetricModeration)
+
+	require.NoError(t, counter.Flush(context.Background()))
+
+	assert.Equal(t, map[activity.BucketKey]int64{
+		{GroupID: -100, Day: "2025-01-02", Metric: activity.MetricMessages}:   2,
+		{GroupID: -100, Day: "2025-01-02", Metric: activity.MetricCommands}:   1,
+		{GroupID: -100, Day: "2025-01-03", Metric: activity.MetricModeration}: 1,
+	}, repo.bucketSnapshot())
+}
+
+func TestBufferedCounter_FailedBucketFlushDoesNotDoubleCount(t *testing.T) {
+	repo := newFakeCountRepository()
+	repo.bucketErr = assert.AnError
+	counter := NewBufferedCounter(repo, time.Hour, 0, nopLogger{})
+
+	key := activity.Key{GroupID: -100, UserID: 1}
+	counter.Increment(key)
+	assert.ErrorIs(t, counter.Flush(context.Background()), assert.AnError)
+
+	repo.mu.Lock()
+	repo.bucketErr = nil
+	repo.mu.Unlock()
+	require.NoError(t, counter.Flush(context.Background()))
+
+	// 用户计数第一次已写入成功，不会重复累加；按天统计在重试时写入
+	totals, _ := repo.snapshot()
+	assert.Equal(t, int64(1), totals[key])
+	buckets := repo.bucketSnapshot()
+	assert.Equal(t, int64(1), buckets[activity.BucketKey{GroupID: -100, Day: activity.DayOf(time.Now()), Metric: activity.MetricMessages}])
+}
+
+func TestBufferedCounter_TopCommands(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Hour, 0, nopLogger{})
+	h := NewMessageCounterHandler(counter)
+
+	send := func(groupID int64, text string) {
+		ctx := &handler.Context{ChatType: "supergroup", ChatID: groupID, UserID: 1, Text: text}
+		require.NoError(t, h.Handle(ctx))
+	}
+
+	for _, text := range []string{"/ping", "/stats", "/ping@mybot", "hello", "/rules", "/stats export", "/ping", "/<b>"} {
+		send(-100, text)
+	}
+	send(-200, "/rules")
+
+	// 一部分已写入，一部分仍在缓冲区
+	require.NoError(t, counter.Flush(context.Background()))
+	send(-100, "/rules")
+	send(-100, "/rules")
+
+	top, err := counter.TopCommands(context.Background(), -100, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*activity.CommandUsage{
+		{Command: "ping", Count: 3},
+		{Command: "rules", Count: 3},
+	}, top)
+
+	top, err = counter.TopCommands(context.Background(), -100, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 3)
+	assert.Equal(t, "stats", top[2].Command)
+
+	// 命令也计入当天的命令数
+	require.NoError(t, counter.Flush(context.Background()))
+	assert.Equal(t, int64(9), repo.bucketSnapshot()[activity.BucketKey{GroupID: -100, Day: activity.DayOf(time.Now()), Metric: activity.MetricCommands}])
+}
+
+func TestBufferedCounter_ConcurrentCommands(t *testing.T) {
+	repo := newFakeCountRepository()
+	counter := NewBufferedCounter(repo, time.Millisecond, 7, nopLogger{})
+	counter.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				counter.IncrementCommand(-100, "ping")
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, counter.Stop(context.Background()))
+
+	top, err := counter.TopCommands(context.Background(), -100, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*activity.CommandUsage{{Command: "ping", Count: 1000}}, top)
+}
+
+func TestTopCommands(t *testing.T) {
+	top := topCommands(map[string]int64{"a": 1, "b": 5, "c": 5, "d": 2}, 3)
+	assert.Equal(t, []*activity.CommandUsage{
+		{Command: "b", Count: 5},
+		{Command: "c", Count: 5},
+		{Command: "d", Count: 2},
+	}, top)
+	assert.Empty(t, topCommands(map[string]int64{}, 3))
+}
@@ -0,0 +1,52 @@
+package listener
+
+import (
+	"regexp"
+	"telegram-bot/internal/domain/activity"
+	"telegram-bot/internal/handler"
+)
+
+// validCommandName Telegram 命令名格式
+var validCommandName = regexp.MustCompile(`^[A-Za-z0-9_]{1,32}$`)
+
+// MessageCounterHandler 消息计数处理器
+// 统计每个用户在群组中的发言数，计数经 BufferedCounter 批量写入
+type MessageCounterHandler struct {
+	counter *BufferedCounter
+}
+
+// NewMessageCounterHandler 创建消息计数处理器
+func NewMessageCounterHandler(counter *BufferedCounter) *MessageCounterHandler {
+	return &MessageCounterHandler{
+		counter: counter,
+	}
+}
+
+// Match 只统计群组中的新消息（编辑不重复计数）
+func (h *MessageCounterHandler) Match(ctx *handler.Context) bool {
+	return ctx.IsGroup() && !ctx.IsEdit && ctx.UserID != 0
+}
+
+// Handle 处理消息
+func (h *MessageCounterHandler) Handle(ctx *handler.Context) error {
+	h.counter.Increment(activity.Key{GroupID: ctx.ChatID, UserID: ctx.UserID})
+	if command, ok := handler.ParseCommand(ctx.Text); ok {
+		if validCommandName.MatchString(command) {
+			h.counter.IncrementCommand(ctx.ChatID, command)
+		} else {
+			// 不符合 Telegram 命令格式的只计入命令总数，避免任意文本产生大量命令记录
+			h.counter.IncrementMetric(ctx.ChatID, activity.MetricCommands)
+		}
+	}
+	return nil
+}
+
+// Priority 监听器优先级
+func (h *MessageCounterHandler) Priority() int {
+	return 910
+}
+
+// ContinueChain 总是继续
+func (h *MessageCounterHandler) ContinueChain() bool {
+	return true
+}
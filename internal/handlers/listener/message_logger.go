@@ -7,14 +7,17 @@ import (
 
 // MessageLoggerHandler 消息日志处理器
 // 记录所有接收到的消息（用于审计和调试）
+// 消息文本可能包含个人信息或密钥，配置 redactor 时先脱敏再记录
 type MessageLoggerHandler struct {
-	logger middleware.Logger
+	logger   middleware.Logger
+	redactor middleware.Redactor // 可为 nil（不脱敏）
 }
 
 // NewMessageLoggerHandler 创建消息日志处理器
-func NewMessageLoggerHandler(logger middleware.Logger) *MessageLoggerHandler {
+func NewMessageLoggerHandler(logger middleware.Logger, redactor middleware.Redactor) *MessageLoggerHandler {
 	return &MessageLoggerHandler{
-		logger: logger,
+		logger:   logger,
+		redactor: redactor,
 	}
 }
 
@@ -33,13 +36,21 @@ func (h *MessageLoggerHandler) Handle(ctx *handler.Context) error {
 		"user_id", ctx.UserID,
 		"username", ctx.Username,
 		"first_name", ctx.FirstName,
-		"text", ctx.Text,
+		"text", h.redactedText(ctx.Text),
 		"message_id", ctx.MessageID,
 	)
 
 	return nil
 }
 
+// redactedText 脱敏后的消息文本
+func (h *MessageLoggerHandler) redactedText(text string) string {
+	if h.redactor == nil {
+		return text
+	}
+	return h.redactor.Sanitize(text)
+}
+
 // Priority 最低优先级
 func (h *MessageLoggerHandler) Priority() int {
 	return 900
@@ -0,0 +1,46 @@
+package listener
+
+import (
+	"testing"
+
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// textLogger 记录每条日志的 text 字段
+type textLogger struct {
+	nopLogger
+	texts []interface{}
+}
+
+func (l *textLogger) Debug(msg string, fields ...interface{}) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == "text" {
+			l.texts = append(l.texts, fields[i+1])
+		}
+	}
+}
+
+func TestMessageLoggerHandler_Redaction(t *testing.T) {
+	log := &textLogger{}
+	h := NewMessageLoggerHandler(log, logger.NewSanitizer())
+
+	require.NoError(t, h.Handle(&handler.Context{Text: "联系我 alice@example.com"}))
+	require.NoError(t, h.Handle(&handler.Context{Text: "hello world"}))
+
+	require.Len(t, log.texts, 2)
+	assert.NotContains(t, log.texts[0], "alice@example.com")
+	assert.Contains(t, log.texts[0], "***@example.com")
+	assert.Equal(t, "hello world", log.texts[1])
+}
+
+func TestMessageLoggerHandler_NoRedactor(t *testing.T) {
+	log := &textLogger{}
+	h := NewMessageLoggerHandler(log, nil)
+
+	require.NoError(t, h.Handle(&handler.Context{Text: "alice@example.com"}))
+	assert.Equal(t, []interface{}{"alice@example.com"}, log.texts)
+}
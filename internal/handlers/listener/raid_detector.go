@@ -0,0 +1,173 @@
+package listener
+
+import (
+	"sync"
+	"time"
+)
+
+// RaidDetector 突袭检测器
+// 统计每个群组在时间窗口内的入群人数，超过阈值时进入防突袭模式；
+// 防突袭模式在最后一次入群后经过静默期自动解除。状态只保存在内存中
+type RaidDetector struct {
+	threshold int           // 窗口内入群人数达到该值时触发
+	window    time.Duration // 统计窗口
+	quiet     time.Duration // 静默期：无人入群超过该时长后解除
+
+	mu     sync.Mutex
+	groups map[int64]*raidState
+	now    func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// raidState 单个群组的突袭状态
+type raidState struct {
+	joins    []joinEvent // 窗口内的入群记录
+	active   bool
+	lastJoin time.Time
+}
+
+// joinEvent 入群记录
+type joinEvent struct {
+	userID int64
+	at     time.Time
+}
+
+// NewRaidDetector 创建突袭检测器
+func NewRaidDetector(threshold int, window, quiet time.Duration) *RaidDetector {
+	return &RaidDetector{
+		threshold: threshold,
+		window:    window,
+		quiet:     quiet,
+		groups:    make(map[int64]*raidState),
+		now:       time.Now,
+	}
+}
+
+// RecordJoin 记录一次入群，返回需要限制的用户
+// 触发防突袭模式时返回窗口内所有入群用户（包括触发前加入的），
+// 防突袭模式已开启时只返回本次入群的用户，未开启时返回 nil
+func (d *RaidDetector) RecordJoin(groupID, userID int64) (restrict []int64, triggered bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	state, ok := d.groups[groupID]
+	if !ok {
+		state = &raidState{}
+		d.groups[groupID] = state
+	}
+	if state.active && d.quietSince(state, now) {
+		state.active = false
+	}
+
+	state.lastJoin = now
+	state.joins = append(pruneJoins(state.joins, now.Add(-d.window)), joinEvent{userID: userID, at: now})
+
+	if state.active {
+		return []int64{userID}, false
+	}
+	if len(state.joins) < d.threshold {
+		return nil, false
+	}
+
+	state.active = true
+	restrict = make([]int64, 0, len(state.joins))
+	for _, j := range state.joins {
+		restrict = append(restrict, j.userID)
+	}
+	return restrict, true
+}
+
+// IsActive 群组是否处于防突袭模式
+func (d *RaidDetector) IsActive(groupID int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.groups[groupID]
+	return ok && state.active && !d.quietSince(state, d.now())
+}
+
+// Lift 手动解除防突袭模式，未开启时返回 false
+func (d *RaidDetector) Lift(groupID int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.groups[groupID]
+	if !ok || !state.active {
+		return false
+	}
+	delete(d.groups, groupID)
+	return true
+}
+
+// Sweep 解除已度过静默期的防突袭模式，并清理不再需要的状态
+// 返回本次解除防突袭模式的群组
+func (d *RaidDetector) Sweep() []int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	var lifted []int64
+	for groupID, state := range d.groups {
+		if state.active {
+			if !d.quietSince(state, now) {
+				continue
+			}
+			lifted = append(lifted, groupID)
+		} else if now.Sub(state.lastJoin) < d.window {
+			continue
+		}
+		delete(d.groups, groupID)
+	}
+	return lifted
+}
+
+// StartSweeper 启动后台任务，定期解除防突袭模式并回调 onLift
+func (d *RaidDetector) StartSweeper(interval time.Duration, onLift func(groupID int64)) {
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				for _, groupID := range d.Sweep() {
+					onLift(groupID)
+				}
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台任务
+func (d *RaidDetector) Stop() {
+	if d.stop == nil {
+		return
+	}
+	close(d.stop)
+	<-d.done
+	d.stop = nil
+}
+
+// quietSince 最后一次入群后是否已经过静默期
+func (d *RaidDetector) quietSince(state *raidState, now time.Time) bool {
+	return now.Sub(state.lastJoin) >= d.quiet
+}
+
+// pruneJoins 移除早于 cutoff 的入群记录
+func pruneJoins(joins []joinEvent, cutoff time.Time) []joinEvent {
+	i := 0
+	for i < len(joins) && joins[i].at.Before(cutoff) {
+		i++
+	}
+	return joins[i:]
+}
@@ -0,0 +1,93 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRaidDetector(now *time.Time) *RaidDetector {
+	d := NewRaidDetector(3, time.Minute, 5*time.Minute)
+	d.now = func() time.Time { return *now }
+	return d
+}
+
+func TestRaidDetector_TriggersOnBurst(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := newTestRaidDetector(&now)
+	const groupID = int64(-100)
+
+	restrict, triggered := d.RecordJoin(groupID, 1)
+	assert.Nil(t, restrict)
+	assert.False(t, triggered)
+
+	now = now.Add(10 * time.Second)
+	restrict, triggered = d.RecordJoin(groupID, 2)
+	assert.Nil(t, restrict)
+	assert.False(t, triggered)
+	assert.False(t, d.IsActive(groupID))
+
+	// 第三个入群触发，之前入群的用户一并限制
+	now = now.Add(10 * time.Second)
+	restrict, triggered = d.RecordJoin(groupID, 3)
+	assert.True(t, triggered)
+	assert.Equal(t, []int64{1, 2, 3}, restrict)
+	assert.True(t, d.IsActive(groupID))
+
+	// 已触发后只限制新入群的用户
+	now = now.Add(10 * time.Second)
+	restrict, triggered = d.RecordJoin(groupID, 4)
+	assert.False(t, triggered)
+	assert.Equal(t, []int64{4}, restrict)
+}
+
+func TestRaidDetector_SlowJoinsDoNotTrigger(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := newTestRaidDetector(&now)
+
+	for i := int64(1); i <= 5; i++ {
+		_, triggered := d.RecordJoin(-100, i)
+		assert.False(t, triggered)
+		now = now.Add(40 * time.Second)
+	}
+	assert.False(t, d.IsActive(-100))
+}
+
+func TestRaidDetector_LiftsAfterQuietPeriod(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := newTestRaidDetector(&now)
+	const groupID = int64(-100)
+
+	for i := int64(1); i <= 3; i++ {
+		d.RecordJoin(groupID, i)
+	}
+	assert.True(t, d.IsActive(groupID))
+
+	// 静默期内不解除
+	now = now.Add(4 * time.Minute)
+	assert.Empty(t, d.Sweep())
+	assert.True(t, d.IsActive(groupID))
+
+	now = now.Add(time.Minute)
+	assert.False(t, d.IsActive(groupID))
+	assert.Equal(t, []int64{groupID}, d.Sweep())
+	assert.Empty(t, d.Sweep())
+
+	// 解除后单个入群不再限制
+	restrict, triggered := d.RecordJoin(groupID, 9)
+	assert.Nil(t, restrict)
+	assert.False(t, triggered)
+}
+
+func TestRaidDetector_Lift(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := newTestRaidDetector(&now)
+
+	assert.False(t, d.Lift(-100))
+	for i := int64(1); i <= 3; i++ {
+		d.RecordJoin(-100, i)
+	}
+	assert.True(t, d.Lift(-100))
+	assert.False(t, d.IsActive(-100))
+}
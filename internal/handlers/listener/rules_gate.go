@@ -0,0 +1,247 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// rulesAckCallbackPrefix 同意群规按钮的回调数据前缀，回调数据为 "rules:<用户ID>"
+	rulesAckCallbackPrefix = "rules"
+	// rulesAckGrace 禁言比同意时限多保留的时间，保证超时踢出前不会自动解除
+	rulesAckGrace = 5 * time.Minute
+	// rulesAckKickDuration 超时踢出的封禁时长（到期后可以重新加入；Telegram 把不足 30 秒的封禁视为永久封禁）
+	rulesAckKickDuration = time.Minute
+)
+
+// memberPermissions 同意群规后恢复的成员权限
+// 全部允许表示解除对该成员的单独限制，实际可用的权限仍以群组默认权限为准
+var memberPermissions = models.ChatPermissions{
+	CanSendMessages:       true,
+	CanSendAudios:         true,
+	CanSendDocuments:      true,
+	CanSendPhotos:         true,
+	CanSendVideos:         true,
+	CanSendVideoNotes:     true,
+	CanSendVoiceNotes:     true,
+	CanSendPolls:          true,
+	CanSendOtherMessages:  true,
+	CanAddWebPagePreviews: true,
+	CanInviteUsers:        true,
+}
+
+// RulesGateAPI 同意群规流程需要的 Telegram 操作（由 telegram.API 实现）
+type RulesGateAPI interface {
+	MemberRestrictor
+	RestrictChatMember(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions) error
+	BanChatMemberWithDuration(ctx context.Context, chatID, userID int64, until time.Time) error
+	SendMessageWithButtons(ctx context.Context, chatID int64, text string, buttons [][]handler.Button) (int, error)
+	EditMessageWithButtons(ctx context.Context, chatID int64, messageID int, text string, buttons [][]handler.Button) error
+}
+
+// rulesAckKey 等待同意群规的成员
+type rulesAckKey struct {
+	chatID int64
+	userID int64
+}
+
+// pendingRulesAck 等待同意的群规消息
+type pendingRulesAck struct {
+	name      string
+	messageID int
+	deadline  time.Time
+	heldUntil time.Time // 入群时防突袭或新账号限制的禁言到期时间，同意后保留；零值表示没有
+}
+
+// RulesGateHandler 新成员同意群规处理器
+// 群组设置了群规和同意时限（/rulesgate）时，禁言新成员并发送群规和“同意”按钮，
+// 成员点击按钮后解除禁言，超时未同意则移出群组。
+// 等待中的成员只保存在内存中：机器人重启后不会再踢出，禁言在时限过后由 Telegram 自动解除。
+// 防突袭模式和新账号限制（优先级更高的处理器）的禁言不会被缩短，也不会因同意群规而解除
+type RulesGateHandler struct {
+	groupRepo    GroupReader
+	api          RulesGateAPI
+	raids        *RaidDetector
+	raidRestrict time.Duration // 防突袭模式下新成员的禁言时长
+	pending      *ttlmap.Map[rulesAckKey, pendingRulesAck]
+	now          func() time.Time
+}
+
+// NewRulesGateHandler 创建新成员同意群规处理器，并启动超时检查任务
+// raids 和 raidRestrict 与 AntiRaidHandler 使用的一致
+func NewRulesGateHandler(groupRepo GroupReader, api RulesGateAPI, raids *RaidDetector, raidRestrict time.Duration) *RulesGateHandler {
+	h := &RulesGateHandler{
+		groupRepo:    groupRepo,
+		api:          api,
+		raids:        raids,
+		raidRestrict: raidRestrict,
+		now:          time.Now,
+	}
+	h.pending = ttlmap.New(h.kick)
+	h.pending.StartSweeper(30 * time.Second)
+	return h
+}
+
+// Stop 停止超时检查任务
+func (h *RulesGateHandler) Stop() {
+	h.pending.Stop()
+}
+
+// Match 群组中的新成员入群消息，且群组设置了群规和同意时限
+func (h *RulesGateHandler) Match(ctx *handler.Context) bool {
+	if !ctx.IsGroup() || ctx.Message == nil || len(ctx.Message.NewChatMembers) == 0 {
+		return false
+	}
+
+	g, err := h.groupRepo.FindByID(ctx.RequestContext(), ctx.ChatID)
+	if err != nil {
+		return false
+	}
+	return g.RulesAckTimeout() > 0 && g.Rules() != ""
+}
+
+// Handle 禁言新成员并发送群规
+// 机器人和群组保护列表中的用户（/protect）不受影响
+func (h *RulesGateHandler) Handle(ctx *handler.Context) error {
+	reqCtx := ctx.RequestContext()
+
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil {
+		return nil
+	}
+	timeout := g.RulesAckTimeout()
+
+	var lastErr error
+	now := h.now()
+	for _, member := range ctx.Message.NewChatMembers {
+		if member.IsBot || g.IsProtected(member.ID) {
+			continue
+		}
+
+		// 已有更长的禁言时不再禁言，避免缩短
+		deadline := now.Add(timeout)
+		held := h.heldUntil(g, member.ID, now)
+		if restrictUntil := deadline.Add(rulesAckGrace); held.Before(restrictUntil) {
+			if err := h.api.RestrictChatMemberWithDuration(reqCtx, ctx.ChatID, member.ID, models.ChatPermissions{}, restrictUntil); err != nil {
+				lastErr = err
+				continue
+			}
+		}
+
+		name := memberName(member)
+		text := fmt.Sprintf("👋 欢迎 %s！请阅读群规，并在 %d 分钟内点击下方按钮同意，否则将被移出群组\n\n📜 <b>群规</b>\n\n%s",
+			html.EscapeString(name), int(timeout/time.Minute), html.EscapeString(g.Rules()))
+		buttons := handler.NewKeyboard().
+			Row(handler.CallbackButton("✅ 我已阅读并同意群规", fmt.Sprintf("%s:%d", rulesAckCallbackPrefix, member.ID))).
+			Buttons()
+		messageID, err := h.api.SendMessageWithButtons(reqCtx, ctx.ChatID, text, buttons)
+		if err != nil {
+			// 成员看不到群规也就无法同意，解除同意群规的禁言
+			_ = h.release(reqCtx, ctx.ChatID, member.ID, held, now)
+			lastErr = err
+			continue
+		}
+
+		h.pending.Set(rulesAckKey{chatID: ctx.ChatID, userID: member.ID},
+			pendingRulesAck{name: name, messageID: messageID, deadline: deadline, heldUntil: held}, timeout)
+	}
+	return lastErr
+}
+
+// Prefix 实现 handler.CallbackHandler
+func (h *RulesGateHandler) Prefix() string {
+	return rulesAckCallbackPrefix
+}
+
+// HandleCallback 成员点击同意按钮后解除禁言
+// 防突袭模式中或入群时受到防突袭、新账号限制（/minaccountage）的成员继续保持原来的禁言
+func (h *RulesGateHandler) HandleCallback(ctx context.Context, q *handler.CallbackQuery) (handler.CallbackAnswer, error) {
+	userID, err := strconv.ParseInt(strings.TrimPrefix(q.Data, rulesAckCallbackPrefix+":"), 10, 64)
+	if err != nil {
+		return handler.CallbackAnswer{}, nil
+	}
+	if userID != q.UserID {
+		return handler.CallbackAnswer{Text: "这不是给你的按钮", ShowAlert: true}, nil
+	}
+
+	key := rulesAckKey{chatID: q.ChatID, userID: userID}
+	pending, ok := h.pending.Delete(key)
+	if !ok {
+		return handler.CallbackAnswer{Text: "验证已结束"}, nil
+	}
+
+	now := h.now()
+	raid := h.raids != nil && h.raids.IsActive(q.ChatID)
+	held := pending.heldUntil.After(now)
+	if !raid {
+		// 防突袭模式中不改动禁言：入群后才触发的防突袭禁言到期时间未知，保持原样
+		err = h.release(ctx, q.ChatID, userID, pending.heldUntil, now)
+	}
+	if err != nil {
+		// 放回等待列表，成员可以再次点击
+		if remaining := pending.deadline.Sub(now); remaining > 0 {
+			h.pending.Set(key, pending, remaining)
+		}
+		return handler.CallbackAnswer{Text: "❌ 操作失败，请稍后重试", ShowAlert: true}, err
+	}
+
+	_ = h.api.EditMessageWithButtons(ctx, q.ChatID, pending.messageID,
+		fmt.Sprintf("✅ %s 已同意群规，欢迎加入！", html.EscapeString(pending.name)), nil)
+
+	if raid || held {
+		return handler.CallbackAnswer{Text: "✅ 已同意群规；群组的入群限制仍在生效，暂时还不能发言，禁言到期后自动解除", ShowAlert: true}, nil
+	}
+	return handler.CallbackAnswer{Text: "✅ 已同意群规，欢迎加入"}, nil
+}
+
+// heldUntil 防突袭模式和新账号限制对新成员的禁言到期时间，取较晚者；都没有时返回零值
+// 与 AntiRaidHandler、AccountAgeHandler 的判断一致，它们在本处理器之前执行
+func (h *RulesGateHandler) heldUntil(g *group.Group, userID int64, now time.Time) time.Time {
+	var until time.Time
+	if h.raids != nil && g.IsFeatureOptedIn(FeatureAntiRaid) && h.raids.IsActive(g.ID) {
+		until = now.Add(h.raidRestrict)
+	}
+	if g.MinAccountAge() > 0 {
+		if remaining, young := accountAgeShortfall(userID, g.MinAccountAge(), now); young && now.Add(remaining).After(until) {
+			until = now.Add(remaining)
+		}
+	}
+	return until
+}
+
+// release 解除同意群规的禁言；heldUntil 未到期时改为保持到 heldUntil，不提前解除其他限制
+func (h *RulesGateHandler) release(ctx context.Context, chatID, userID int64, heldUntil, now time.Time) error {
+	if heldUntil.After(now) {
+		return h.api.RestrictChatMemberWithDuration(ctx, chatID, userID, models.ChatPermissions{}, heldUntil)
+	}
+	return h.api.RestrictChatMember(ctx, chatID, userID, memberPermissions)
+}
+
+// kick 超时未同意时移出群组并更新群规消息（尽力而为，失败时禁言仍会在时限过后自动解除）
+func (h *RulesGateHandler) kick(key rulesAckKey, pending pendingRulesAck) {
+	ctx := context.Background()
+	if err := h.api.BanChatMemberWithDuration(ctx, key.chatID, key.userID, h.now().Add(rulesAckKickDuration)); err != nil {
+		return
+	}
+	_ = h.api.EditMessageWithButtons(ctx, key.chatID, pending.messageID,
+		fmt.Sprintf("⏰ %s 未在时限内同意群规，已被移出群组", html.EscapeString(pending.name)), nil)
+}
+
+// Priority 在新账号入群限制之后、命令之前执行
+func (h *RulesGateHandler) Priority() int {
+	return 62
+}
+
+// ContinueChain 总是继续
+func (h *RulesGateHandler) ContinueChain() bool {
+	return true
+}
@@ -0,0 +1,193 @@
+package listener
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRulesGateAPI 记录同意群规流程中的 Telegram 操作
+type fakeRulesGateAPI struct {
+	restricted   map[int64]time.Time // userID -> 禁言到期时间
+	unrestricted []int64
+	banned       []int64
+	buttons      [][]handler.Button
+	edits        []string
+}
+
+func newFakeRulesGateAPI() *fakeRulesGateAPI {
+	return &fakeRulesGateAPI{restricted: make(map[int64]time.Time)}
+}
+
+func (f *fakeRulesGateAPI) RestrictChatMemberWithDuration(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions, until time.Time) error {
+	f.restricted[userID] = until
+	return nil
+}
+
+func (f *fakeRulesGateAPI) RestrictChatMember(ctx context.Context, chatID, userID int64, permissions models.ChatPermissions) error {
+	f.unrestricted = append(f.unrestricted, userID)
+	return nil
+}
+
+func (f *fakeRulesGateAPI) BanChatMemberWithDuration(ctx context.Context, chatID, userID int64, until time.Time) error {
+	f.banned = append(f.banned, userID)
+	return nil
+}
+
+func (f *fakeRulesGateAPI) SendMessageWithButtons(ctx context.Context, chatID int64, text string, buttons [][]handler.Button) (int, error) {
+	f.buttons = buttons
+	return 42, nil
+}
+
+func (f *fakeRulesGateAPI) EditMessageWithButtons(ctx context.Context, chatID int64, messageID int, text string, buttons [][]handler.Button) error {
+	f.edits = append(f.edits, text)
+	return nil
+}
+
+func newRulesGateGroup(chatID int64) *group.Group {
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.SetSetting(group.SettingRules, "不要刷屏")
+	g.SetSetting(group.SettingRulesAckMinutes, 5)
+	return g
+}
+
+func TestRulesGateHandler_Agree(t *testing.T) {
+	const chatID = int64(-100123)
+	const member = int64(100_000_000)
+
+	api := newFakeRulesGateAPI()
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	h := NewRulesGateHandler(&fakeGroupReader{group: g}, api, nil, time.Hour)
+	defer h.Stop()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	// 未设置群规或时限时不处理
+	assert.False(t, h.Match(newJoinContext(chatID, member)))
+
+	g = newRulesGateGroup(chatID)
+	h.groupRepo = &fakeGroupReader{group: g}
+	ctx := newJoinContext(chatID, member)
+	require.True(t, h.Match(ctx))
+	require.NoError(t, h.Handle(ctx))
+
+	assert.Equal(t, now.Add(5*time.Minute+rulesAckGrace), api.restricted[member])
+	require.Len(t, api.buttons, 1)
+	data := api.buttons[0][0].Data
+	assert.Equal(t, "rules:100000000", data)
+
+	// 其他人点击按钮无效
+	answer, err := h.HandleCallback(context.Background(), &handler.CallbackQuery{Data: data, UserID: 7, ChatID: chatID, MessageID: 42})
+	require.NoError(t, err)
+	assert.True(t, answer.ShowAlert)
+	assert.Empty(t, api.unrestricted)
+
+	answer, err = h.HandleCallback(context.Background(), &handler.CallbackQuery{Data: data, UserID: member, ChatID: chatID, MessageID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, "✅ 已同意群规，欢迎加入", answer.Text)
+	assert.Equal(t, []int64{member}, api.unrestricted)
+	require.Len(t, api.edits, 1)
+	assert.Contains(t, api.edits[0], "已同意群规")
+
+	// 已同意后不会再被踢出
+	assert.Zero(t, h.pending.Len())
+	answer, err = h.HandleCallback(context.Background(), &handler.CallbackQuery{Data: data, UserID: member, ChatID: chatID, MessageID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, "验证已结束", answer.Text)
+	assert.Empty(t, api.banned)
+}
+
+func TestRulesGateHandler_AgreeKeepsAccountAgeRestriction(t *testing.T) {
+	const chatID = int64(-100123)
+	const member = int64(20_000_000_000)
+
+	api := newFakeRulesGateAPI()
+	g := newRulesGateGroup(chatID)
+	g.SetSetting(group.SettingMinAccountAgeDays, 7)
+	h := NewRulesGateHandler(&fakeGroupReader{group: g}, api, nil, time.Hour)
+	defer h.Stop()
+
+	// 新账号限制更长，不缩短
+	require.NoError(t, h.Handle(newJoinContext(chatID, member)))
+	assert.NotContains(t, api.restricted, member)
+
+	answer, err := h.HandleCallback(context.Background(), &handler.CallbackQuery{Data: api.buttons[0][0].Data, UserID: member, ChatID: chatID, MessageID: 42})
+	require.NoError(t, err)
+	assert.Contains(t, answer.Text, "暂时还不能发言")
+	assert.Empty(t, api.unrestricted)
+	assert.True(t, api.restricted[member].After(time.Now().Add(24*time.Hour)))
+}
+
+func TestRulesGateHandler_KeepsAntiRaidRestriction(t *testing.T) {
+	const chatID = int64(-100123)
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	detector := newTestRaidDetector(&now)
+	api := newFakeRulesGateAPI()
+	g := newRulesGateGroup(chatID)
+	g.EnableFeature(FeatureAntiRaid)
+	h := NewRulesGateHandler(&fakeGroupReader{group: g}, api, detector, time.Hour)
+	defer h.Stop()
+	h.now = func() time.Time { return now }
+
+	// 入群前未触发防突袭：同意群规的禁言正常生效
+	require.NoError(t, h.Handle(newJoinContext(chatID, 100_000_001)))
+	early := api.buttons[0][0].Data
+	assert.Equal(t, now.Add(5*time.Minute+rulesAckGrace), api.restricted[100_000_001])
+
+	// 防突袭模式中入群：已有 1 小时禁言，不缩短
+	detector.RecordJoin(chatID, 1)
+	detector.RecordJoin(chatID, 2)
+	detector.RecordJoin(chatID, 3)
+	require.True(t, detector.IsActive(chatID))
+	require.NoError(t, h.Handle(newJoinContext(chatID, 100_000_002)))
+	assert.NotContains(t, api.restricted, int64(100_000_002))
+
+	// 防突袭模式中同意群规不解除禁言
+	for _, q := range []*handler.CallbackQuery{
+		{Data: early, UserID: 100_000_001, ChatID: chatID, MessageID: 42},
+		{Data: api.buttons[0][0].Data, UserID: 100_000_002, ChatID: chatID, MessageID: 42},
+	} {
+		answer, err := h.HandleCallback(context.Background(), q)
+		require.NoError(t, err)
+		assert.Contains(t, answer.Text, "入群限制仍在生效")
+	}
+	assert.Empty(t, api.unrestricted)
+	assert.NotContains(t, api.restricted, int64(100_000_002))
+}
+
+func TestRulesGateHandler_TimeoutKick(t *testing.T) {
+	const chatID = int64(-100123)
+	const member = int64(100_000_000)
+
+	api := newFakeRulesGateAPI()
+	h := NewRulesGateHandler(&fakeGroupReader{group: newRulesGateGroup(chatID)}, api, nil, time.Hour)
+	defer h.Stop()
+
+	require.NoError(t, h.Handle(newJoinContext(chatID, member)))
+	assert.Equal(t, 0, h.pending.Sweep())
+	assert.Empty(t, api.banned)
+
+	// 时限到期
+	key := rulesAckKey{chatID: chatID, userID: member}
+	pending, ok := h.pending.Get(key)
+	require.True(t, ok)
+	h.pending.Set(key, pending, 0)
+	assert.Equal(t, 1, h.pending.Sweep())
+
+	assert.Equal(t, []int64{member}, api.banned)
+	require.Len(t, api.edits, 1)
+	assert.Contains(t, api.edits[0], "已被移出群组")
+
+	answer, err := h.HandleCallback(context.Background(), &handler.CallbackQuery{Data: api.buttons[0][0].Data, UserID: member, ChatID: chatID, MessageID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, "验证已结束", answer.Text)
+	assert.Empty(t, api.unrestricted)
+}
@@ -0,0 +1,83 @@
+package listener
+
+import (
+	"context"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/internal/middleware"
+)
+
+// GroupReader 群组查询接口
+type GroupReader interface {
+	FindByID(ctx context.Context, id int64) (*group.Group, error)
+}
+
+// UserReader 用户查询接口
+type UserReader interface {
+	FindByID(ctx context.Context, id int64) (*user.User, error)
+}
+
+// MessageDeleter 删除消息接口
+type MessageDeleter interface {
+	DeleteMessage(ctx context.Context, chatID int64, messageID int) error
+}
+
+// ShadowBanHandler 影子封禁处理器
+// 静默删除被影子封禁用户的消息，不做任何回复，对方不会察觉
+// 优先级高于命令，匹配后停止处理链，被封禁用户的命令也不会执行
+type ShadowBanHandler struct {
+	groupRepo GroupReader
+	userRepo  UserReader
+	deleter   MessageDeleter
+	logger    middleware.Logger
+}
+
+// NewShadowBanHandler 创建影子封禁处理器
+func NewShadowBanHandler(groupRepo GroupReader, userRepo UserReader, deleter MessageDeleter, logger middleware.Logger) *ShadowBanHandler {
+	return &ShadowBanHandler{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		deleter:   deleter,
+		logger:    logger,
+	}
+}
+
+// Match 群组中被影子封禁且不是管理员的用户的消息
+func (h *ShadowBanHandler) Match(ctx *handler.Context) bool {
+	// 以群组或频道身份发送的消息没有真实用户，不参与影子封禁
+	if !ctx.IsGroup() || ctx.UserID == 0 || ctx.IsSenderChat() {
+		return false
+	}
+
+	reqCtx := ctx.RequestContext()
+	g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
+	if err != nil || !g.IsShadowBanned(ctx.UserID) {
+		return false
+	}
+
+	// 管理员豁免（封禁后才被提升为管理员的情况）
+	u, err := h.userRepo.FindByID(reqCtx, ctx.UserID)
+	if err == nil && u.IsAdmin(ctx.ChatID) {
+		return false
+	}
+	return true
+}
+
+// Handle 删除消息，失败时只记录日志，不返回错误（错误会被回复到群里，暴露影子封禁）
+func (h *ShadowBanHandler) Handle(ctx *handler.Context) error {
+	if err := h.deleter.DeleteMessage(ctx.RequestContext(), ctx.ChatID, ctx.MessageID); err != nil {
+		h.logger.Error("shadowban_delete_failed", "chat_id", ctx.ChatID, "user_id", ctx.UserID, "message_id", ctx.MessageID, "error", err)
+	}
+	return nil
+}
+
+// Priority 在命令之前执行
+func (h *ShadowBanHandler) Priority() int {
+	return 50
+}
+
+// ContinueChain 消息已删除，不再交给其他处理器
+func (h *ShadowBanHandler) ContinueChain() bool {
+	return false
+}
@@ -0,0 +1,121 @@
+package listener
+
+import (
+	"context"
+	"testing"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGroupReader struct {
+	group *group.Group
+}
+
+func (f *fakeGroupReader) FindByID(ctx context.Context, id int64) (*group.Group, error) {
+	if f.group == nil || f.group.ID != id {
+		return nil, group.ErrGroupNotFound
+	}
+	return f.group, nil
+}
+
+type fakeUserReader struct {
+	users map[int64]*user.User
+}
+
+func (f *fakeUserReader) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	if u, ok := f.users[id]; ok {
+		return u, nil
+	}
+	return nil, user.ErrUserNotFound
+}
+
+type deletedMessage struct {
+	chatID    int64
+	messageID int
+}
+
+type fakeDeleter struct {
+	deleted []deletedMessage
+	err     error
+}
+
+func (f *fakeDeleter) DeleteMessage(ctx context.Context, chatID int64, messageID int) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, deletedMessage{chatID, messageID})
+	return nil
+}
+
+func TestShadowBanHandler(t *testing.T) {
+	const chatID = int64(-100123)
+
+	g := group.NewGroup(chatID, "Test Group", "supergroup")
+	g.ShadowBan(2)
+	g.ShadowBan(3)
+
+	admin := user.NewUser(3, "mod", "Mod", "")
+	admin.SetPermission(chatID, user.PermissionAdmin)
+
+	deleter := &fakeDeleter{}
+	h := NewShadowBanHandler(&fakeGroupReader{group: g}, &fakeUserReader{users: map[int64]*user.User{3: admin}}, deleter, nopLogger{})
+
+	router := handler.NewRouter()
+	router.Register(h)
+	replied := &replyRecorder{}
+	router.Register(replied)
+
+	newCtx := func(userID int64, messageID int) *handler.Context {
+		return &handler.Context{ChatType: "supergroup", ChatID: chatID, UserID: userID, MessageID: messageID, Text: "hi"}
+	}
+
+	t.Run("shadow-banned user's message is deleted silently", func(t *testing.T) {
+		require.NoError(t, router.Route(newCtx(2, 11)))
+		assert.Equal(t, []deletedMessage{{chatID, 11}}, deleter.deleted)
+		assert.Zero(t, replied.calls)
+	})
+
+	t.Run("normal user's message is kept", func(t *testing.T) {
+		deleter.deleted = nil
+		require.NoError(t, router.Route(newCtx(1, 12)))
+		assert.Empty(t, deleter.deleted)
+		assert.Equal(t, 1, replied.calls)
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		replied.calls = 0
+		require.NoError(t, router.Route(newCtx(3, 13)))
+		assert.Empty(t, deleter.deleted)
+		assert.Equal(t, 1, replied.calls)
+	})
+
+	t.Run("delete failure is not surfaced", func(t *testing.T) {
+		replied.calls = 0
+		deleter.err = assert.AnError
+		defer func() { deleter.err = nil }()
+
+		require.NoError(t, router.Route(newCtx(2, 15)))
+		assert.Zero(t, replied.calls)
+	})
+
+	t.Run("private chats are ignored", func(t *testing.T) {
+		ctx := newCtx(2, 14)
+		ctx.ChatType = "private"
+		assert.False(t, h.Match(ctx))
+	})
+}
+
+// replyRecorder 模拟后续的命令处理器
+type replyRecorder struct {
+	calls int
+}
+
+func (r *replyRecorder) Match(ctx *handler.Context) bool   { return true }
+func (r *replyRecorder) Handle(ctx *handler.Context) error { r.calls++; return nil }
+func (r *replyRecorder) Priority() int                     { return 100 }
+func (r *replyRecorder) ContinueChain() bool               { return false }
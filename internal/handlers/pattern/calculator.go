@@ -45,8 +45,8 @@ func (h *CalculatorHandler) Match(ctx *handler.Context) bool {
 		return false
 	}
 
-	// 2. 检查文本是否为空
-	if ctx.Text == "" {
+	// 2. 检查文本是否为空（编辑后的消息不重复计算）
+	if ctx.Text == "" || ctx.IsEdit {
 		return false
 	}
 
@@ -62,7 +62,7 @@ func (h *CalculatorHandler) Match(ctx *handler.Context) bool {
 
 	// 5. 检查群组是否启用了计算器功能
 	if h.groupRepo != nil {
-		reqCtx := context.TODO()
+		reqCtx := ctx.RequestContext()
 		g, err := h.groupRepo.FindByID(reqCtx, ctx.ChatID)
 		if err != nil {
 			// 群组不存在时默认启用
@@ -151,6 +151,18 @@ func TestCalculatorHandler_Match(t *testing.T) {
 			expected:     true,
 			mockRequired: true,
 		},
+		{
+			name: "does not match edited message",
+			ctx: &handler.Context{
+				Text:     "1+2",
+				ChatType: "group",
+				ChatID:   -1001234567890,
+				IsEdit:   true,
+			},
+			setupMock:    func() {},
+			expected:     false,
+			mockRequired: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"telegram-bot/internal/handler"
+)
+
+// InlineCalculator 内联计算器
+// 用户在任意聊天输入 "@botname 1+2*3" 时返回计算结果
+type InlineCalculator struct {
+	pattern *regexp.Regexp
+}
+
+// NewInlineCalculator 创建内联计算器
+func NewInlineCalculator() *InlineCalculator {
+	return &InlineCalculator{
+		pattern: regexp.MustCompile(`^[\d\s\.\+\-\*/\(\)]+$`),
+	}
+}
+
+// Match 查询内容为数学表达式时匹配
+func (c *InlineCalculator) Match(q *handler.InlineQuery) bool {
+	expr := strings.TrimSpace(q.Query)
+	return expr != "" && c.pattern.MatchString(expr) && containsOperator(expr)
+}
+
+// Results 返回计算结果（表达式无效时不返回结果）
+func (c *InlineCalculator) Results(ctx context.Context, q *handler.InlineQuery) ([]handler.InlineResult, error) {
+	expr := strings.TrimSpace(q.Query)
+	result, err := evaluateExpression(expr)
+	if err != nil {
+		return nil, nil
+	}
+
+	value := formatNumber(result)
+	return []handler.InlineResult{{
+		ID:          "calc",
+		Title:       "= " + value,
+		Description: expr,
+		Text:        fmt.Sprintf("<code>%s</code> = <b>%s</b>", html.EscapeString(expr), value),
+	}}, nil
+}
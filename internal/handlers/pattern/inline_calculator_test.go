@@ -0,0 +1,45 @@
+package pattern
+
+import (
+	"context"
+	"testing"
+
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineCalculator_Match(t *testing.T) {
+	c := NewInlineCalculator()
+
+	assert.True(t, c.Match(&handler.InlineQuery{Query: "1+2"}))
+	assert.True(t, c.Match(&handler.InlineQuery{Query: " (2+3)*4 "}))
+	assert.False(t, c.Match(&handler.InlineQuery{Query: ""}))
+	assert.False(t, c.Match(&handler.InlineQuery{Query: "123"}))
+	assert.False(t, c.Match(&handler.InlineQuery{Query: "rules"}))
+}
+
+func TestInlineCalculator_Results(t *testing.T) {
+	c := NewInlineCalculator()
+
+	results, err := c.Results(context.Background(), &handler.InlineQuery{Query: "2*3+1"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "= 7", results[0].Title)
+	assert.Equal(t, "<code>2*3+1</code> = <b>7</b>", results[0].Text)
+
+	results, err = c.Results(context.Background(), &handler.InlineQuery{Query: "1/0"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestInlineRouter_RoutesToCalculator(t *testing.T) {
+	router := handler.NewInlineRouter()
+	router.Register(NewInlineCalculator())
+
+	results, err := router.Route(context.Background(), &handler.InlineQuery{ID: "q1", Query: "10/4"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "= 2.5", results[0].Title)
+}
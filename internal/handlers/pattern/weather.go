@@ -28,6 +28,11 @@ func (h *WeatherHandler) Match(ctx *handler.Context) bool {
 		return false
 	}
 
+	// 编辑后的消息不重复回复
+	if ctx.IsEdit {
+		return false
+	}
+
 	return h.pattern.MatchString(ctx.Text)
 }
 
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Checker 依赖检查（例如 MongoDB、Telegram API）
+type Checker interface {
+	// Name 检查名称，作为 /health 响应中 checks 的键
+	Name() string
+	// Check 执行检查，返回 nil 表示健康
+	Check(ctx context.Context) error
+}
+
+// SimpleChecker 由函数实现的检查
+type SimpleChecker struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewSimpleChecker 创建由函数实现的检查
+func NewSimpleChecker(name string, fn func(ctx context.Context) error) *SimpleChecker {
+	return &SimpleChecker{name: name, fn: fn}
+}
+
+// Name 检查名称
+func (c *SimpleChecker) Name() string {
+	return c.name
+}
+
+// Check 执行检查
+func (c *SimpleChecker) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
+
+// CachedChecker 缓存检查结果的包装器
+// ttl 内的探针直接返回上次的结果，避免频繁的 /health 请求压垮依赖（例如每次都 ping MongoDB）；
+// 检查进行中时并发的探针会等待同一次检查完成，而不是各自再发起一次
+type CachedChecker struct {
+	checker Checker
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	checked   bool
+	lastErr   error
+	checkedAt time.Time
+	now       func() time.Time
+}
+
+// NewCachedChecker 创建缓存检查结果的包装器
+func NewCachedChecker(checker Checker, ttl time.Duration) *CachedChecker {
+	return &CachedChecker{
+		checker: checker,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Name 检查名称（与被包装的检查相同）
+func (c *CachedChecker) Name() string {
+	return c.checker.Name()
+}
+
+// Check 缓存有效时返回上次的结果，否则执行检查并缓存
+func (c *CachedChecker) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.checked && c.now().Sub(c.checkedAt) < c.ttl {
+		return c.lastErr
+	}
+
+	c.lastErr = c.checker.Check(ctx)
+	c.checkedAt = c.now()
+	c.checked = true
+	return c.lastErr
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingChecker 记录检查次数
+func countingChecker(calls *int, err *error) *SimpleChecker {
+	return NewSimpleChecker("mongodb", func(ctx context.Context) error {
+		*calls++
+		return *err
+	})
+}
+
+func TestCachedChecker_CachesWithinTTL(t *testing.T) {
+	calls := 0
+	var checkErr error
+	c := NewCachedChecker(countingChecker(&calls, &checkErr), 10*time.Second)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	assert.Equal(t, "mongodb", c.Name())
+
+	// TTL 内只执行一次，后续返回缓存结果（包括错误）
+	checkErr = assert.AnError
+	assert.Equal(t, assert.AnError, c.Check(context.Background()))
+	checkErr = nil
+	now = now.Add(9 * time.Second)
+	assert.Equal(t, assert.AnError, c.Check(context.Background()))
+	assert.Equal(t, 1, calls)
+
+	// 过期后重新执行
+	now = now.Add(time.Second)
+	assert.NoError(t, c.Check(context.Background()))
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, c.Check(context.Background()))
+	assert.Equal(t, 2, calls)
+}
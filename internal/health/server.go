@@ -0,0 +1,229 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InfoFunc 返回附加到健康状态中的信息
+type InfoFunc func() interface{}
+
+// checkTimeout 单次 /health 请求中依赖检查的超时时间
+const checkTimeout = 5 * time.Second
+
+// Server 健康检查 HTTP 服务
+// /health/live 用于存活探针，总是立即返回；
+// /health 执行注册的依赖检查（启动后台检查后直接返回最近一轮的结果），
+// 返回运行状态及注册的附加信息（例如主节点身份），任一检查失败时状态为 unhealthy 并返回 503；
+// /debug/{name} 返回通过 AddDebug 注册的调试信息（会暴露内部实现，默认不注册）
+type Server struct {
+	server    *http.Server
+	startTime time.Time
+
+	mu       sync.RWMutex
+	checkers []Checker
+	info     map[string]InfoFunc
+	debug    map[string]InfoFunc
+	last     *checkResult // 后台检查的最近结果（未启动后台检查时为 nil）
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// checkResult 一轮依赖检查的结果
+type checkResult struct {
+	status    string
+	code      int
+	checks    map[string]string
+	checkedAt time.Time
+}
+
+// NewServer 创建健康检查服务
+func NewServer(addr string) *Server {
+	s := &Server{
+		startTime: time.Now(),
+		info:      make(map[string]InfoFunc),
+		debug:     make(map[string]InfoFunc),
+	}
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// AddChecker 注册依赖检查，每次请求 /health 时执行（启动后台检查后改由后台执行；
+// 开销较大的检查可用 CachedChecker 包装）
+func (s *Server) AddChecker(c Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkers = append(s.checkers, c)
+}
+
+// AddInfo 注册附加信息，每次请求 /health 时调用 fn 获取最新值
+func (s *Server) AddInfo(name string, fn InfoFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info[name] = fn
+}
+
+// AddDebug 注册调试接口 /debug/{name}，每次请求时调用 fn 获取最新值
+func (s *Server) AddDebug(name string, fn InfoFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.debug[name] = fn
+}
+
+// Handler 返回 HTTP 处理器
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health/live", s.handleLive)
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/debug/", s.handleDebug)
+	return mux
+}
+
+// Start 在后台启动 HTTP 服务，监听失败时通过 errFn 报告
+func (s *Server) Start(errFn func(error)) {
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errFn(err)
+		}
+	}()
+}
+
+// StartBackground 启动后台依赖检查，每隔 interval 执行一轮并保存结果
+// 启动后 /health 直接返回最近一轮的结果，探针延迟不再受依赖延迟影响；
+// 首轮检查在返回前同步完成，保证 /health 从一开始就有结果
+func (s *Server) StartBackground(interval time.Duration) {
+	s.refresh()
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.refresh()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// refresh 执行一轮依赖检查并保存结果
+func (s *Server) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	result := s.runChecks(ctx)
+
+	s.mu.Lock()
+	s.last = result
+	s.mu.Unlock()
+}
+
+// Shutdown 停止后台检查并关闭 HTTP 服务
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+	return s.server.Shutdown(ctx)
+}
+
+// handleLive 存活探针
+func (s *Server) handleLive(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// handleHealth 运行状态
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.info))
+	for name := range s.info {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	info := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		info[name] = s.info[name]()
+	}
+	result := s.last
+	s.mu.RUnlock()
+
+	// 未启动后台检查时在请求中执行
+	if result == nil {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+		result = s.runChecks(ctx)
+	}
+
+	writeJSON(w, result.code, map[string]interface{}{
+		"status":         result.status,
+		"uptime_seconds": int(time.Since(s.startTime).Seconds()),
+		"checks":         result.checks,
+		"checked_at":     result.checkedAt.UTC().Format(time.RFC3339),
+		"info":           info,
+	})
+}
+
+// runChecks 执行所有依赖检查，任一失败时状态为 unhealthy
+func (s *Server) runChecks(ctx context.Context) *checkResult {
+	s.mu.RLock()
+	checkers := make([]Checker, len(s.checkers))
+	copy(checkers, s.checkers)
+	s.mu.RUnlock()
+
+	result := &checkResult{
+		status: "ok",
+		code:   http.StatusOK,
+		checks: make(map[string]string, len(checkers)),
+	}
+	for _, c := range checkers {
+		if err := c.Check(ctx); err != nil {
+			result.checks[c.Name()] = err.Error()
+			result.status, result.code = "unhealthy", http.StatusServiceUnavailable
+			continue
+		}
+		result.checks[c.Name()] = "ok"
+	}
+	result.checkedAt = time.Now()
+	return result
+}
+
+// handleDebug 调试信息，未注册的名称返回 404
+func (s *Server) handleDebug(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	fn, ok := s.debug[strings.TrimPrefix(r.URL.Path, "/debug/")]
+	s.mu.RUnlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": "not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, fn())
+}
+
+// writeJSON 输出 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,159 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/handler"
+	"telegram-bot/internal/handlers/command"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Live(t *testing.T) {
+	s := NewServer(":0")
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestServer_HealthIncludesInfo(t *testing.T) {
+	s := NewServer(":0")
+
+	leader := false
+	s.AddInfo("leader", func() interface{} { return leader })
+
+	get := func() map[string]interface{} {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	body := get()
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, map[string]interface{}{"leader": false}, body["info"])
+
+	// 每次请求读取最新状态
+	leader = true
+	assert.Equal(t, map[string]interface{}{"leader": true}, get()["info"])
+}
+
+func TestServer_HealthRunsCheckers(t *testing.T) {
+	s := NewServer(":0")
+	var mongoErr error
+	s.AddChecker(NewSimpleChecker("mongodb", func(ctx context.Context) error { return mongoErr }))
+	s.AddChecker(NewSimpleChecker("telegram", func(ctx context.Context) error { return nil }))
+
+	get := func() (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	code, body := get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, map[string]interface{}{"mongodb": "ok", "telegram": "ok"}, body["checks"])
+
+	// 任一检查失败时返回 503
+	mongoErr = assert.AnError
+	code, body = get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unhealthy", body["status"])
+	assert.Equal(t, map[string]interface{}{"mongodb": assert.AnError.Error(), "telegram": "ok"}, body["checks"])
+}
+
+func TestServer_BackgroundServesLastResult(t *testing.T) {
+	s := NewServer(":0")
+	var calls atomic.Int32
+	s.AddChecker(NewSimpleChecker("mongodb", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+
+	// 间隔足够长，只有启动时的首轮检查
+	s.StartBackground(time.Hour)
+	defer s.Shutdown(context.Background())
+	require.Equal(t, int32(1), calls.Load())
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// /health 不在请求中执行检查
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestServer_BackgroundUpdatesStatus(t *testing.T) {
+	s := NewServer(":0")
+	var failing atomic.Bool
+	s.AddChecker(NewSimpleChecker("mongodb", func(ctx context.Context) error {
+		if failing.Load() {
+			return assert.AnError
+		}
+		return nil
+	}))
+
+	s.StartBackground(5 * time.Millisecond)
+	defer s.Shutdown(context.Background())
+
+	status := func() int {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusOK, status())
+
+	failing.Store(true)
+	assert.Eventually(t, func() bool { return status() == http.StatusServiceUnavailable }, time.Second, 5*time.Millisecond)
+
+	failing.Store(false)
+	assert.Eventually(t, func() bool { return status() == http.StatusOK }, time.Second, 5*time.Millisecond)
+}
+
+func TestServer_DebugCommands(t *testing.T) {
+	s := NewServer(":0")
+
+	router := handler.NewRouter()
+	router.Register(command.NewPingHandler(nil))
+	s.AddDebug("commands", func() interface{} { return router.Describe() })
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/commands", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var infos []handler.HandlerInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, "ping", infos[0].Command)
+	assert.Equal(t, "User", infos[0].Permission)
+	assert.Equal(t, "*command.PingHandler", infos[0].Type)
+	assert.Equal(t, 100, infos[0].Priority)
+}
+
+func TestServer_DebugNotRegistered(t *testing.T) {
+	s := NewServer(":0")
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/commands", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
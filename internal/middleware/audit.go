@@ -0,0 +1,43 @@
+package middleware
+
+import "telegram-bot/internal/handler"
+
+// AuditMiddleware 管理操作审计中间件
+// 处理器通过 ctx.RecordAction 记录的每个管理操作都写一条 admin_action 日志，
+// 包含操作者、命令和操作对象；处理器返回错误时已执行的操作也会记录
+type AuditMiddleware struct {
+	logger Logger
+}
+
+// NewAuditMiddleware 创建管理操作审计中间件
+func NewAuditMiddleware(logger Logger) *AuditMiddleware {
+	return &AuditMiddleware{logger: logger}
+}
+
+// Middleware 返回中间件函数
+func (m *AuditMiddleware) Middleware() handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(ctx *handler.Context) error {
+			// 同一条消息可能经过多个处理器，只记录本次处理器新增的操作
+			before := len(ctx.Actions())
+			err := next(ctx)
+
+			var command string
+			if named, ok := ctx.Handler.(NamedCommand); ok {
+				command = named.GetName()
+			}
+			for _, action := range ctx.Actions()[before:] {
+				m.logger.Info("admin_action",
+					"request_id", ctx.RequestID,
+					"chat_id", ctx.ChatID,
+					"user_id", ctx.UserID,
+					"command", command,
+					"action", action.Action,
+					"target_id", action.TargetID,
+					"message", action.Message,
+				)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"testing"
+
+	"telegram-bot/internal/handler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	log := &recordingLogger{}
+	router := handler.NewRouter()
+	router.Use(NewAuditMiddleware(log).Middleware())
+
+	// 记录操作的命令，以及只返回 error 的监听器
+	router.Register(&stubHandler{priority: 50, continueChain: true, handle: func(ctx *handler.Context) error {
+		return nil
+	}})
+	router.Register(&namedHandler{name: "tempban", stubHandler: stubHandler{priority: 100, handle: func(ctx *handler.Context) error {
+		ctx.RecordAction(handler.ActionResult{Action: "tempban", TargetID: 42, Message: "1h: 刷屏"})
+		return nil
+	}}})
+
+	ctx := &handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: 1, Text: "/tempban"}
+	require.NoError(t, router.Route(ctx))
+
+	require.Len(t, log.entries, 1)
+	entry := log.entries[0]
+	assert.Equal(t, "admin_action", entry.msg)
+	assert.Equal(t, "tempban", entry.fields["command"])
+	assert.Equal(t, "tempban", entry.fields["action"])
+	assert.Equal(t, int64(42), entry.fields["target_id"])
+	assert.Equal(t, "1h: 刷屏", entry.fields["message"])
+	assert.Equal(t, int64(1), entry.fields["user_id"])
+	assert.Equal(t, []handler.ActionResult{{Action: "tempban", TargetID: 42, Message: "1h: 刷屏"}}, ctx.Actions())
+
+	// 没有记录操作的消息不写审计日志
+	require.NoError(t, router.Route(&handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: 1, Text: "hello"}))
+	assert.Len(t, log.entries, 1)
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+)
+
+// NamedCommand 可选接口：按命令名配置冷却时间（command.BaseCommand 实现）
+type NamedCommand interface {
+	GetName() string
+}
+
+// CooldownExempt 可选接口：命令的部分用法不受冷却限制（如 /feedback list）
+type CooldownExempt interface {
+	CooldownExempt(ctx *handler.Context) bool
+}
+
+// cooldownKey 冷却记录的键
+type cooldownKey struct {
+	userID  int64
+	command string
+}
+
+// CooldownMiddleware 命令冷却中间件
+// 按 (用户, 命令名) 限制配置了冷却时间的命令，冷却期内再次调用返回 RATE_LIMIT 错误并附带剩余时间；
+// 与全局限流互相独立。执行前先占用冷却，并发调用只有一个能通过；用法错误等失败的调用退还冷却
+type CooldownMiddleware struct {
+	cooldowns map[string]time.Duration // 命令名 -> 冷却时间
+
+	mu       sync.Mutex // 保证检查和记录是原子的
+	lastUsed *ttlmap.Map[cooldownKey, time.Time]
+	now      func() time.Time
+}
+
+// NewCooldownMiddleware 创建命令冷却中间件，并启动冷却记录的清理任务
+func NewCooldownMiddleware(cooldowns map[string]time.Duration) *CooldownMiddleware {
+	m := &CooldownMiddleware{
+		cooldowns: cooldowns,
+		lastUsed:  ttlmap.New[cooldownKey, time.Time](nil),
+		now:       time.Now,
+	}
+	m.lastUsed.StartSweeper(time.Minute)
+	return m
+}
+
+// Stop 停止冷却记录的清理任务
+func (m *CooldownMiddleware) Stop() {
+	m.lastUsed.Stop()
+}
+
+// Middleware 返回中间件函数
+func (m *CooldownMiddleware) Middleware() handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(ctx *handler.Context) error {
+			named, ok := ctx.Handler.(NamedCommand)
+			if !ok {
+				return next(ctx)
+			}
+			cooldown, ok := m.cooldowns[named.GetName()]
+			if !ok || cooldown <= 0 {
+				return next(ctx)
+			}
+			if exempt, ok := ctx.Handler.(CooldownExempt); ok && exempt.CooldownExempt(ctx) {
+				return next(ctx)
+			}
+
+			key := cooldownKey{userID: ctx.UserID, command: named.GetName()}
+			if remaining, ok := m.claim(key, cooldown); !ok {
+				return errors.New(errors.CodeRateLimit, "command cooldown").
+					WithContext("retry_after", formatRetryAfter(remaining))
+			}
+
+			if err := next(ctx); err != nil {
+				// 失败的调用退还冷却（占用期间其他调用都被拒绝，可以直接删除）
+				m.lastUsed.Delete(key)
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// claim 检查冷却并记录本次使用，冷却中时返回剩余时间和 false
+func (m *CooldownMiddleware) claim(key cooldownKey, cooldown time.Duration) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if last, ok := m.lastUsed.Get(key); ok {
+		if remaining := cooldown - now.Sub(last); remaining > 0 {
+			return remaining, false
+		}
+	}
+	m.lastUsed.Set(key, now, cooldown)
+	return 0, true
+}
+
+// formatRetryAfter 格式化剩余冷却时间：不足一分钟按秒，否则按分钟（向上取整）
+func formatRetryAfter(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%d 秒", int((d+time.Second-1)/time.Second))
+	}
+	return fmt.Sprintf("%d 分钟", int((d+time.Minute-1)/time.Minute))
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedHandler 只匹配 "/<name>" 的命令处理器
+type namedHandler struct {
+	stubHandler
+	name string
+}
+
+func (h *namedHandler) Match(ctx *handler.Context) bool { return ctx.Text == "/"+h.name }
+func (h *namedHandler) GetName() string                 { return h.name }
+
+// newCooldownRouter 注册 report（冷却 5 分钟）和 ping（未配置冷却）两个命令
+func newCooldownRouter(t *testing.T, now *time.Time, fail *error) (*handler.Router, map[string]int) {
+	mw := NewCooldownMiddleware(map[string]time.Duration{"report": 5 * time.Minute})
+	t.Cleanup(mw.Stop)
+	mw.now = func() time.Time { return *now }
+
+	calls := map[string]int{}
+	router := handler.NewRouter()
+	router.Use(mw.Middleware())
+	for _, name := range []string{"report", "ping"} {
+		router.Register(&namedHandler{name: name, stubHandler: stubHandler{handle: func(ctx *handler.Context) error {
+			calls[name]++
+			return *fail
+		}}})
+	}
+	return router, calls
+}
+
+func routeCommand(router *handler.Router, userID int64, command string) error {
+	return router.Route(&handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: userID, Text: "/" + command})
+}
+
+func TestCooldownMiddleware(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var fail error
+	router, calls := newCooldownRouter(t, &now, &fail)
+
+	require.NoError(t, routeCommand(router, 1, "report"))
+
+	// 冷却期内再次调用被拒绝，并附带剩余时间
+	now = now.Add(2 * time.Minute)
+	err := routeCommand(router, 1, "report")
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.CodeRateLimit))
+	assert.Contains(t, handler.UserMessage(err), "请在 3 分钟 后再试")
+
+	now = now.Add(2*time.Minute + 50*time.Second)
+	err = routeCommand(router, 1, "report")
+	assert.Contains(t, handler.UserMessage(err), "请在 10 秒 后再试")
+
+	// 其他用户和未配置冷却的命令不受影响
+	require.NoError(t, routeCommand(router, 2, "report"))
+	require.NoError(t, routeCommand(router, 1, "ping"))
+	require.NoError(t, routeCommand(router, 1, "ping"))
+
+	// 冷却结束后可以再次使用
+	now = now.Add(10 * time.Second)
+	require.NoError(t, routeCommand(router, 1, "report"))
+
+	assert.Equal(t, map[string]int{"report": 3, "ping": 2}, calls)
+}
+
+func TestCooldownMiddleware_FailedCallNotCounted(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var fail error = errors.Validation("", "bad args")
+	router, calls := newCooldownRouter(t, &now, &fail)
+
+	require.Error(t, routeCommand(router, 1, "report"))
+
+	fail = nil
+	require.NoError(t, routeCommand(router, 1, "report"))
+	assert.Equal(t, 2, calls["report"])
+}
+
+func TestCooldownMiddleware_ClaimedBeforeHandler(t *testing.T) {
+	mw := NewCooldownMiddleware(map[string]time.Duration{"report": 5 * time.Minute})
+	t.Cleanup(mw.Stop)
+
+	router := handler.NewRouter()
+	router.Use(mw.Middleware())
+
+	// 处理期间同一用户的并发调用已被拒绝
+	calls := 0
+	var inner error
+	router.Register(&namedHandler{name: "report", stubHandler: stubHandler{handle: func(ctx *handler.Context) error {
+		calls++
+		if calls == 1 {
+			inner = routeCommand(router, 1, "report")
+		}
+		return nil
+	}}})
+
+	require.NoError(t, routeCommand(router, 1, "report"))
+	assert.True(t, errors.HasCode(inner, errors.CodeRateLimit))
+	assert.Equal(t, 1, calls)
+}
+
+// exemptHandler "/report list" 不受冷却限制
+type exemptHandler struct {
+	namedHandler
+}
+
+func (h *exemptHandler) Match(ctx *handler.Context) bool {
+	return strings.HasPrefix(ctx.Text, "/report")
+}
+
+func (h *exemptHandler) CooldownExempt(ctx *handler.Context) bool {
+	return ctx.Text == "/report list"
+}
+
+func TestCooldownMiddleware_Exempt(t *testing.T) {
+	mw := NewCooldownMiddleware(map[string]time.Duration{"report": 5 * time.Minute})
+	t.Cleanup(mw.Stop)
+
+	calls := 0
+	router := handler.NewRouter()
+	router.Use(mw.Middleware())
+	router.Register(&exemptHandler{namedHandler{name: "report", stubHandler: stubHandler{handle: func(ctx *handler.Context) error {
+		calls++
+		return nil
+	}}}})
+
+	route := func(text string) error {
+		return router.Route(&handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: 1, Text: text})
+	}
+	require.NoError(t, route("/report list"))
+	require.NoError(t, route("/report list"))
+	require.NoError(t, route("/report spam"))
+	assert.Error(t, route("/report spam"))
+	assert.Equal(t, 3, calls)
+}
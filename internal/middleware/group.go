@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
 	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/handler"
@@ -33,8 +32,7 @@ func (m *GroupMiddleware) Middleware() handler.Middleware {
 				return next(ctx)
 			}
 
-			// 创建 context（TODO: 从 handler.Context 传递）
-			reqCtx := context.TODO()
+			reqCtx := ctx.RequestContext()
 
 			// 1. 尝试加载群组
 			g, err := m.groupRepo.FindByID(reqCtx, ctx.ChatID)
@@ -49,12 +47,13 @@ func (m *GroupMiddleware) Middleware() handler.Middleware {
 				if err := m.groupRepo.Save(reqCtx, g); err != nil {
 					// 创建失败，记录错误并返回错误，不允许继续执行
 					m.logger.Error("failed_to_create_group",
+						"request_id", ctx.RequestID,
 						"error", err.Error(),
 						"chat_id", ctx.ChatID,
 						"chat_title", ctx.ChatTitle,
 						"chat_type", ctx.ChatType,
 					)
-					return fmt.Errorf("failed to create group: %w", err)
+					return fmt.Errorf("failed to create group [request_id=%s]: %w", ctx.RequestID, err)
 				}
 			}
 
@@ -1,5 +1,7 @@
 package middleware
 
+import "telegram-bot/pkg/logger"
+
 // Logger 日志接口
 type Logger interface {
 	Debug(msg string, fields ...interface{})
@@ -7,3 +9,49 @@ type Logger interface {
 	Warn(msg string, fields ...interface{})
 	Error(msg string, fields ...interface{})
 }
+
+// Redactor 日志脱敏接口（由 logger.Sanitizer 实现）
+// 用于记录消息文本前遮盖 Email、手机号、Token 等敏感内容
+type Redactor interface {
+	Sanitize(text string) string
+}
+
+// redact 使用 r 脱敏文本，r 为 nil 时原样返回
+func redact(r Redactor, text string) string {
+	if r == nil {
+		return text
+	}
+	return r.Sanitize(text)
+}
+
+// fieldBinder 支持绑定字段的 Logger（logger.Logger 实现了此接口）
+type fieldBinder interface {
+	With(fields ...interface{}) logger.Logger
+}
+
+// withFields 返回绑定了字段的 Logger，之后每次调用都会带上这些字段
+// 不支持 With 的 Logger 在每次调用时把字段追加到参数前
+func withFields(l Logger, fields ...interface{}) Logger {
+	if b, ok := l.(fieldBinder); ok {
+		return b.With(fields...)
+	}
+	return &boundLogger{next: l, fields: fields}
+}
+
+// boundLogger 为不支持 With 的 Logger 绑定字段
+type boundLogger struct {
+	next   Logger
+	fields []interface{}
+}
+
+func (l *boundLogger) Debug(msg string, fields ...interface{}) { l.next.Debug(msg, l.with(fields)...) }
+func (l *boundLogger) Info(msg string, fields ...interface{})  { l.next.Info(msg, l.with(fields)...) }
+func (l *boundLogger) Warn(msg string, fields ...interface{})  { l.next.Warn(msg, l.with(fields)...) }
+func (l *boundLogger) Error(msg string, fields ...interface{}) { l.next.Error(msg, l.with(fields)...) }
+
+// with 绑定字段在前，调用参数在后
+func (l *boundLogger) with(fields []interface{}) []interface{} {
+	all := make([]interface{}, 0, len(l.fields)+len(fields))
+	all = append(all, l.fields...)
+	return append(all, fields...)
+}
@@ -1,13 +1,18 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/logger"
 	"time"
 )
 
 // LoggingMiddleware 日志中间件
 type LoggingMiddleware struct {
-	logger Logger
+	logger   Logger
+	redactor Redactor // 可为 nil（不脱敏）
 }
 
 // NewLoggingMiddleware 创建日志中间件
@@ -15,36 +20,56 @@ func NewLoggingMiddleware(logger Logger) *LoggingMiddleware {
 	return &LoggingMiddleware{logger: logger}
 }
 
+// SetRedactor 设置消息文本的脱敏器（nil 表示不脱敏）
+func (m *LoggingMiddleware) SetRedactor(r Redactor) {
+	m.redactor = r
+}
+
 // Middleware 返回中间件函数
 func (m *LoggingMiddleware) Middleware() handler.Middleware {
 	return func(next handler.HandlerFunc) handler.HandlerFunc {
 		return func(ctx *handler.Context) error {
 			start := time.Now()
 
-			m.logger.Info("message_received",
-				"chat_type", ctx.ChatType,
+			// 为消息分配请求ID（同一条消息匹配多个处理器时复用）
+			ensureRequestID(ctx)
+
+			// 同一条消息的日志都带上请求ID、聊天和用户
+			log := withFields(m.logger,
+				"request_id", ctx.RequestID,
 				"chat_id", ctx.ChatID,
 				"user_id", ctx.UserID,
+			)
+
+			log.Info("message_received",
+				"chat_type", ctx.ChatType,
 				"username", ctx.Username,
-				"text", ctx.Text,
+				"text", redact(m.redactor, ctx.Text),
 			)
 
 			err := next(ctx)
 
 			duration := time.Since(start)
 
-			if err != nil {
-				m.logger.Error("handler_error",
+			if errors.Is(ctx.RequestContext().Err(), context.DeadlineExceeded) {
+				// 超过单条消息的处理期限，处理器被取消
+				log.Error("handler_deadline_exceeded",
+					"error", fmt.Sprint(err),
+					"duration_ms", duration.Milliseconds(),
+				)
+			} else if errors.Is(err, handler.ErrUsage) {
+				// 参数有误，Router 会回复用法说明，不是处理失败
+				log.Info("handler_usage",
+					"duration_ms", duration.Milliseconds(),
+				)
+			} else if err != nil {
+				log.Error("handler_error",
 					"error", err.Error(),
 					"duration_ms", duration.Milliseconds(),
-					"chat_id", ctx.ChatID,
-					"user_id", ctx.UserID,
 				)
 			} else {
-				m.logger.Info("handler_success",
+				log.Info("handler_success",
 					"duration_ms", duration.Milliseconds(),
-					"chat_id", ctx.ChatID,
-					"user_id", ctx.UserID,
 				)
 			}
 
@@ -52,3 +77,16 @@ func (m *LoggingMiddleware) Middleware() handler.Middleware {
 		}
 	}
 }
+
+// ensureRequestID 生成请求ID并写入 ctx.Ctx，便于下游通过 logger.WithContext 获取
+func ensureRequestID(ctx *handler.Context) {
+	if ctx.RequestID != "" {
+		return
+	}
+
+	ctx.RequestID = logger.GenerateTraceID()
+	if ctx.Ctx == nil {
+		ctx.Ctx = context.Background()
+	}
+	ctx.Ctx = logger.WithTraceID(ctx.Ctx, ctx.RequestID)
+}
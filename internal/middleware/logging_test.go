@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logEntry 记录的一条日志
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger 记录所有日志，便于断言字段
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, fields []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := make(map[string]interface{})
+	for i := 0; i+1 < len(fields); i += 2 {
+		if key, ok := fields[i].(string); ok {
+			m[key] = fields[i+1]
+		}
+	}
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: m})
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...interface{}) { l.record("debug", msg, fields) }
+func (l *recordingLogger) Info(msg string, fields ...interface{})  { l.record("info", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...interface{})  { l.record("warn", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...interface{}) { l.record("error", msg, fields) }
+
+// stubHandler 可配置的测试处理器
+type stubHandler struct {
+	priority      int
+	continueChain bool
+	handle        func(ctx *handler.Context) error
+}
+
+func (h *stubHandler) Match(ctx *handler.Context) bool   { return true }
+func (h *stubHandler) Handle(ctx *handler.Context) error { return h.handle(ctx) }
+func (h *stubHandler) Priority() int                     { return h.priority }
+func (h *stubHandler) ContinueChain() bool               { return h.continueChain }
+
+func TestLoggingMiddleware_RequestID(t *testing.T) {
+	log := &recordingLogger{}
+	router := handler.NewRouter()
+	router.Use(NewLoggingMiddleware(log).Middleware())
+
+	// 记录中间件之后看到的请求ID
+	var seenByMiddleware []string
+	router.Use(func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(ctx *handler.Context) error {
+			seenByMiddleware = append(seenByMiddleware, ctx.RequestID)
+			return next(ctx)
+		}
+	})
+
+	// 两个处理器都匹配同一条消息
+	var seenByHandlers []string
+	var seenInCtx []string
+	record := func(ctx *handler.Context) error {
+		seenByHandlers = append(seenByHandlers, ctx.RequestID)
+		seenInCtx = append(seenInCtx, logger.GetTraceID(ctx.Ctx))
+		return nil
+	}
+	router.Register(&stubHandler{priority: 900, continueChain: true, handle: record})
+	router.Register(&stubHandler{priority: 910, continueChain: true, handle: record})
+
+	ctx := &handler.Context{Ctx: context.Background(), ChatID: -100, UserID: 1, Text: "hello"}
+	require.NoError(t, router.Route(ctx))
+
+	requestID := ctx.RequestID
+	require.NotEmpty(t, requestID)
+
+	// 整个处理链中的请求ID保持一致
+	assert.Equal(t, []string{requestID, requestID}, seenByMiddleware)
+	assert.Equal(t, []string{requestID, requestID}, seenByHandlers)
+	assert.Equal(t, []string{requestID, requestID}, seenInCtx)
+
+	// 所有日志都带有 request_id 字段
+	require.NotEmpty(t, log.entries)
+	for _, entry := range log.entries {
+		assert.Equal(t, requestID, entry.fields["request_id"], entry.msg)
+	}
+}
+
+func TestLoggingMiddleware_RequestIDInErrorLog(t *testing.T) {
+	log := &recordingLogger{}
+	router := handler.NewRouter()
+	router.Use(NewLoggingMiddleware(log).Middleware())
+	router.Register(&stubHandler{priority: 100, handle: func(ctx *handler.Context) error {
+		return assert.AnError
+	}})
+
+	ctx := &handler.Context{ChatID: -100, UserID: 1}
+	assert.Error(t, router.Route(ctx))
+
+	last := log.entries[len(log.entries)-1]
+	assert.Equal(t, "handler_error", last.msg)
+	assert.Equal(t, ctx.RequestID, last.fields["request_id"])
+	assert.Equal(t, int64(-100), last.fields["chat_id"])
+	assert.Equal(t, int64(1), last.fields["user_id"])
+
+	// 没有原始 context 时也会创建，保证下游可用
+	assert.NotNil(t, ctx.Ctx)
+}
+
+func TestLoggingMiddleware_UsageNotError(t *testing.T) {
+	log := &recordingLogger{}
+	next := func(ctx *handler.Context) error {
+		return handler.Usage("用法: <code>/schedule 1h 内容</code>")
+	}
+
+	err := NewLoggingMiddleware(log).Middleware()(next)(&handler.Context{ChatID: -100, UserID: 1})
+	assert.ErrorIs(t, err, handler.ErrUsage)
+
+	// 参数有误不是处理失败，不记录错误日志
+	for _, entry := range log.entries {
+		assert.NotEqual(t, "error", entry.level, entry.msg)
+	}
+	last := log.entries[len(log.entries)-1]
+	assert.Equal(t, "handler_usage", last.msg)
+}
+
+func TestLoggingMiddleware_DeadlineExceeded(t *testing.T) {
+	log := &recordingLogger{}
+	router := handler.NewRouter()
+	router.SetTimeout(20 * time.Millisecond)
+	router.Use(NewLoggingMiddleware(log).Middleware())
+
+	// 处理器一直阻塞，直到处理期限到达被取消
+	cancelled := false
+	router.Register(&stubHandler{priority: 100, continueChain: true, handle: func(ctx *handler.Context) error {
+		select {
+		case <-ctx.Ctx.Done():
+			cancelled = true
+			return ctx.Ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}})
+	// 超时后不再执行后续处理器
+	listenerCalled := false
+	router.Register(&stubHandler{priority: 900, continueChain: true, handle: func(ctx *handler.Context) error {
+		listenerCalled = true
+		return nil
+	}})
+
+	ctx := &handler.Context{Ctx: context.Background(), ChatID: -100, UserID: 1}
+	err := router.Route(ctx)
+
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.CodeTimeout))
+	assert.True(t, cancelled)
+	assert.False(t, listenerCalled)
+
+	last := log.entries[len(log.entries)-1]
+	assert.Equal(t, "handler_deadline_exceeded", last.msg)
+	assert.Equal(t, ctx.RequestID, last.fields["request_id"])
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+)
+
+// MembershipRequired 可选接口：处理器要求群组中的调用者是群组成员
+// 嵌入 command.BaseCommand 的处理器调用 RequireMembership() 开启
+type MembershipRequired interface {
+	RequiresMembership() bool
+}
+
+// ChatMemberGetter 查询群组成员接口（由 telegram.API 实现）
+type ChatMemberGetter interface {
+	GetChatMember(ctx context.Context, chatID, userID int64) (*group.Member, error)
+}
+
+// memberKey 成员资格缓存的键
+type memberKey struct {
+	chatID int64
+	userID int64
+}
+
+// MembershipMiddleware 群组成员检查中间件
+// 只检查实现 MembershipRequired 且开启的处理器，拒绝不是群组成员的调用者（例如关联频道讨论组中非成员的评论）；
+// 查询结果缓存 ttl 时间，避免每条命令都调用 Telegram API。
+// 尽力而为：查询失败时放行并记录警告，不因 Telegram API 不可用而拒绝正常成员
+type MembershipMiddleware struct {
+	members ChatMemberGetter
+	ttl     time.Duration
+	cache   *ttlmap.Map[memberKey, bool]
+	logger  Logger
+}
+
+// NewMembershipMiddleware 创建群组成员检查中间件，并启动缓存清理任务
+func NewMembershipMiddleware(members ChatMemberGetter, ttl time.Duration, logger Logger) *MembershipMiddleware {
+	m := &MembershipMiddleware{
+		members: members,
+		ttl:     ttl,
+		cache:   ttlmap.New[memberKey, bool](nil),
+		logger:  logger,
+	}
+	m.cache.StartSweeper(ttl)
+	return m
+}
+
+// Stop 停止缓存清理任务
+func (m *MembershipMiddleware) Stop() {
+	m.cache.Stop()
+}
+
+// Middleware 返回中间件函数
+func (m *MembershipMiddleware) Middleware() handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(ctx *handler.Context) error {
+			required, ok := ctx.Handler.(MembershipRequired)
+			if !ok || !required.RequiresMembership() || !ctx.IsGroup() || ctx.IsAnonymousAdmin() {
+				return next(ctx)
+			}
+
+			// 以频道身份发送的消息（如关联频道的评论）不代表群组成员
+			if ctx.IsSenderChat() || !m.isMember(ctx) {
+				return errors.New(errors.CodeNotMember, "caller is not a member of the group")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// isMember 检查调用者是否为群组成员（带缓存，查询失败时视为成员）
+func (m *MembershipMiddleware) isMember(ctx *handler.Context) bool {
+	key := memberKey{chatID: ctx.ChatID, userID: ctx.UserID}
+	if cached, ok := m.cache.Get(key); ok {
+		return cached
+	}
+
+	member, err := m.members.GetChatMember(ctx.RequestContext(), ctx.ChatID, ctx.UserID)
+	if err != nil {
+		m.logger.Warn("membership_check_failed",
+			"request_id", ctx.RequestID,
+			"chat_id", ctx.ChatID,
+			"user_id", ctx.UserID,
+			"error", err.Error(),
+		)
+		return true
+	}
+
+	m.cache.Set(key, member.IsMember, m.ttl)
+	return member.IsMember
+}
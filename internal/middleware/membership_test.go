@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMemberGetter 按用户返回预设的成员状态，并记录调用次数
+type fakeMemberGetter struct {
+	members map[int64]*group.Member
+	err     error
+	calls   int
+}
+
+func (f *fakeMemberGetter) GetChatMember(ctx context.Context, chatID, userID int64) (*group.Member, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.members[userID], nil
+}
+
+// memberOnlyHandler 可配置是否要求成员资格的处理器
+type memberOnlyHandler struct {
+	stubHandler
+	required bool
+}
+
+func (h *memberOnlyHandler) RequiresMembership() bool { return h.required }
+
+// routeAs 以指定用户在群组中发送命令，返回处理器是否被执行和路由错误
+func routeAs(router *handler.Router, userID int64) (bool, error) {
+	handled := false
+	ctx := &handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: userID, Text: "/cmd"}
+	ctx.Set("handled", &handled)
+	err := router.Route(ctx)
+	return handled, err
+}
+
+func newMembershipRouter(t *testing.T, getter ChatMemberGetter, required bool) *handler.Router {
+	mw := NewMembershipMiddleware(getter, time.Minute, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+
+	router := handler.NewRouter()
+	router.Use(mw.Middleware())
+	router.Register(&memberOnlyHandler{
+		required: required,
+		stubHandler: stubHandler{handle: func(ctx *handler.Context) error {
+			v, _ := ctx.Get("handled")
+			*v.(*bool) = true
+			return nil
+		}},
+	})
+	return router
+}
+
+func TestMembershipMiddleware_Gate(t *testing.T) {
+	getter := &fakeMemberGetter{members: map[int64]*group.Member{
+		1: {UserID: 1, Status: group.MemberStatusMember, IsMember: true},
+		2: {UserID: 2, Status: group.MemberStatusLeft},
+		3: {UserID: 3, Status: group.MemberStatusRestricted, IsMember: true},
+	}}
+	router := newMembershipRouter(t, getter, true)
+
+	handled, err := routeAs(router, 1)
+	require.NoError(t, err)
+	assert.True(t, handled)
+
+	handled, err = routeAs(router, 2)
+	assert.True(t, errors.HasCode(err, errors.CodeNotMember))
+	assert.False(t, handled)
+	assert.Equal(t, "❌ 只有本群成员才能使用此命令", handler.UserMessage(err))
+
+	handled, err = routeAs(router, 3)
+	require.NoError(t, err)
+	assert.True(t, handled)
+
+	// 结果被缓存，不再调用 API
+	_, _ = routeAs(router, 1)
+	_, _ = routeAs(router, 2)
+	assert.Equal(t, 3, getter.calls)
+}
+
+func TestMembershipMiddleware_OptIn(t *testing.T) {
+	getter := &fakeMemberGetter{}
+	router := newMembershipRouter(t, getter, false)
+
+	// 未开启的处理器不检查成员资格
+	handled, err := routeAs(router, 2)
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Zero(t, getter.calls)
+}
+
+func TestMembershipMiddleware_FailOpen(t *testing.T) {
+	getter := &fakeMemberGetter{err: assert.AnError}
+	router := newMembershipRouter(t, getter, true)
+
+	// 查询失败时放行，且不缓存结果
+	handled, err := routeAs(router, 2)
+	require.NoError(t, err)
+	assert.True(t, handled)
+	_, _ = routeAs(router, 2)
+	assert.Equal(t, 2, getter.calls)
+}
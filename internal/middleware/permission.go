@@ -1,35 +1,71 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
+	"telegram-bot/internal/domain/group"
 	"telegram-bot/internal/domain/user"
 	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/ttlmap"
+	"time"
+)
+
+const (
+	// FeatureAnonymousAdmins 匿名管理员视为群组管理员（默认开启，与 command/toggleanon.go 保持一致）
+	FeatureAnonymousAdmins = "anonymous_admins"
+
+	// defaultSeenInterval 同一用户的最近活跃时间默认最多每隔多久写入一次
+	defaultSeenInterval = 10 * time.Minute
 )
 
 // PermissionMiddleware 权限中间件
-// 负责加载用户信息并注入到上下文中
+// 负责加载用户信息并注入到上下文中，同时记录用户的最近活跃时间
 type PermissionMiddleware struct {
 	userRepo user.Repository
 	ownerIDs []int64 // 配置的Owner用户ID列表
 	logger   Logger  // 用于记录错误
+
+	seenInterval time.Duration                 // 同一用户两次写入最近活跃时间的最小间隔
+	seen         *ttlmap.Map[int64, time.Time] // userID -> 本进程最近一次写入的时间
+	now          func() time.Time
 }
 
-// NewPermissionMiddleware 创建权限中间件
+// NewPermissionMiddleware 创建权限中间件，并启动节流记录的清理任务
 func NewPermissionMiddleware(userRepo user.Repository, ownerIDs []int64, logger Logger) *PermissionMiddleware {
-	return &PermissionMiddleware{
-		userRepo: userRepo,
-		ownerIDs: ownerIDs,
-		logger:   logger,
+	m := &PermissionMiddleware{
+		userRepo:     userRepo,
+		ownerIDs:     ownerIDs,
+		logger:       logger,
+		seenInterval: defaultSeenInterval,
+		seen:         ttlmap.New[int64, time.Time](nil),
+		now:          time.Now,
 	}
+	m.seen.StartSweeper(defaultSeenInterval)
+	return m
+}
+
+// SetSeenInterval 设置同一用户两次写入最近活跃时间的最小间隔
+func (m *PermissionMiddleware) SetSeenInterval(interval time.Duration) {
+	if interval > 0 {
+		m.seenInterval = interval
+	}
+}
+
+// Stop 停止节流记录的清理任务
+func (m *PermissionMiddleware) Stop() {
+	m.seen.Stop()
 }
 
 // Middleware 返回中间件函数
 func (m *PermissionMiddleware) Middleware() handler.Middleware {
 	return func(next handler.HandlerFunc) handler.HandlerFunc {
 		return func(ctx *handler.Context) error {
-			// 创建 context（TODO: 从 handler.Context 传递）
-			reqCtx := context.TODO()
+			// 以群组或频道身份发送的消息没有真实用户，注入临时用户，不读写数据库
+			if ctx.IsSenderChat() {
+				ctx.User = m.senderChatUser(ctx)
+				return next(ctx)
+			}
+
+			reqCtx := ctx.RequestContext()
 
 			// 1. 加载用户
 			u, err := m.userRepo.FindByID(reqCtx, ctx.UserID)
@@ -42,6 +78,14 @@ func (m *PermissionMiddleware) Middleware() handler.Middleware {
 					ctx.LastName,
 				)
 
+				// 群组可将新用户的默认权限设为 None（审核后再提升）
+				if perm, ok := defaultPermission(ctx.Group); ok {
+					u.SetPermission(ctx.ChatID, perm)
+				}
+
+				now := m.now()
+				u.LastSeenAt = now
+
 				// 检查是否为配置的Owner
 				if m.isConfiguredOwner(ctx.UserID) {
 					// 设置为全局Owner权限（groupID = 0 表示全局）
@@ -52,12 +96,14 @@ func (m *PermissionMiddleware) Middleware() handler.Middleware {
 				if err := m.userRepo.Save(reqCtx, u); err != nil {
 					// 创建失败，记录错误并返回错误，不允许继续执行
 					m.logger.Error("failed_to_create_user",
+						"request_id", ctx.RequestID,
 						"error", err.Error(),
 						"user_id", ctx.UserID,
 						"username", ctx.Username,
 					)
-					return fmt.Errorf("failed to create user: %w", err)
+					return fmt.Errorf("failed to create user [request_id=%s]: %w", ctx.RequestID, err)
 				}
+				m.seen.Set(u.ID, now, m.seenInterval)
 			} else {
 				// 用户已存在，检查是否需要升级为Owner
 				if m.isConfiguredOwner(ctx.UserID) {
@@ -67,6 +113,7 @@ func (m *PermissionMiddleware) Middleware() handler.Middleware {
 						if err := m.userRepo.UpdatePermission(reqCtx, ctx.UserID, 0, user.PermissionOwner); err != nil {
 							// 更新失败，记录错误但继续执行
 							m.logger.Warn("failed_to_upgrade_owner_permission",
+								"request_id", ctx.RequestID,
 								"error", err.Error(),
 								"user_id", ctx.UserID,
 								"username", ctx.Username,
@@ -77,6 +124,10 @@ func (m *PermissionMiddleware) Middleware() handler.Middleware {
 						}
 					}
 				}
+
+				// 记录最近活跃时间，用户改名后同步最新的用户名和显示名
+				// （早期只保存了 ID 的用户也在首次出现时补全）
+				m.recordSeen(ctx, u)
 			}
 
 			// 2. 注入到上下文
@@ -89,6 +140,69 @@ func (m *PermissionMiddleware) Middleware() handler.Middleware {
 	}
 }
 
+// recordSeen 写入用户的最近活跃时间和最新的用户名、显示名，失败只记录警告
+// 同一用户在 seenInterval 内只写入一次，避免每条消息都写数据库；用户改名时立即写入。
+// 数据库中的最近活跃时间足够新时同样跳过（例如重启后或由其他实例写入）
+func (m *PermissionMiddleware) recordSeen(ctx *handler.Context, u *user.User) {
+	now := m.now()
+	changed := u.ProfileChanged(ctx.Username, ctx.FirstName, ctx.LastName)
+	if !changed {
+		if last, ok := m.seen.Get(u.ID); ok && now.Sub(last) < m.seenInterval {
+			return
+		}
+		if now.Sub(u.LastSeenAt) < m.seenInterval {
+			m.seen.Set(u.ID, u.LastSeenAt, m.seenInterval-now.Sub(u.LastSeenAt))
+			return
+		}
+	}
+
+	if changed {
+		u.SetProfile(ctx.Username, ctx.FirstName, ctx.LastName)
+	}
+	u.LastSeenAt = now
+
+	if err := m.userRepo.RecordSeen(ctx.RequestContext(), u); err != nil {
+		m.logger.Warn("failed_to_record_user_seen",
+			"request_id", ctx.RequestID,
+			"error", err.Error(),
+			"user_id", ctx.UserID,
+			"username", ctx.Username,
+		)
+		return
+	}
+	m.seen.Set(u.ID, now, m.seenInterval)
+}
+
+// senderChatUser 为以群组或频道身份发送的消息构造临时用户（不保存）
+// 匿名管理员在群组开启 anonymous_admins 功能时拥有 Admin 权限，
+// 否则（包括关联频道）只有普通用户权限
+// 注意：需要在 GroupMiddleware 之后执行才能读取群组设置
+func (m *PermissionMiddleware) senderChatUser(ctx *handler.Context) *user.User {
+	u := user.NewUser(ctx.UserID, "", ctx.SenderChatTitle, "")
+
+	if ctx.IsAnonymousAdmin() && (ctx.Group == nil || ctx.Group.IsFeatureEnabled(FeatureAnonymousAdmins)) {
+		u.SetPermission(ctx.ChatID, user.PermissionAdmin)
+	}
+	return u
+}
+
+// defaultPermission 返回群组为新用户配置的默认权限
+// 只支持降为 None；未配置或配置为其他值时返回 false（使用默认的 User 权限）
+func defaultPermission(g *group.Group) (user.Permission, bool) {
+	if g == nil {
+		return 0, false
+	}
+	value, ok := g.GetStringSetting(group.SettingDefaultPermission)
+	if !ok {
+		return 0, false
+	}
+	perm, ok := user.ParsePermission(value)
+	if !ok || perm != user.PermissionNone {
+		return 0, false
+	}
+	return perm, true
+}
+
 // isConfiguredOwner 检查用户ID是否在配置的Owner列表中
 func (m *PermissionMiddleware) isConfiguredOwner(userID int64) bool {
 	for _, id := range m.ownerIDs {
@@ -0,0 +1,247 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/domain/user"
+	"telegram-bot/internal/handler"
+	"telegram-bot/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testGroupID       = int64(-1001234567890)
+	groupAnonymousBot = int64(1087968824)
+)
+
+// runPermission 执行权限中间件，返回注入的用户
+func runPermission(t *testing.T, ctx *handler.Context) (*user.User, error) {
+	t.Helper()
+
+	// 以群组或频道身份发送时不应访问用户仓储（没有设置任何期望，调用即失败）
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+
+	var injected *user.User
+	err := mw.Middleware()(func(ctx *handler.Context) error {
+		injected = ctx.User
+		return nil
+	})(ctx)
+	return injected, err
+}
+
+func TestPermissionMiddleware_AnonymousAdmin(t *testing.T) {
+	ctx := &handler.Context{
+		ChatType:        "supergroup",
+		ChatID:          testGroupID,
+		UserID:          groupAnonymousBot,
+		SenderChatID:    testGroupID,
+		SenderChatTitle: "Test Group",
+		Group:           group.NewGroup(testGroupID, "Test Group", "supergroup"),
+	}
+
+	u, err := runPermission(t, ctx)
+
+	require.NoError(t, err)
+	require.NotNil(t, u)
+	assert.True(t, u.IsAdmin(testGroupID))
+	assert.False(t, u.IsAdmin(-100999))
+}
+
+func TestPermissionMiddleware_AnonymousAdminDisabled(t *testing.T) {
+	g := group.NewGroup(testGroupID, "Test Group", "supergroup")
+	g.DisableFeature(FeatureAnonymousAdmins)
+
+	ctx := &handler.Context{
+		ChatType:     "supergroup",
+		ChatID:       testGroupID,
+		UserID:       groupAnonymousBot,
+		SenderChatID: testGroupID,
+		Group:        g,
+	}
+
+	u, err := runPermission(t, ctx)
+
+	require.NoError(t, err)
+	require.NotNil(t, u)
+	assert.Equal(t, user.PermissionUser, u.GetPermission(testGroupID))
+}
+
+func TestPermissionMiddleware_LinkedChannel(t *testing.T) {
+	ctx := &handler.Context{
+		ChatType:        "supergroup",
+		ChatID:          testGroupID,
+		UserID:          777000,
+		SenderChatID:    -100555,
+		SenderChatTitle: "News Channel",
+		Group:           group.NewGroup(testGroupID, "Test Group", "supergroup"),
+	}
+
+	u, err := runPermission(t, ctx)
+
+	require.NoError(t, err)
+	require.NotNil(t, u)
+	assert.Equal(t, "News Channel", u.FirstName)
+	assert.Equal(t, user.PermissionUser, u.GetPermission(testGroupID))
+}
+
+func TestPermissionMiddleware_DefaultPermissionNone(t *testing.T) {
+	g := group.NewGroup(testGroupID, "Test Group", "supergroup")
+	g.SetSetting(group.SettingDefaultPermission, "none")
+
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(nil, user.ErrUserNotFound)
+	userRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	ctx := &handler.Context{
+		ChatType: "supergroup",
+		ChatID:   testGroupID,
+		UserID:   123,
+		Username: "newbie",
+		Group:    g,
+	}
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	err := mw.Middleware()(func(ctx *handler.Context) error {
+		// 普通用户命令的权限检查
+		return ctx.RequirePermission(user.PermissionUser)
+	})(ctx)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "当前权限: None")
+	assert.Equal(t, user.PermissionNone, ctx.User.GetPermission(testGroupID))
+}
+
+func TestPermissionMiddleware_DefaultPermissionUnset(t *testing.T) {
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(nil, user.ErrUserNotFound)
+	userRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	ctx := &handler.Context{
+		ChatType: "supergroup",
+		ChatID:   testGroupID,
+		UserID:   123,
+		Group:    group.NewGroup(testGroupID, "Test Group", "supergroup"),
+	}
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	err := mw.Middleware()(func(ctx *handler.Context) error {
+		return ctx.RequirePermission(user.PermissionUser)
+	})(ctx)
+
+	require.NoError(t, err)
+	assert.Empty(t, ctx.User.Permissions)
+}
+
+func TestPermissionMiddleware_NewUserProfile(t *testing.T) {
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(nil, user.ErrUserNotFound)
+	userRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, u *user.User) error {
+		assert.Equal(t, "alice", u.Username)
+		assert.Equal(t, "Alice", u.FirstName)
+		assert.Equal(t, "Liddell", u.LastName)
+		return nil
+	})
+
+	ctx := &handler.Context{
+		ChatType:  "private",
+		ChatID:    123,
+		UserID:    123,
+		Username:  "alice",
+		FirstName: "Alice",
+		LastName:  "Liddell",
+	}
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	require.NoError(t, mw.Middleware()(func(ctx *handler.Context) error { return nil })(ctx))
+	assert.Equal(t, "alice", ctx.User.Username)
+}
+
+func TestPermissionMiddleware_RefreshProfile(t *testing.T) {
+	stored := user.NewUser(123, "", "", "") // 早期只保存了 ID 的用户
+	same := user.NewUser(123, "alice", "Alice", "")
+
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	gomock.InOrder(
+		userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(stored, nil),
+		userRepo.EXPECT().RecordSeen(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, u *user.User) error {
+			assert.Equal(t, "alice", u.Username)
+			assert.Equal(t, "Alice", u.FirstName)
+			return nil
+		}),
+		// 名字没有变化且在节流间隔内时不写数据库
+		userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(same, nil),
+	)
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	next := mw.Middleware()(func(ctx *handler.Context) error { return nil })
+
+	for range 2 {
+		ctx := &handler.Context{ChatType: "private", ChatID: 123, UserID: 123, Username: "alice", FirstName: "Alice"}
+		require.NoError(t, next(ctx))
+		assert.Equal(t, "alice", ctx.User.Username)
+		assert.Equal(t, "Alice", ctx.User.FirstName)
+	}
+}
+
+func TestPermissionMiddleware_SeenThrottle(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var written []time.Time
+
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).DoAndReturn(func(_ any, _ int64) (*user.User, error) {
+		return user.NewUser(123, "alice", "Alice", ""), nil
+	}).AnyTimes()
+	userRepo.EXPECT().RecordSeen(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, u *user.User) error {
+		written = append(written, u.LastSeenAt)
+		return nil
+	}).AnyTimes()
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	mw.SetSeenInterval(10 * time.Minute)
+	mw.now = func() time.Time { return now }
+	next := mw.Middleware()(func(ctx *handler.Context) error { return nil })
+
+	send := func(username string) {
+		ctx := &handler.Context{ChatType: "private", ChatID: 123, UserID: 123, Username: username, FirstName: "Alice"}
+		require.NoError(t, next(ctx))
+	}
+
+	send("alice")
+	now = now.Add(5 * time.Minute)
+	send("alice") // 节流间隔内，不写入
+	now = now.Add(6 * time.Minute)
+	send("alice") // 距上次写入已超过间隔
+	now = now.Add(time.Minute)
+	send("alice_new") // 改名立即写入
+
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []time.Time{start, start.Add(11 * time.Minute), start.Add(12 * time.Minute)}, written)
+}
+
+func TestPermissionMiddleware_SeenRecentlyInDB(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored := user.NewUser(123, "alice", "Alice", "")
+	stored.LastSeenAt = now.Add(-time.Minute) // 重启前或其他实例刚写入过
+
+	userRepo := mocks.NewMockUserRepository(gomock.NewController(t))
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).Return(stored, nil)
+
+	mw := NewPermissionMiddleware(userRepo, nil, &recordingLogger{})
+	t.Cleanup(mw.Stop)
+	mw.now = func() time.Time { return now }
+
+	ctx := &handler.Context{ChatType: "private", ChatID: 123, UserID: 123, Username: "alice", FirstName: "Alice"}
+	require.NoError(t, mw.Middleware()(func(ctx *handler.Context) error { return nil })(ctx))
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+	"time"
+)
+
+// QuotaStore 命令每日使用次数计数接口（mongodb.CommandQuotaRepository 实现）
+type QuotaStore interface {
+	// Add 累加群组命令当天的使用次数并返回累加后的值，expireAt 之后计数可以删除
+	Add(ctx context.Context, groupID int64, command, day string, delta int64, expireAt time.Time) (int64, error)
+}
+
+// QuotaMiddleware 命令每日配额中间件
+// 按 (群组, 命令名) 限制配置了配额的命令每天的使用次数，日期按群组时区计算，零点重置；
+// 配额用完后返回 RATE_LIMIT 错误并附带重置时间。执行失败的调用退还配额
+type QuotaMiddleware struct {
+	quotas map[string]int // 命令名 -> 每天的使用次数
+	store  QuotaStore
+	now    func() time.Time
+}
+
+// NewQuotaMiddleware 创建命令每日配额中间件
+func NewQuotaMiddleware(quotas map[string]int, store QuotaStore) *QuotaMiddleware {
+	return &QuotaMiddleware{
+		quotas: quotas,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+// Middleware 返回中间件函数
+func (m *QuotaMiddleware) Middleware() handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(ctx *handler.Context) error {
+			named, ok := ctx.Handler.(NamedCommand)
+			if !ok {
+				return next(ctx)
+			}
+			name := named.GetName()
+			limit, ok := m.quotas[name]
+			if !ok || limit <= 0 {
+				return next(ctx)
+			}
+
+			loc := time.UTC
+			if ctx.Group != nil {
+				loc = ctx.Group.Location()
+			}
+			now := m.now().In(loc)
+			day := now.Format("2006-01-02")
+			resetAt := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+
+			// 先占用一次配额，并发调用也不会超出
+			reqCtx := ctx.RequestContext()
+			count, err := m.store.Add(reqCtx, ctx.ChatID, name, day, 1, resetAt)
+			if err != nil {
+				return errors.Wrap(err, "count command quota")
+			}
+			if count > int64(limit) {
+				return errors.New(errors.CodeRateLimit, "command quota exhausted").
+					WithContext("quota", fmt.Sprintf("本群今天的 /%s 配额（%d 次）已用完", name, limit)).
+					WithContext("resets_at", resetAt.Format("2006-01-02 15:04 MST"))
+			}
+
+			if err := next(ctx); err != nil {
+				// 用法错误等失败的调用退还配额（处理期限已过时仍要退还）
+				_, _ = m.store.Add(context.WithoutCancel(reqCtx), ctx.ChatID, name, day, -1, resetAt)
+				return err
+			}
+			return nil
+		}
+	}
+}
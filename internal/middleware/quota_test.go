@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/group"
+	"telegram-bot/internal/handler"
+	"telegram-bot/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuotaStore 内存中的每日计数
+type fakeQuotaStore struct {
+	counts map[string]int64
+}
+
+func (s *fakeQuotaStore) Add(ctx context.Context, groupID int64, command, day string, delta int64, expireAt time.Time) (int64, error) {
+	key := fmt.Sprintf("%d/%s/%s", groupID, command, day)
+	s.counts[key] += delta
+	return s.counts[key], nil
+}
+
+func TestQuotaMiddleware(t *testing.T) {
+	// 群组时区为 UTC+8，UTC 15:30 是当地 23:30
+	now := time.Date(2025, 6, 1, 15, 30, 0, 0, time.UTC)
+	store := &fakeQuotaStore{counts: map[string]int64{}}
+	mw := NewQuotaMiddleware(map[string]int{"cleanup": 2}, store)
+	mw.now = func() time.Time { return now }
+
+	var fail error
+	calls := map[string]int{}
+	router := handler.NewRouter()
+	router.Use(mw.Middleware())
+	for _, name := range []string{"cleanup", "ping"} {
+		router.Register(&namedHandler{name: name, stubHandler: stubHandler{handle: func(ctx *handler.Context) error {
+			calls[name]++
+			return fail
+		}}})
+	}
+
+	g := group.NewGroup(testGroupID, "Test", "supergroup")
+	g.SetSetting(group.SettingTimezone, "Asia/Shanghai")
+	route := func(command string) error {
+		return router.Route(&handler.Context{ChatType: "supergroup", ChatID: testGroupID, UserID: 1, Group: g, Text: "/" + command})
+	}
+
+	// 每次成功调用占用一次配额
+	require.NoError(t, route("cleanup"))
+	assert.Equal(t, int64(1), store.counts[fmt.Sprintf("%d/cleanup/2025-06-01", testGroupID)])
+
+	// 失败的调用退还配额
+	fail = assert.AnError
+	assert.Error(t, route("cleanup"))
+	assert.Equal(t, int64(1), store.counts[fmt.Sprintf("%d/cleanup/2025-06-01", testGroupID)])
+	fail = nil
+
+	require.NoError(t, route("cleanup"))
+
+	// 配额用完后拒绝，并附带重置时间（群组时区的零点）
+	err := route("cleanup")
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.CodeRateLimit))
+	msg := handler.UserMessage(err)
+	assert.Contains(t, msg, "本群今天的 /cleanup 配额（2 次）已用完")
+	assert.Contains(t, msg, "将于 2025-06-02 00:00 CST 重置")
+	assert.Equal(t, 3, calls["cleanup"])
+
+	// 未配置配额的命令不受影响
+	require.NoError(t, route("ping"))
+	require.NoError(t, route("ping"))
+	require.NoError(t, route("ping"))
+
+	// 当地零点后重置
+	now = now.Add(31 * time.Minute)
+	require.NoError(t, route("cleanup"))
+	assert.Equal(t, int64(1), store.counts[fmt.Sprintf("%d/cleanup/2025-06-02", testGroupID)])
+	assert.Equal(t, 4, calls["cleanup"])
+}
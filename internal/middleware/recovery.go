@@ -27,6 +27,7 @@ func (m *RecoveryMiddleware) Middleware() handler.Middleware {
 				if r := recover(); r != nil {
 					// 记录 panic 信息和堆栈
 					m.logger.Error("panic_recovered",
+						"request_id", ctx.RequestID,
 						"panic", r,
 						"stack", string(debug.Stack()),
 						"chat_id", ctx.ChatID,
@@ -37,10 +38,10 @@ func (m *RecoveryMiddleware) Middleware() handler.Middleware {
 					switch v := r.(type) {
 					case error:
 						// 如果 panic 的值本身就是 error，包装它
-						err = fmt.Errorf("panic recovered: %w", v)
+						err = fmt.Errorf("panic recovered [request_id=%s]: %w", ctx.RequestID, v)
 					default:
 						// 否则创建新的 error
-						err = fmt.Errorf("panic recovered: %v (type: %T)", r, r)
+						err = fmt.Errorf("panic recovered [request_id=%s]: %v (type: %T)", ctx.RequestID, r, r)
 					}
 
 					// 尝试通知用户
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain/schedule"
+	"telegram-bot/pkg/logger"
+)
+
+// ScheduledMessageRepository 定时消息任务所需的仓储接口
+type ScheduledMessageRepository interface {
+	FindDue(ctx context.Context, now time.Time) ([]*schedule.Message, error)
+	UpdateNextRun(ctx context.Context, id string, nextRunAt time.Time) error
+}
+
+// MessageSender 消息发送接口
+type MessageSender interface {
+	SendMessage(ctx context.Context, chatID int64, text string) (int, error)
+}
+
+// maxSendRetryAge 到期超过该时长仍发送失败的消息跳过本次发送（如机器人已被移出群组或失去发言权限）
+const maxSendRetryAge = time.Hour
+
+// ScheduledMessageJob 定时消息发送任务
+// 每分钟从数据库读取到期消息并发送，消息持久化在数据库中，重启后不会丢失
+type ScheduledMessageJob struct {
+	repo   ScheduledMessageRepository
+	sender MessageSender
+	logger logger.Logger
+	now    func() time.Time
+}
+
+// NewScheduledMessageJob 创建定时消息发送任务
+func NewScheduledMessageJob(repo ScheduledMessageRepository, sender MessageSender, log logger.Logger) *ScheduledMessageJob {
+	return &ScheduledMessageJob{
+		repo:   repo,
+		sender: sender,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+func (j *ScheduledMessageJob) Name() string {
+	return "ScheduledMessages"
+}
+
+func (j *ScheduledMessageJob) Schedule() string {
+	return "1m" // 每分钟检查一次
+}
+
+func (j *ScheduledMessageJob) Run(ctx context.Context) error {
+	now := j.now()
+
+	messages, err := j.repo.FindDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	sent, skipped := 0, 0
+	for _, m := range messages {
+		if _, err := j.sender.SendMessage(ctx, m.GroupID, m.Text); err != nil {
+			if now.Sub(m.NextRunAt) < maxSendRetryAge {
+				// 发送失败时不推进时间，下次检查时重试，消息不会被静默跳过
+				j.logger.Error("Failed to send scheduled message", "id", m.ID, "group_id", m.GroupID, "error", err)
+				continue
+			}
+			// 重试期已过，跳过本次发送并推进到下一个周期，避免每次检查都重试
+			j.logger.Warn("Giving up sending scheduled message until next run", "id", m.ID, "group_id", m.GroupID, "due", m.NextRunAt, "error", err)
+			skipped++
+		} else {
+			sent++
+		}
+
+		m.Advance(now)
+		if err := j.repo.UpdateNextRun(ctx, m.ID, m.NextRunAt); err != nil {
+			j.logger.Error("Failed to update scheduled message", "id", m.ID, "error", err)
+		}
+	}
+
+	if len(messages) > 0 {
+		j.logger.Info("Scheduled messages processed", "due", len(messages), "sent", sent, "skipped", skipped)
+	}
+
+	return nil
+}
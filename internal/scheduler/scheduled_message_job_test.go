@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/schedule"
+	"telegram-bot/pkg/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScheduleRepo 内存中的定时消息仓储
+type fakeScheduleRepo struct {
+	messages []*schedule.Message
+	updated  map[string]time.Time
+}
+
+func (r *fakeScheduleRepo) FindDue(ctx context.Context, now time.Time) ([]*schedule.Message, error) {
+	var due []*schedule.Message
+	for _, m := range r.messages {
+		if m.IsDue(now) {
+			copied := *m
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeScheduleRepo) UpdateNextRun(ctx context.Context, id string, nextRunAt time.Time) error {
+	if r.updated == nil {
+		r.updated = make(map[string]time.Time)
+	}
+	r.updated[id] = nextRunAt
+	return nil
+}
+
+type sentMessage struct {
+	chatID int64
+	text   string
+}
+
+// fakeSender 记录发送的消息
+type fakeSender struct {
+	sent []sentMessage
+	err  error
+}
+
+func (s *fakeSender) SendMessage(ctx context.Context, chatID int64, text string) (int, error) {
+	s.sent = append(s.sent, sentMessage{chatID: chatID, text: text})
+	return len(s.sent), s.err
+}
+
+func TestScheduledMessageJob_SendsDueMessages(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeScheduleRepo{
+		messages: []*schedule.Message{
+			{ID: "due", GroupID: -100, Text: "公告", Interval: time.Hour, NextRunAt: now.Add(-time.Minute)},
+			{ID: "later", GroupID: -200, Text: "稍后", Interval: time.Hour, NextRunAt: now.Add(time.Minute)},
+		},
+	}
+	sender := &fakeSender{}
+
+	job := NewScheduledMessageJob(repo, sender, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Run(context.Background()))
+
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, sentMessage{chatID: -100, text: "公告"}, sender.sent[0])
+	assert.Equal(t, now.Add(59*time.Minute), repo.updated["due"])
+	assert.NotContains(t, repo.updated, "later")
+}
+
+func TestScheduledMessageJob_RetriesOnSendFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeScheduleRepo{
+		messages: []*schedule.Message{
+			{ID: "due", GroupID: -100, Text: "公告", Interval: time.Hour, NextRunAt: now},
+		},
+	}
+	sender := &fakeSender{err: errors.New("bot was kicked")}
+
+	job := NewScheduledMessageJob(repo, sender, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	// 发送失败不推进时间
+	require.NoError(t, job.Run(context.Background()))
+	assert.NotContains(t, repo.updated, "due")
+
+	// 下次检查时重试，成功后按原计划推进
+	sender.err = nil
+	now = now.Add(time.Minute)
+	require.NoError(t, job.Run(context.Background()))
+	assert.Len(t, sender.sent, 2)
+	assert.Equal(t, now.Add(59*time.Minute), repo.updated["due"])
+}
+
+func TestScheduledMessageJob_SkipsRunAfterRetryAge(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := due.Add(maxSendRetryAge - time.Minute)
+	repo := &fakeScheduleRepo{
+		messages: []*schedule.Message{
+			{ID: "due", GroupID: -100, Text: "公告", Interval: 24 * time.Hour, NextRunAt: due},
+		},
+	}
+	sender := &fakeSender{err: errors.New("bot was kicked")}
+
+	job := NewScheduledMessageJob(repo, sender, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	// 重试期内继续重试
+	require.NoError(t, job.Run(context.Background()))
+	assert.NotContains(t, repo.updated, "due")
+
+	// 重试期已过，跳过本次发送，推进到下一个周期
+	now = due.Add(maxSendRetryAge)
+	require.NoError(t, job.Run(context.Background()))
+	assert.Equal(t, due.Add(24*time.Hour), repo.updated["due"])
+}
+
+func TestScheduledMessageJob_Schedule(t *testing.T) {
+	job := NewScheduledMessageJob(&fakeScheduleRepo{}, &fakeSender{}, &MockLogger{})
+	_, err := timeutil.ParseInterval(job.Schedule())
+	assert.NoError(t, err)
+}
@@ -2,11 +2,12 @@ package scheduler
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
+	"telegram-bot/pkg/lock"
 	"telegram-bot/pkg/logger"
+	"telegram-bot/pkg/timeutil"
 )
 
 // Job 定时任务接口
@@ -54,6 +55,7 @@ func (j *SimpleJob) Schedule() string {
 type Scheduler struct {
 	jobs   []Job
 	logger logger.Logger
+	locker lock.Locker // 多实例部署时保证每个任务只在一个实例上执行
 	mu     sync.RWMutex
 	wg     sync.WaitGroup
 	ctx    context.Context
@@ -66,6 +68,7 @@ func NewScheduler(log logger.Logger) *Scheduler {
 	return &Scheduler{
 		jobs:   make([]Job, 0),
 		logger: log,
+		locker: lock.NewNoopLocker(),
 		ctx:    ctx,
 		cancel: cancel,
 	}
@@ -79,6 +82,14 @@ func (s *Scheduler) AddJob(job Job) {
 	s.logger.Info("Job added", "name", job.Name(), "schedule", job.Schedule())
 }
 
+// SetLocker 设置分布式锁（需在 Start 之前调用）
+// 默认使用空实现，适用于单实例部署
+func (s *Scheduler) SetLocker(locker lock.Locker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locker = locker
+}
+
 // Start 启动调度器
 func (s *Scheduler) Start() {
 	s.mu.RLock()
@@ -116,7 +127,7 @@ func (s *Scheduler) Stop() {
 func (s *Scheduler) runJob(job Job) {
 	defer s.wg.Done()
 
-	interval, err := parseDuration(job.Schedule())
+	interval, err := timeutil.ParseInterval(job.Schedule())
 	if err != nil {
 		s.logger.Error("Invalid schedule format", "job", job.Name(), "schedule", job.Schedule(), "error", err)
 		return
@@ -130,7 +141,7 @@ func (s *Scheduler) runJob(job Job) {
 	// 立即执行一次（同步）
 	// 注意：如果任务执行时间较长，会阻塞定时器启动
 	// 但可以确保 context 取消信号正确传递
-	s.executeJob(job)
+	s.executeJob(job, interval)
 
 	for {
 		select {
@@ -138,13 +149,25 @@ func (s *Scheduler) runJob(job Job) {
 			s.logger.Info("Job stopped", "name", job.Name())
 			return
 		case <-ticker.C:
-			s.executeJob(job)
+			s.executeJob(job, interval)
 		}
 	}
 }
 
 // executeJob 执行任务
-func (s *Scheduler) executeJob(job Job) {
+// 执行前获取以任务名命名的锁，锁在一个调度周期后过期且执行完不释放：
+// 持有锁的实例在下个周期续期，其他实例在此期间跳过，实例宕机后由其他实例接管
+func (s *Scheduler) executeJob(job Job, interval time.Duration) {
+	acquired, err := s.locker.TryAcquire(s.ctx, "scheduler:"+job.Name(), interval)
+	if err != nil {
+		s.logger.Error("Job lock failed", "name", job.Name(), "error", err)
+		return
+	}
+	if !acquired {
+		s.logger.Debug("Job skipped: lock held by another instance", "name", job.Name())
+		return
+	}
+
 	startTime := time.Now()
 	s.logger.Info("Job executing", "name", job.Name())
 
@@ -154,7 +177,7 @@ func (s *Scheduler) executeJob(job Job) {
 	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
 	defer cancel()
 
-	err := job.Run(ctx)
+	err = job.Run(ctx)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -180,34 +203,3 @@ func (s *Scheduler) GetJobs() []Job {
 	copy(jobs, s.jobs)
 	return jobs
 }
-
-// parseDuration 解析时间间隔
-// 支持格式：
-// - "30s" - 30秒
-// - "5m" - 5分钟
-// - "1h" - 1小时
-// - "1d" - 1天
-func parseDuration(schedule string) (time.Duration, error) {
-	if schedule == "" {
-		return 0, fmt.Errorf("empty schedule")
-	}
-
-	// 尝试直接解析（支持 s, m, h）
-	duration, err := time.ParseDuration(schedule)
-	if err == nil {
-		return duration, nil
-	}
-
-	// 支持 "d" (天) 格式
-	if len(schedule) > 1 && schedule[len(schedule)-1] == 'd' {
-		days := schedule[:len(schedule)-1]
-		var d int
-		_, err := fmt.Sscanf(days, "%d", &d)
-		if err != nil {
-			return 0, fmt.Errorf("invalid schedule format: %s", schedule)
-		}
-		return time.Duration(d) * 24 * time.Hour, nil
-	}
-
-	return 0, fmt.Errorf("invalid schedule format: %s (supported: 30s, 5m, 1h, 1d)", schedule)
-}
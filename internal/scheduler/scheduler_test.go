@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"telegram-bot/pkg/lock"
 	"telegram-bot/pkg/logger"
 
 	"github.com/stretchr/testify/assert"
@@ -56,72 +57,12 @@ func (m *MockLogger) WithContext(ctx context.Context) logger.Logger {
 	return m
 }
 
-func (m *MockLogger) SetLevel(level logger.Level) {}
-
-func TestParseDuration(t *testing.T) {
-	tests := []struct {
-		name     string
-		schedule string
-		want     time.Duration
-		wantErr  bool
-	}{
-		{
-			name:     "parse seconds",
-			schedule: "30s",
-			want:     30 * time.Second,
-			wantErr:  false,
-		},
-		{
-			name:     "parse minutes",
-			schedule: "5m",
-			want:     5 * time.Minute,
-			wantErr:  false,
-		},
-		{
-			name:     "parse hours",
-			schedule: "1h",
-			want:     1 * time.Hour,
-			wantErr:  false,
-		},
-		{
-			name:     "parse days",
-			schedule: "1d",
-			want:     24 * time.Hour,
-			wantErr:  false,
-		},
-		{
-			name:     "parse multiple days",
-			schedule: "7d",
-			want:     7 * 24 * time.Hour,
-			wantErr:  false,
-		},
-		{
-			name:     "invalid format",
-			schedule: "invalid",
-			want:     0,
-			wantErr:  true,
-		},
-		{
-			name:     "empty schedule",
-			schedule: "",
-			want:     0,
-			wantErr:  true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseDuration(tt.schedule)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
+func (m *MockLogger) With(fields ...interface{}) logger.Logger {
+	return m
 }
 
+func (m *MockLogger) SetLevel(level logger.Level) {}
+
 func TestSimpleJob(t *testing.T) {
 	t.Run("creates simple job correctly", func(t *testing.T) {
 		called := false
@@ -374,3 +315,49 @@ func TestScheduler_ConcurrentSafety(t *testing.T) {
 
 	assert.Equal(t, 10, len(scheduler.GetJobs()))
 }
+
+func TestScheduler_LockPreventsDuplicateRuns(t *testing.T) {
+	backend := lock.NewMemoryBackend()
+
+	var counter int32
+	newInstance := func(owner string) *Scheduler {
+		s := NewScheduler(&MockLogger{})
+		s.SetLocker(backend.Locker(owner))
+		s.AddJob(NewSimpleJob("report", "1h", func(ctx context.Context) error {
+			atomic.AddInt32(&counter, 1)
+			return nil
+		}))
+		return s
+	}
+
+	// 两个实例同时启动，同一任务只执行一次
+	first := newInstance("instance-1")
+	second := newInstance("instance-2")
+	first.Start()
+	second.Start()
+
+	time.Sleep(100 * time.Millisecond)
+	first.Stop()
+	second.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter))
+}
+
+func TestScheduler_LockHolderKeepsRunning(t *testing.T) {
+	backend := lock.NewMemoryBackend()
+	scheduler := NewScheduler(&MockLogger{})
+	scheduler.SetLocker(backend.Locker("instance-1"))
+
+	var counter int32
+	scheduler.AddJob(NewSimpleJob("cleanup", "50ms", func(ctx context.Context) error {
+		atomic.AddInt32(&counter, 1)
+		return nil
+	}))
+
+	scheduler.Start()
+	time.Sleep(175 * time.Millisecond)
+	scheduler.Stop()
+
+	// 持有者在每个周期续期锁，任务正常执行多次
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&counter), int32(2))
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/pkg/logger"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ChatPermissionsSetter 设置群组默认权限接口
+type ChatPermissionsSetter interface {
+	SetChatPermissions(ctx context.Context, chatID int64, permissions models.ChatPermissions) error
+}
+
+// fallbackChatPermissions 无法读取禁言前的权限时恢复的默认权限（普通成员可以发送各类消息）
+var fallbackChatPermissions = models.ChatPermissions{
+	CanSendMessages:       true,
+	CanSendAudios:         true,
+	CanSendDocuments:      true,
+	CanSendPhotos:         true,
+	CanSendVideos:         true,
+	CanSendVideoNotes:     true,
+	CanSendVoiceNotes:     true,
+	CanSendPolls:          true,
+	CanSendOtherMessages:  true,
+	CanAddWebPagePreviews: true,
+	CanInviteUsers:        true,
+}
+
+// EncodeChatPermissions 将群组默认权限编码为限制记录的 State
+func EncodeChatPermissions(permissions *models.ChatPermissions) string {
+	if permissions == nil {
+		return ""
+	}
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeChatPermissions 从限制记录的 State 解码群组默认权限，无法解码时返回默认权限
+func decodeChatPermissions(state string) models.ChatPermissions {
+	var permissions models.ChatPermissions
+	if state == "" || json.Unmarshal([]byte(state), &permissions) != nil {
+		return fallbackChatPermissions
+	}
+	return permissions
+}
+
+// RestoreSilence 恢复全群禁言前的群组默认权限
+func RestoreSilence(ctx context.Context, setter ChatPermissionsSetter, rec *restriction.Record) error {
+	return setter.SetChatPermissions(ctx, rec.GroupID, decodeChatPermissions(rec.State))
+}
+
+// maxRestoreRetryAge 到期超过该时长仍恢复失败的全群禁言记录不再重试（如机器人已被移出群组）
+const maxRestoreRetryAge = time.Hour
+
+// SilenceRestoreJob 全群禁言到期恢复任务
+// 根据数据库中的禁言记录恢复群组权限，机器人重启后仍能按时恢复
+type SilenceRestoreJob struct {
+	repo   ExpiredBanRepository
+	setter ChatPermissionsSetter
+	logger logger.Logger
+	now    func() time.Time
+}
+
+// NewSilenceRestoreJob 创建全群禁言到期恢复任务
+func NewSilenceRestoreJob(repo ExpiredBanRepository, setter ChatPermissionsSetter, log logger.Logger) *SilenceRestoreJob {
+	return &SilenceRestoreJob{
+		repo:   repo,
+		setter: setter,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+func (j *SilenceRestoreJob) Name() string {
+	return "SilenceRestore"
+}
+
+func (j *SilenceRestoreJob) Schedule() string {
+	return "30s" // 禁言通常只有几分钟，检查得更频繁
+}
+
+func (j *SilenceRestoreJob) Run(ctx context.Context) error {
+	now := j.now()
+	records, err := j.repo.FindExpired(ctx, restriction.KindSilence, now)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if err := RestoreSilence(ctx, j.setter, rec); err != nil {
+			if now.Sub(rec.Until) < maxRestoreRetryAge {
+				// 保留记录，下次继续尝试
+				j.logger.Error("Failed to restore chat permissions", "group_id", rec.GroupID, "error", err)
+				continue
+			}
+			// 重试期已过，删除记录放弃恢复，避免无限重试
+			j.logger.Warn("Giving up restoring chat permissions", "group_id", rec.GroupID, "until", rec.Until, "error", err)
+			if err := j.repo.Delete(ctx, rec.ID); err != nil {
+				j.logger.Error("Failed to delete silence record", "id", rec.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := j.repo.Delete(ctx, rec.ID); err != nil {
+			j.logger.Error("Failed to delete silence record", "id", rec.ID, "error", err)
+			continue
+		}
+		j.logger.Info("Group silence lifted", "group_id", rec.GroupID)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/restriction"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePermissionsSetter 记录设置的群组权限
+type fakePermissionsSetter struct {
+	set map[int64]models.ChatPermissions
+	err error
+}
+
+func (f *fakePermissionsSetter) SetChatPermissions(ctx context.Context, chatID int64, permissions models.ChatPermissions) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.set == nil {
+		f.set = make(map[int64]models.ChatPermissions)
+	}
+	f.set[chatID] = permissions
+	return nil
+}
+
+func TestSilenceRestoreJob_RestoresExpired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	prior := &models.ChatPermissions{CanSendMessages: true, CanSendPhotos: true}
+
+	repo := &fakeBanRepo{records: map[string]*restriction.Record{
+		"expired": {ID: "expired", GroupID: -100, Kind: restriction.KindSilence, State: EncodeChatPermissions(prior), Until: now.Add(-time.Second)},
+		"future":  {ID: "future", GroupID: -200, Kind: restriction.KindSilence, Until: now.Add(time.Minute)},
+		"ban":     {ID: "ban", GroupID: -100, UserID: 1, Kind: restriction.KindBan, Until: now.Add(-time.Minute)},
+	}}
+	setter := &fakePermissionsSetter{}
+
+	job := NewSilenceRestoreJob(repo, setter, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Run(context.Background()))
+
+	// 只恢复到期的全群禁言，按禁言前的权限恢复
+	assert.Equal(t, map[int64]models.ChatPermissions{-100: *prior}, setter.set)
+	assert.NotContains(t, repo.records, "expired")
+	assert.Contains(t, repo.records, "future")
+	assert.Contains(t, repo.records, "ban")
+
+	// 时间到达后恢复另一个群组
+	now = now.Add(time.Minute)
+	require.NoError(t, job.Run(context.Background()))
+	assert.Equal(t, fallbackChatPermissions, setter.set[-200])
+	assert.NotContains(t, repo.records, "future")
+}
+
+func TestSilenceRestoreJob_GivesUpAfterRetryAge(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeBanRepo{records: map[string]*restriction.Record{
+		"recent": {ID: "recent", GroupID: -100, Kind: restriction.KindSilence, Until: now.Add(-time.Minute)},
+		"stale":  {ID: "stale", GroupID: -200, Kind: restriction.KindSilence, Until: now.Add(-maxRestoreRetryAge)},
+	}}
+	setter := &fakePermissionsSetter{err: errors.New("bot was kicked")}
+
+	job := NewSilenceRestoreJob(repo, setter, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	// 重试期内保留记录，重试期已过删除记录不再重试
+	require.NoError(t, job.Run(context.Background()))
+	assert.Contains(t, repo.records, "recent")
+	assert.NotContains(t, repo.records, "stale")
+}
+
+func TestDecodeChatPermissions(t *testing.T) {
+	prior := &models.ChatPermissions{CanSendMessages: true, CanPinMessages: true}
+	assert.Equal(t, *prior, decodeChatPermissions(EncodeChatPermissions(prior)))
+
+	// 没有保存或无法解析时恢复默认权限
+	assert.Equal(t, fallbackChatPermissions, decodeChatPermissions(""))
+	assert.Equal(t, fallbackChatPermissions, decodeChatPermissions("{broken"))
+}
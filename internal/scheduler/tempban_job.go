@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain/restriction"
+	"telegram-bot/pkg/logger"
+)
+
+// ExpiredBanRepository 临时封禁任务所需的仓储接口
+type ExpiredBanRepository interface {
+	FindExpired(ctx context.Context, kind restriction.Kind, now time.Time) ([]*restriction.Record, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ChatMemberUnbanner 解除封禁接口
+type ChatMemberUnbanner interface {
+	UnbanChatMember(ctx context.Context, chatID, userID int64) error
+}
+
+// maxUnbanRetryAge 到期超过该时长仍解封失败的记录不再重试（如机器人已被移出群组）
+const maxUnbanRetryAge = time.Hour
+
+// TempBanReconcileJob 临时封禁到期解除任务
+// 根据数据库中的封禁记录主动解封，不依赖 Telegram 自身的到期计时
+type TempBanReconcileJob struct {
+	repo     ExpiredBanRepository
+	unbanner ChatMemberUnbanner
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// NewTempBanReconcileJob 创建临时封禁到期解除任务
+func NewTempBanReconcileJob(repo ExpiredBanRepository, unbanner ChatMemberUnbanner, log logger.Logger) *TempBanReconcileJob {
+	return &TempBanReconcileJob{
+		repo:     repo,
+		unbanner: unbanner,
+		logger:   log,
+		now:      time.Now,
+	}
+}
+
+func (j *TempBanReconcileJob) Name() string {
+	return "TempBanReconcile"
+}
+
+func (j *TempBanReconcileJob) Schedule() string {
+	return "1m" // 每分钟检查一次
+}
+
+func (j *TempBanReconcileJob) Run(ctx context.Context) error {
+	now := j.now()
+	records, err := j.repo.FindExpired(ctx, restriction.KindBan, now)
+	if err != nil {
+		return err
+	}
+
+	unbanned, abandoned := 0, 0
+	for _, rec := range records {
+		if err := j.unbanner.UnbanChatMember(ctx, rec.GroupID, rec.UserID); err != nil {
+			if now.Sub(rec.Until) < maxUnbanRetryAge {
+				// 保留记录，下次继续尝试
+				j.logger.Error("Failed to unban member", "group_id", rec.GroupID, "user_id", rec.UserID, "error", err)
+				continue
+			}
+			// 重试期已过，删除记录放弃解封，避免无限重试
+			j.logger.Error("Giving up unbanning member", "group_id", rec.GroupID, "user_id", rec.UserID, "until", rec.Until, "error", err)
+			if err := j.repo.Delete(ctx, rec.ID); err != nil {
+				j.logger.Error("Failed to delete ban record", "id", rec.ID, "error", err)
+				continue
+			}
+			abandoned++
+			continue
+		}
+
+		if err := j.repo.Delete(ctx, rec.ID); err != nil {
+			j.logger.Error("Failed to delete ban record", "id", rec.ID, "error", err)
+			continue
+		}
+		unbanned++
+	}
+
+	if len(records) > 0 {
+		j.logger.Info("Expired temp bans reconciled", "expired", len(records), "unbanned", unbanned, "abandoned", abandoned)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain/restriction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBanRepo 内存中的封禁记录仓储
+type fakeBanRepo struct {
+	records map[string]*restriction.Record
+}
+
+func (r *fakeBanRepo) FindExpired(ctx context.Context, kind restriction.Kind, now time.Time) ([]*restriction.Record, error) {
+	var expired []*restriction.Record
+	for _, rec := range r.records {
+		if rec.Kind == kind && rec.IsExpired(now) {
+			expired = append(expired, rec)
+		}
+	}
+	return expired, nil
+}
+
+func (r *fakeBanRepo) Delete(ctx context.Context, id string) error {
+	delete(r.records, id)
+	return nil
+}
+
+// fakeUnbanner 记录被解封的用户
+type fakeUnbanner struct {
+	unbanned []int64
+	failFor  int64
+}
+
+func (u *fakeUnbanner) UnbanChatMember(ctx context.Context, chatID, userID int64) error {
+	if userID == u.failFor {
+		return errors.New("not enough rights")
+	}
+	u.unbanned = append(u.unbanned, userID)
+	return nil
+}
+
+func TestTempBanReconcileJob_UnbansOnlyExpired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeBanRepo{records: map[string]*restriction.Record{
+		"expired": {ID: "expired", GroupID: -100, UserID: 1, Kind: restriction.KindBan, Until: now.Add(-time.Minute)},
+		"exact":   {ID: "exact", GroupID: -100, UserID: 2, Kind: restriction.KindBan, Until: now},
+		"future":  {ID: "future", GroupID: -100, UserID: 3, Kind: restriction.KindBan, Until: now.Add(time.Hour)},
+	}}
+	unbanner := &fakeUnbanner{}
+
+	job := NewTempBanReconcileJob(repo, unbanner, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Run(context.Background()))
+
+	assert.ElementsMatch(t, []int64{1, 2}, unbanner.unbanned)
+	assert.Len(t, repo.records, 1)
+	assert.Contains(t, repo.records, "future")
+}
+
+func TestTempBanReconcileJob_KeepsRecordOnFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeBanRepo{records: map[string]*restriction.Record{
+		"expired": {ID: "expired", GroupID: -100, UserID: 1, Kind: restriction.KindBan, Until: now.Add(-time.Minute)},
+	}}
+	unbanner := &fakeUnbanner{failFor: 1}
+
+	job := NewTempBanReconcileJob(repo, unbanner, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Run(context.Background()))
+	assert.Contains(t, repo.records, "expired")
+}
+
+func TestTempBanReconcileJob_GivesUpAfterRetryAge(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeBanRepo{records: map[string]*restriction.Record{
+		"stale": {ID: "stale", GroupID: -100, UserID: 1, Kind: restriction.KindBan, Until: now.Add(-maxUnbanRetryAge)},
+	}}
+	unbanner := &fakeUnbanner{failFor: 1}
+
+	job := NewTempBanReconcileJob(repo, unbanner, &MockLogger{})
+	job.now = func() time.Time { return now }
+
+	// 到期超过重试期仍失败，删除记录不再重试
+	require.NoError(t, job.Run(context.Background()))
+	assert.Empty(t, repo.records)
+}
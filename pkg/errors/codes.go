@@ -11,6 +11,15 @@ const (
 	// CodePermission 权限错误
 	CodePermission = "PERMISSION_DENIED"
 
+	// CodeInsufficientPermission 用户权限等级不足
+	CodeInsufficientPermission = "INSUFFICIENT_PERMISSION"
+
+	// CodeBotPermission 机器人在群组中缺少执行操作所需的管理员权限
+	CodeBotPermission = "BOT_PERMISSION_DENIED"
+
+	// CodeUserNotFound 用户不存在
+	CodeUserNotFound = "USER_NOT_FOUND"
+
 	// CodeInternal 内部错误
 	CodeInternal = "INTERNAL_ERROR"
 
@@ -23,9 +32,18 @@ const (
 	// CodeRateLimit 限流错误
 	CodeRateLimit = "RATE_LIMIT_EXCEEDED"
 
+	// CodeUnsupportedChatType 命令不支持当前聊天类型（如在私聊中使用群组命令）
+	CodeUnsupportedChatType = "UNSUPPORTED_CHAT_TYPE"
+
 	// CodeTimeout 超时错误
 	CodeTimeout = "TIMEOUT"
 
+	// CodeMaintenance 机器人处于维护模式，暂停处理命令
+	CodeMaintenance = "MAINTENANCE"
+
+	// CodeNotMember 调用者不是群组成员
+	CodeNotMember = "NOT_MEMBER"
+
 	// CodeUnknown 未知错误
 	CodeUnknown = "UNKNOWN"
 )
\ No newline at end of file
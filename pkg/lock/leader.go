@@ -0,0 +1,109 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Elector 基于分布式锁的主节点选举
+// 持有租约（一个带过期时间的锁）的实例为主节点，并在租约到期前定期续期；
+// 其他实例作为备用定期尝试获取租约，主节点宕机、租约过期后自动接管。
+type Elector struct {
+	locker        Locker
+	name          string
+	lease         time.Duration
+	renewInterval time.Duration
+
+	mu     sync.RWMutex
+	leader bool
+}
+
+// NewElector 创建主节点选举器
+// 续期间隔为租约的 1/3，保证偶发的续期失败不会立即丢失租约
+func NewElector(locker Locker, name string, lease time.Duration) *Elector {
+	return &Elector{
+		locker:        locker,
+		name:          name,
+		lease:         lease,
+		renewInterval: lease / 3,
+	}
+}
+
+// IsLeader 当前实例是否为主节点
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Run 参与选举直到 ctx 取消
+// 成为主节点时在新的 goroutine 中调用 lead，失去主节点身份时取消传给 lead 的 context
+// 并等待其返回。退出时释放租约，便于备用实例尽快接管。
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	var (
+		cancelLead context.CancelFunc
+		leadDone   chan struct{}
+	)
+	stopLeading := func() {
+		if cancelLead != nil {
+			cancelLead()
+			<-leadDone
+			cancelLead = nil
+		}
+	}
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		isLeader := e.step(ctx)
+
+		if isLeader && cancelLead == nil {
+			leadCtx, cancel := context.WithCancel(ctx)
+			cancelLead = cancel
+			leadDone = make(chan struct{})
+			go func() {
+				defer close(leadDone)
+				lead(leadCtx)
+			}()
+		} else if !isLeader {
+			stopLeading()
+		}
+
+		select {
+		case <-ctx.Done():
+			stopLeading()
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step 尝试获取或续期租约，返回当前是否为主节点
+// 续期出错时视为失去主节点身份，宁可短暂无主也不出现双主
+func (e *Elector) step(ctx context.Context) bool {
+	acquired, err := e.locker.TryAcquire(ctx, e.name, e.lease)
+	isLeader := err == nil && acquired
+
+	e.mu.Lock()
+	e.leader = isLeader
+	e.mu.Unlock()
+
+	return isLeader
+}
+
+// resign 放弃主节点身份并释放租约
+func (e *Elector) resign() {
+	e.mu.Lock()
+	wasLeader := e.leader
+	e.leader = false
+	e.mu.Unlock()
+
+	if wasLeader {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = e.locker.Release(ctx, e.name)
+	}
+}
@@ -0,0 +1,99 @@
+package lock
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector_LeaseRenewal(t *testing.T) {
+	b, now := newTestBackend()
+	ctx := context.Background()
+
+	primary := NewElector(b.Locker("a"), "leader", 15*time.Second)
+	standby := NewElector(b.Locker("b"), "leader", 15*time.Second)
+
+	assert.True(t, primary.step(ctx))
+	assert.False(t, standby.step(ctx))
+
+	// 主节点按续期间隔续约，租约一直有效
+	for i := 0; i < 5; i++ {
+		*now = now.Add(primary.renewInterval)
+		assert.True(t, primary.step(ctx))
+		assert.False(t, standby.step(ctx))
+	}
+
+	assert.True(t, primary.IsLeader())
+	assert.False(t, standby.IsLeader())
+}
+
+func TestElector_Failover(t *testing.T) {
+	b, now := newTestBackend()
+	ctx := context.Background()
+
+	primary := NewElector(b.Locker("a"), "leader", 15*time.Second)
+	standby := NewElector(b.Locker("b"), "leader", 15*time.Second)
+
+	require.True(t, primary.step(ctx))
+
+	// 主节点停止续约；租约到期前备用节点无法接管
+	*now = now.Add(14 * time.Second)
+	assert.False(t, standby.step(ctx))
+
+	*now = now.Add(time.Second)
+	assert.True(t, standby.step(ctx), "standby takes over after the lease expires")
+
+	// 原主节点恢复后成为备用
+	assert.False(t, primary.step(ctx))
+	assert.False(t, primary.IsLeader())
+}
+
+func TestElector_ResignReleasesLease(t *testing.T) {
+	b, _ := newTestBackend()
+	ctx := context.Background()
+
+	primary := NewElector(b.Locker("a"), "leader", time.Hour)
+	standby := NewElector(b.Locker("b"), "leader", time.Hour)
+
+	require.True(t, primary.step(ctx))
+	primary.resign()
+
+	assert.False(t, primary.IsLeader())
+	assert.True(t, standby.step(ctx))
+}
+
+func TestElector_RunStartsAndStopsLeading(t *testing.T) {
+	b := NewMemoryBackend()
+	elector := NewElector(b.Locker("a"), "leader", 30*time.Millisecond)
+
+	var leading int32
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		elector.Run(ctx, func(leadCtx context.Context) {
+			atomic.AddInt32(&leading, 1)
+			<-leadCtx.Done()
+		})
+		close(stopped)
+	}()
+
+	assert.Eventually(t, elector.IsLeader, time.Second, 5*time.Millisecond)
+
+	// 续期期间 lead 只启动一次
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&leading))
+
+	cancel()
+	<-stopped
+	assert.False(t, elector.IsLeader())
+
+	// 退出时释放租约，其他实例可以立即获取
+	ok, err := b.Locker("b").TryAcquire(context.Background(), "leader", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,37 @@
+// Package lock 提供多实例部署下的分布式锁
+//
+// 多个 Bot 实例同时运行时，用锁保证同一时刻只有一个实例执行某项工作
+// （例如定时任务）。锁带有过期时间，持有者崩溃后会自动释放。
+package lock
+
+import (
+	"context"
+	"time"
+)
+
+// Locker 分布式锁接口
+type Locker interface {
+	// TryAcquire 尝试获取锁，ttl 后自动过期
+	// 锁被其他持有者占用时返回 false；当前持有者再次获取会延长过期时间
+	TryAcquire(ctx context.Context, name string, ttl time.Duration) (bool, error)
+	// Release 释放锁，只会释放自己持有的锁
+	Release(ctx context.Context, name string) error
+}
+
+// NoopLocker 单实例部署使用的空实现，总是获取成功
+type NoopLocker struct{}
+
+// NewNoopLocker 创建空实现
+func NewNoopLocker() NoopLocker {
+	return NoopLocker{}
+}
+
+// TryAcquire 总是获取成功
+func (NoopLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+// Release 无操作
+func (NoopLocker) Release(ctx context.Context, name string) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newTestBackend 创建使用可控时钟的锁存储
+func newTestBackend() (*MemoryBackend, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewMemoryBackend()
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestMemoryLocker_Acquire(t *testing.T) {
+	b, _ := newTestBackend()
+	ctx := context.Background()
+
+	ok, err := b.Locker("a").TryAcquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 持有者可以重复获取（续期）
+	ok, err = b.Locker("a").TryAcquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestMemoryLocker_Contention(t *testing.T) {
+	b, _ := newTestBackend()
+	ctx := context.Background()
+
+	ok, _ := b.Locker("a").TryAcquire(ctx, "job", time.Minute)
+	require.True(t, ok)
+
+	ok, err := b.Locker("b").TryAcquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "second owner should not acquire a held lock")
+
+	// 不同名称的锁互不影响
+	ok, _ = b.Locker("b").TryAcquire(ctx, "other", time.Minute)
+	assert.True(t, ok)
+}
+
+func TestMemoryLocker_Release(t *testing.T) {
+	b, _ := newTestBackend()
+	ctx := context.Background()
+
+	ok, _ := b.Locker("a").TryAcquire(ctx, "job", time.Minute)
+	require.True(t, ok)
+
+	// 其他持有者无法释放
+	require.NoError(t, b.Locker("b").Release(ctx, "job"))
+	ok, _ = b.Locker("b").TryAcquire(ctx, "job", time.Minute)
+	assert.False(t, ok)
+
+	require.NoError(t, b.Locker("a").Release(ctx, "job"))
+	ok, _ = b.Locker("b").TryAcquire(ctx, "job", time.Minute)
+	assert.True(t, ok)
+}
+
+func TestMemoryLocker_Expiry(t *testing.T) {
+	b, now := newTestBackend()
+	ctx := context.Background()
+
+	ok, _ := b.Locker("a").TryAcquire(ctx, "job", time.Minute)
+	require.True(t, ok)
+
+	*now = now.Add(59 * time.Second)
+	ok, _ = b.Locker("b").TryAcquire(ctx, "job", time.Minute)
+	assert.False(t, ok)
+
+	*now = now.Add(time.Second)
+	ok, _ = b.Locker("b").TryAcquire(ctx, "job", time.Minute)
+	assert.True(t, ok, "expired lock can be taken over")
+}
+
+func TestNoopLocker(t *testing.T) {
+	l := NewNoopLocker()
+
+	ok, err := l.TryAcquire(context.Background(), "job", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, l.Release(context.Background(), "job"))
+}
+
+func TestMongoLocker_AcquireQuery(t *testing.T) {
+	l := &MongoLocker{owner: "host-1"}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	filter, update := l.acquireQuery("scheduler:report", time.Hour, now)
+
+	assert.Equal(t, bson.M{
+		"_id": "scheduler:report",
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{"owner": "host-1"},
+		},
+	}, filter)
+	assert.Equal(t, bson.M{
+		"$set": bson.M{"owner": "host-1", "expires_at": now.Add(time.Hour)},
+	}, update)
+}
@@ -0,0 +1,67 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLock 内存锁记录
+type memoryLock struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryBackend 进程内锁存储
+// 同一进程内的多个持有者共享，主要用于测试
+type MemoryBackend struct {
+	mu    sync.Mutex
+	locks map[string]memoryLock
+	now   func() time.Time
+}
+
+// NewMemoryBackend 创建进程内锁存储
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		locks: make(map[string]memoryLock),
+		now:   time.Now,
+	}
+}
+
+// Locker 返回指定持有者的锁
+func (b *MemoryBackend) Locker(owner string) *MemoryLocker {
+	return &MemoryLocker{backend: b, owner: owner}
+}
+
+// MemoryLocker 进程内锁
+type MemoryLocker struct {
+	backend *MemoryBackend
+	owner   string
+}
+
+// TryAcquire 尝试获取锁
+func (l *MemoryLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	b := l.backend
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if current, ok := b.locks[name]; ok && current.owner != l.owner && now.Before(current.expiresAt) {
+		return false, nil
+	}
+
+	b.locks[name] = memoryLock{owner: l.owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Release 释放锁
+func (l *MemoryLocker) Release(ctx context.Context, name string) error {
+	b := l.backend
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if current, ok := b.locks[name]; ok && current.owner == l.owner {
+		delete(b.locks, name)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoLocker 基于 MongoDB 的分布式锁
+// 每个锁是 locks 集合中以锁名为 _id 的文档。获取锁时按
+// "锁已过期或持有者是自己" 条件 upsert：锁被他人持有时条件不匹配，
+// upsert 会因 _id 重复而失败，即视为获取失败。
+type MongoLocker struct {
+	collection *mongo.Collection
+	owner      string
+	now        func() time.Time
+}
+
+// NewMongoLocker 创建 MongoDB 分布式锁
+// owner 用于区分实例，每个实例应唯一（例如 主机名-进程号）
+func NewMongoLocker(db *mongo.Database, owner string) *MongoLocker {
+	return &MongoLocker{
+		collection: db.Collection("locks"),
+		owner:      owner,
+		now:        time.Now,
+	}
+}
+
+// TryAcquire 尝试获取锁
+func (l *MongoLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	filter, update := l.acquireQuery(name, ttl, l.now())
+
+	_, err := l.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil // 锁被其他实例持有
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Release 释放锁
+func (l *MongoLocker) Release(ctx context.Context, name string) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": l.owner})
+	return err
+}
+
+// acquireQuery 构建获取锁的查询条件和更新内容
+func (l *MongoLocker) acquireQuery(name string, ttl time.Duration, now time.Time) (bson.M, bson.M) {
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{"owner": l.owner},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"owner":      l.owner,
+			"expires_at": now.Add(ttl),
+		},
+	}
+	return filter, update
+}
@@ -10,16 +10,17 @@ import (
 // JSONLogger JSON 格式的日志实现
 type JSONLogger struct {
 	mu        sync.Mutex
-	level     Level
+	level     *levelVar // 日志级别（派生的 Logger 共享）
 	output    io.Writer
 	fields    map[string]interface{}
+	bound     []interface{} // With 绑定的键值对字段
 	addSource bool
 }
 
 // NewJSONLogger 创建 JSON Logger
 func NewJSONLogger(cfg Config) *JSONLogger {
 	return &JSONLogger{
-		level:     cfg.Level,
+		level:     newLevelVar(cfg.Level),
 		output:    cfg.Output,
 		fields:    make(map[string]interface{}),
 		addSource: cfg.AddSource,
@@ -36,7 +37,7 @@ type logEntry struct {
 
 // log 内部日志方法
 func (l *JSONLogger) log(level Level, msg string, fields ...interface{}) {
-	if level < l.level {
+	if !l.level.Enabled(level) {
 		return
 	}
 
@@ -51,15 +52,9 @@ func (l *JSONLogger) log(level Level, msg string, fields ...interface{}) {
 		allFields[k] = v
 	}
 
-	// 添加临时字段
-	for i := 0; i < len(fields); i += 2 {
-		if i+1 < len(fields) {
-			key, ok := fields[i].(string)
-			if ok {
-				allFields[key] = fields[i+1]
-			}
-		}
-	}
+	// 添加绑定字段和临时字段（临时字段覆盖同名的绑定字段）
+	addPairs(allFields, l.bound)
+	addPairs(allFields, fields)
 
 	// 构建日志条目
 	entry := logEntry{
@@ -114,6 +109,7 @@ func (l *JSONLogger) WithField(key string, value interface{}) Logger {
 		level:     l.level,
 		output:    l.output,
 		fields:    newFields,
+		bound:     l.bound,
 		addSource: l.addSource,
 	}
 }
@@ -132,13 +128,36 @@ func (l *JSONLogger) WithFields(fields map[string]interface{}) Logger {
 		level:     l.level,
 		output:    l.output,
 		fields:    newFields,
+		bound:     l.bound,
 		addSource: l.addSource,
 	}
 }
 
-// SetLevel 设置日志级别
+// With 绑定键值对字段，返回的子 Logger 在每条日志中先输出这些字段，父 Logger 不受影响
+func (l *JSONLogger) With(fields ...interface{}) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	return &JSONLogger{
+		level:     l.level,
+		output:    l.output,
+		fields:    l.fields, // 实例字段创建后不再修改，可以共享
+		bound:     bindFields(l.bound, fields),
+		addSource: l.addSource,
+	}
+}
+
+// addPairs 将键值对字段写入 map（键不是字符串或奇数个时忽略）
+func addPairs(dst map[string]interface{}, fields []interface{}) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if key, ok := fields[i].(string); ok {
+			dst[key] = fields[i+1]
+		}
+	}
+}
+
+// SetLevel 设置日志级别（同时作用于派生的 Logger，下一条日志立即生效）
 func (l *JSONLogger) SetLevel(level Level) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.level = level
+	l.level.Store(level)
 }
\ No newline at end of file
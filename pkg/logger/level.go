@@ -1,6 +1,9 @@
 package logger
 
-import "strings"
+import (
+	"strings"
+	"sync/atomic"
+)
 
 // Level 日志级别
 type Level int
@@ -42,4 +45,33 @@ func ParseLevel(s string) Level {
 	default:
 		return LevelInfo // 默认 Info
 	}
-}
\ No newline at end of file
+}
+
+// levelVar 可在运行时修改的日志级别（并发安全）
+// 通过 WithField/WithFields/WithContext 派生的 Logger 共享同一个 levelVar，
+// 因此在任一 Logger 上调用 SetLevel 会同时影响它派生出的所有 Logger
+type levelVar struct {
+	v atomic.Int32
+}
+
+// newLevelVar 创建日志级别
+func newLevelVar(level Level) *levelVar {
+	lv := &levelVar{}
+	lv.Store(level)
+	return lv
+}
+
+// Load 读取当前级别
+func (lv *levelVar) Load() Level {
+	return Level(lv.v.Load())
+}
+
+// Store 修改级别
+func (lv *levelVar) Store(level Level) {
+	lv.v.Store(int32(level))
+}
+
+// Enabled 该级别的日志是否输出
+func (lv *levelVar) Enabled(level Level) bool {
+	return level >= lv.Load()
+}
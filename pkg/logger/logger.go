@@ -18,6 +18,9 @@ type Logger interface {
 	WithField(key string, value interface{}) Logger
 	WithFields(fields map[string]interface{}) Logger
 
+	// 绑定键值对字段（如 "request_id", id），返回的子 Logger 在每条日志中先输出这些字段
+	With(fields ...interface{}) Logger
+
 	// 从 context 中提取字段
 	WithContext(ctx context.Context) Logger
 
@@ -82,6 +85,14 @@ func New(cfg Config) Logger {
 	}
 }
 
+// bindFields 复制父 Logger 的绑定字段并追加新字段
+// 只在 With 时分配一次，子 Logger 之间不共享底层数组
+func bindFields(parent, fields []interface{}) []interface{} {
+	bound := make([]interface{}, 0, len(parent)+len(fields))
+	bound = append(bound, parent...)
+	return append(bound, fields...)
+}
+
 // Default 创建默认的 Logger（Text 格式，Info 级别）
 func Default() Logger {
 	return New(Config{
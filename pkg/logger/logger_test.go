@@ -179,4 +179,75 @@ func TestLevelString(t *testing.T) {
 			t.Errorf("Level(%d).String() = %q, want %q", tt.level, got, tt.want)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestSetLevel(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := New(Config{Level: LevelInfo, Format: format, Output: &buf})
+			child := log.WithField("component", "test")
+
+			log.Debug("before")
+			if buf.Len() > 0 {
+				t.Fatal("Debug should not be logged at Info level")
+			}
+
+			// 修改后立即生效，派生的 Logger 同样生效
+			log.SetLevel(LevelDebug)
+			child.Debug("child debug")
+			if !strings.Contains(buf.String(), "child debug") {
+				t.Error("Debug should be logged after SetLevel(LevelDebug)")
+			}
+			buf.Reset()
+
+			child.SetLevel(LevelError)
+			log.Warn("warn")
+			if buf.Len() > 0 {
+				t.Error("Warn should not be logged after SetLevel(LevelError)")
+			}
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			parent := New(Config{Level: LevelInfo, Format: format, Output: &buf})
+			child := parent.With("request_id", "req123", "user_id", 42)
+
+			child.Info("handled", "duration_ms", 5)
+			out := buf.String()
+			for _, want := range []string{"req123", "user_id", "42", "duration_ms"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("child log %q should contain %q", out, want)
+				}
+			}
+			buf.Reset()
+
+			// 派生的子 Logger 继承绑定字段
+			child.With("group_id", -100).Info("nested")
+			if out := buf.String(); !strings.Contains(out, "req123") || !strings.Contains(out, "-100") {
+				t.Errorf("nested log %q should contain bound fields", out)
+			}
+			buf.Reset()
+
+			// 父 Logger 不受影响
+			parent.Info("plain")
+			if out := buf.String(); strings.Contains(out, "req123") || strings.Contains(out, "group_id") {
+				t.Errorf("parent log %q should not contain child fields", out)
+			}
+		})
+	}
+}
+
+func TestWith_TextOrder(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewStandardLogger(Config{Level: LevelInfo, Output: &buf})
+
+	log.With("request_id", "req123").Info("msg", "user_id", 42)
+	if !strings.Contains(buf.String(), "msg request_id=req123 user_id=42") {
+		t.Errorf("bound fields should precede call fields: %q", buf.String())
+	}
+}
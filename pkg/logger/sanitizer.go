@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -8,6 +9,7 @@ import (
 // Sanitizer 敏感信息脱敏器
 type Sanitizer struct {
 	patterns map[string]*regexp.Regexp
+	custom   []*regexp.Regexp // 自定义规则，匹配内容整体替换为 ***
 }
 
 // NewSanitizer 创建新的脱敏器
@@ -28,6 +30,20 @@ func NewSanitizer() *Sanitizer {
 	}
 }
 
+// NewSanitizerWithPatterns 创建带自定义规则的脱敏器
+// 先应用内置规则（Token、密码、Email、信用卡、手机号），再将匹配 patterns（正则）的内容替换为 ***
+func NewSanitizerWithPatterns(patterns []string) (*Sanitizer, error) {
+	s := NewSanitizer()
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		s.custom = append(s.custom, re)
+	}
+	return s, nil
+}
+
 // Sanitize 脱敏字符串
 func (s *Sanitizer) Sanitize(text string) string {
 	result := text
@@ -73,6 +89,11 @@ func (s *Sanitizer) Sanitize(text string) string {
 		})
 	}
 
+	// 自定义规则
+	for _, re := range s.custom {
+		result = re.ReplaceAllString(result, "***")
+	}
+
 	return result
 }
 
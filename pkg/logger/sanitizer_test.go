@@ -168,3 +168,22 @@ func TestSanitizer_MultiplePatterns(t *testing.T) {
 		t.Error("expected phone to be sanitized")
 	}
 }
+
+func TestSanitizerWithPatterns(t *testing.T) {
+	s, err := NewSanitizerWithPatterns([]string{`sk-[A-Za-z0-9]{8,}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := s.Sanitize("key sk-abcdef123456 mail user@example.com")
+	if strings.Contains(result, "sk-abcdef123456") || !strings.Contains(result, "key *** mail") {
+		t.Errorf("expected custom pattern masked, got: %s", result)
+	}
+	if !strings.Contains(result, "***@example.com") {
+		t.Errorf("expected built-in rules still applied, got: %s", result)
+	}
+
+	if _, err := NewSanitizerWithPatterns([]string{"("}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
@@ -10,9 +10,10 @@ import (
 // StandardLogger 标准文本格式的日志实现
 type StandardLogger struct {
 	mu             sync.Mutex
-	level          Level
+	level          *levelVar // 日志级别（派生的 Logger 共享）
 	output         io.Writer
 	fields         map[string]interface{}
+	bound          []interface{} // With 绑定的键值对字段
 	timeFormat     string
 	addSource      bool
 	enableSanitize bool
@@ -27,7 +28,7 @@ func NewStandardLogger(cfg Config) *StandardLogger {
 	}
 
 	return &StandardLogger{
-		level:          cfg.Level,
+		level:          newLevelVar(cfg.Level),
 		output:         cfg.Output,
 		fields:         make(map[string]interface{}),
 		timeFormat:     cfg.TimeFormat,
@@ -39,7 +40,7 @@ func NewStandardLogger(cfg Config) *StandardLogger {
 
 // log 内部日志方法
 func (l *StandardLogger) log(level Level, msg string, fields ...interface{}) {
-	if level < l.level {
+	if !l.level.Enabled(level) {
 		return // 级别不够，不输出
 	}
 
@@ -65,16 +66,9 @@ func (l *StandardLogger) log(level Level, msg string, fields ...interface{}) {
 		}
 	}
 
-	// 添加临时字段
-	if len(fields) > 0 {
-		for i := 0; i < len(fields); i += 2 {
-			if i+1 < len(fields) {
-				key := fields[i]
-				val := fields[i+1]
-				output += fmt.Sprintf(" %s=%v", key, val)
-			}
-		}
-	}
+	// 添加绑定字段和临时字段
+	output += formatPairs(l.bound)
+	output += formatPairs(fields)
 
 	output += "\n"
 	l.output.Write([]byte(output))
@@ -112,6 +106,7 @@ func (l *StandardLogger) WithField(key string, value interface{}) Logger {
 		level:          l.level,
 		output:         l.output,
 		fields:         newFields,
+		bound:          l.bound,
 		timeFormat:     l.timeFormat,
 		addSource:      l.addSource,
 		enableSanitize: l.enableSanitize,
@@ -139,6 +134,7 @@ func (l *StandardLogger) WithFields(fields map[string]interface{}) Logger {
 		level:          l.level,
 		output:         l.output,
 		fields:         newFields,
+		bound:          l.bound,
 		timeFormat:     l.timeFormat,
 		addSource:      l.addSource,
 		enableSanitize: l.enableSanitize,
@@ -146,9 +142,34 @@ func (l *StandardLogger) WithFields(fields map[string]interface{}) Logger {
 	}
 }
 
-// SetLevel 设置日志级别
+// With 绑定键值对字段，返回的子 Logger 在每条日志中先输出这些字段，父 Logger 不受影响
+func (l *StandardLogger) With(fields ...interface{}) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	return &StandardLogger{
+		level:          l.level,
+		output:         l.output,
+		fields:         l.fields, // 实例字段创建后不再修改，可以共享
+		bound:          bindFields(l.bound, fields),
+		timeFormat:     l.timeFormat,
+		addSource:      l.addSource,
+		enableSanitize: l.enableSanitize,
+		sanitizer:      l.sanitizer,
+	}
+}
+
+// formatPairs 格式化键值对字段（奇数个时忽略最后一个键）
+func formatPairs(fields []interface{}) string {
+	var output string
+	for i := 0; i+1 < len(fields); i += 2 {
+		output += fmt.Sprintf(" %s=%v", fields[i], fields[i+1])
+	}
+	return output
+}
+
+// SetLevel 设置日志级别（同时作用于派生的 Logger，下一条日志立即生效）
 func (l *StandardLogger) SetLevel(level Level) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.level = level
+	l.level.Store(level)
 }
\ No newline at end of file
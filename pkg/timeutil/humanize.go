@@ -0,0 +1,53 @@
+// Package timeutil 提供面向用户的时间显示工具
+package timeutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// Locale 相对时间的文案（便于以后支持多语言）
+type Locale struct {
+	JustNow string // 不足一分钟
+	Minutes string // 分钟数的格式，如 "%d分钟"
+	Hours   string // 小时数的格式
+	Days    string // 天数的格式
+	Ago     string // 过去时间的后缀
+	Later   string // 未来时间的后缀
+}
+
+// Chinese 中文文案（项目默认）：刚刚、5分钟前、3小时后、2天前
+var Chinese = Locale{
+	JustNow: "刚刚",
+	Minutes: "%d分钟",
+	Hours:   "%d小时",
+	Days:    "%d天",
+	Ago:     "前",
+	Later:   "后",
+}
+
+// Humanize 用中文文案显示相对时间，d 为距今经过的时长（负数表示未来）
+func Humanize(d time.Duration) string {
+	return Chinese.Humanize(d)
+}
+
+// Humanize 显示相对时间，d 为距今经过的时长（负数表示未来）
+// 不足 1 分钟显示为"刚刚"，之后依次按分钟、小时、天向下取整
+func (l Locale) Humanize(d time.Duration) string {
+	suffix := l.Ago
+	if d < 0 {
+		d = -d
+		suffix = l.Later
+	}
+
+	switch {
+	case d < time.Minute:
+		return l.JustNow
+	case d < time.Hour:
+		return fmt.Sprintf(l.Minutes, int(d/time.Minute)) + suffix
+	case d < 24*time.Hour:
+		return fmt.Sprintf(l.Hours, int(d/time.Hour)) + suffix
+	default:
+		return fmt.Sprintf(l.Days, int(d/(24*time.Hour))) + suffix
+	}
+}
@@ -0,0 +1,42 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "刚刚"},
+		{59 * time.Second, "刚刚"},
+		{60 * time.Second, "1分钟前"},
+		{59*time.Minute + 59*time.Second, "59分钟前"},
+		{60 * time.Minute, "1小时前"},
+		{23*time.Hour + 59*time.Minute, "23小时前"},
+		{24 * time.Hour, "1天前"},
+		{49 * time.Hour, "2天前"},
+		{400 * 24 * time.Hour, "400天前"},
+		{-30 * time.Second, "刚刚"},
+		{-90 * time.Minute, "1小时后"},
+		{-72 * time.Hour, "3天后"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, Humanize(tt.d))
+		})
+	}
+}
+
+func TestLocale_Humanize(t *testing.T) {
+	english := Locale{JustNow: "just now", Minutes: "%d min", Hours: "%d h", Days: "%d d", Ago: " ago", Later: " later"}
+
+	assert.Equal(t, "just now", english.Humanize(10*time.Second))
+	assert.Equal(t, "5 min ago", english.Humanize(5*time.Minute))
+	assert.Equal(t, "2 d later", english.Humanize(-48*time.Hour))
+}
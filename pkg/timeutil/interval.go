@@ -0,0 +1,37 @@
+package timeutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseInterval 解析时间间隔（调度任务的 Schedule 和命令参数通用）
+// 支持格式：
+// - "30s" - 30秒
+// - "5m" - 5分钟
+// - "1h" - 1小时
+// - "1d" - 1天
+func ParseInterval(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty schedule")
+	}
+
+	// 尝试直接解析（支持 s, m, h）
+	duration, err := time.ParseDuration(s)
+	if err == nil {
+		return duration, nil
+	}
+
+	// 支持 "d" (天) 格式
+	if len(s) > 1 && s[len(s)-1] == 'd' {
+		days := s[:len(s)-1]
+		var d int
+		_, err := fmt.Sscanf(days, "%d", &d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid schedule format: %s", s)
+		}
+		return time.Duration(d) * 24 * time.Hour, nil
+	}
+
+	return 0, fmt.Errorf("invalid schedule format: %s (supported: 30s, 5m, 1h, 1d)", s)
+}
@@ -0,0 +1,72 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "parse seconds",
+			schedule: "30s",
+			want:     30 * time.Second,
+			wantErr:  false,
+		},
+		{
+			name:     "parse minutes",
+			schedule: "5m",
+			want:     5 * time.Minute,
+			wantErr:  false,
+		},
+		{
+			name:     "parse hours",
+			schedule: "1h",
+			want:     1 * time.Hour,
+			wantErr:  false,
+		},
+		{
+			name:     "parse days",
+			schedule: "1d",
+			want:     24 * time.Hour,
+			wantErr:  false,
+		},
+		{
+			name:     "parse multiple days",
+			schedule: "7d",
+			want:     7 * 24 * time.Hour,
+			wantErr:  false,
+		},
+		{
+			name:     "invalid format",
+			schedule: "invalid",
+			want:     0,
+			wantErr:  true,
+		},
+		{
+			name:     "empty schedule",
+			schedule: "",
+			want:     0,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInterval(tt.schedule)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package ttlmap 提供带过期时间的进程内 map
+//
+// 用于保存等待用户响应的临时状态（例如确认对话框、入群验证、内存中的定时解除），
+// 条目在被处理前过期时由后台清理任务删除并回调 OnExpire（例如验证超时后踢出用户），
+// 避免从未被处理的条目一直占用内存。
+package ttlmap
+
+import (
+	"sync"
+	"time"
+)
+
+// entry 条目
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// expired 条目是否已过期
+func (e entry[V]) expired(now time.Time) bool {
+	return !now.Before(e.expiresAt)
+}
+
+// Map 带过期时间的 map（并发安全）
+// 过期条目在 Get/Delete 中立即视为不存在，但只由 Sweep 删除，
+// 因此每个过期条目的 OnExpire 回调恰好执行一次；被 Delete 取走的条目不会回调
+type Map[K comparable, V any] struct {
+	onExpire func(key K, value V) // 可为 nil
+
+	mu      sync.Mutex
+	entries map[K]entry[V]
+	now     func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New 创建 TTL map，onExpire 在条目过期被清理时调用（可为 nil）
+func New[K comparable, V any](onExpire func(key K, value V)) *Map[K, V] {
+	return &Map[K, V]{
+		onExpire: onExpire,
+		entries:  make(map[K]entry[V]),
+		now:      time.Now,
+	}
+}
+
+// Set 设置条目，ttl 后过期；覆盖未过期的同名条目时不会回调
+// 同名条目已过期但尚未清理时，先按过期处理再写入新值
+func (m *Map[K, V]) Set(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	now := m.now()
+	old, replaced := m.entries[key]
+	m.entries[key] = entry[V]{value: value, expiresAt: now.Add(ttl)}
+	m.mu.Unlock()
+
+	if replaced && old.expired(now) {
+		m.expire(key, old.value)
+	}
+}
+
+// Get 获取未过期的条目
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || e.expired(m.now()) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Delete 取走未过期的条目（例如用户已确认），之后不会再回调 OnExpire
+// 条目不存在或已过期时返回 false
+func (m *Map[K, V]) Delete(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || e.expired(m.now()) {
+		var zero V
+		return zero, false
+	}
+	delete(m.entries, key)
+	return e.value, true
+}
+
+// Len 当前条目数（包含尚未清理的过期条目）
+func (m *Map[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.entries)
+}
+
+// Sweep 删除所有已过期条目并逐个回调 OnExpire，返回删除数量
+// 回调在锁外执行，可以在回调中再次访问 Map
+func (m *Map[K, V]) Sweep() int {
+	type expiredEntry struct {
+		key   K
+		value V
+	}
+
+	m.mu.Lock()
+	now := m.now()
+	var expired []expiredEntry
+	for key, e := range m.entries {
+		if e.expired(now) {
+			expired = append(expired, expiredEntry{key: key, value: e.value})
+			delete(m.entries, key)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, e := range expired {
+		m.expire(e.key, e.value)
+	}
+	return len(expired)
+}
+
+// expire 回调 OnExpire
+func (m *Map[K, V]) expire(key K, value V) {
+	if m.onExpire != nil {
+		m.onExpire(key, value)
+	}
+}
+
+// StartSweeper 启动后台清理任务，每隔 interval 清理过期条目
+// 过期条目最晚在到期后 interval 内被清理
+func (m *Map[K, V]) StartSweeper(interval time.Duration) {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.Sweep()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台清理任务
+func (m *Map[K, V]) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+	m.stop = nil
+}
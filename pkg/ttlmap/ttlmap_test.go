@@ -0,0 +1,133 @@
+package ttlmap
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiryRecorder 记录 OnExpire 回调
+type expiryRecorder struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newExpiryRecorder() *expiryRecorder {
+	return &expiryRecorder{calls: make(map[string]int)}
+}
+
+func (r *expiryRecorder) onExpire(key string, value int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[key]++
+}
+
+func (r *expiryRecorder) count(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[key]
+}
+
+// newTestMap 创建使用可控时钟的 TTL map
+func newTestMap(r *expiryRecorder) (*Map[string, int], *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := New[string, int](r.onExpire)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestMap_ExpiresOnTime(t *testing.T) {
+	r := newExpiryRecorder()
+	m, now := newTestMap(r)
+
+	m.Set("captcha", 1, time.Minute)
+
+	*now = now.Add(59 * time.Second)
+	v, ok := m.Get("captcha")
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 0, m.Sweep())
+
+	*now = now.Add(time.Second)
+	_, ok = m.Get("captcha")
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.Sweep())
+	assert.Equal(t, 0, m.Len())
+
+	// 回调恰好执行一次
+	assert.Equal(t, 0, m.Sweep())
+	assert.Equal(t, 1, r.count("captcha"))
+}
+
+func TestMap_DeleteCancelsExpiry(t *testing.T) {
+	r := newExpiryRecorder()
+	m, now := newTestMap(r)
+
+	m.Set("confirm", 7, time.Minute)
+	v, ok := m.Delete("confirm")
+	require.True(t, ok)
+	assert.Equal(t, 7, v)
+
+	*now = now.Add(time.Hour)
+	assert.Equal(t, 0, m.Sweep())
+	assert.Equal(t, 0, r.count("confirm"))
+}
+
+func TestMap_DeleteAfterExpiry(t *testing.T) {
+	r := newExpiryRecorder()
+	m, now := newTestMap(r)
+
+	m.Set("confirm", 7, time.Minute)
+	*now = now.Add(time.Minute)
+
+	// 已过期的条目不能再被取走，仍由清理任务回调
+	_, ok := m.Delete("confirm")
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.Sweep())
+	assert.Equal(t, 1, r.count("confirm"))
+}
+
+func TestMap_SetOverExpiredEntry(t *testing.T) {
+	r := newExpiryRecorder()
+	m, now := newTestMap(r)
+
+	m.Set("key", 1, time.Minute)
+	m.Set("key", 2, time.Minute) // 覆盖未过期条目，不回调
+	assert.Equal(t, 0, r.count("key"))
+
+	*now = now.Add(time.Minute)
+	m.Set("key", 3, time.Minute) // 覆盖已过期条目，先回调
+	assert.Equal(t, 1, r.count("key"))
+
+	v, ok := m.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 0, m.Sweep())
+	assert.Equal(t, 1, r.count("key"))
+}
+
+func TestMap_StartSweeper(t *testing.T) {
+	fired := make(chan string, 2)
+	m := New[string, int](func(key string, value int) {
+		fired <- key
+	})
+	m.StartSweeper(5 * time.Millisecond)
+	defer m.Stop()
+
+	m.Set("a", 1, 20*time.Millisecond)
+
+	select {
+	case key := <-fired:
+		assert.Equal(t, "a", key)
+	case <-time.After(time.Second):
+		t.Fatal("entry did not expire")
+	}
+
+	// 后续清理不会再次回调
+	time.Sleep(30 * time.Millisecond)
+	assert.Len(t, fired, 0)
+	assert.Equal(t, 0, m.Len())
+}
@@ -95,6 +95,8 @@ func UserID(id int64) error {
 }
 
 // GroupID 验证群组 ID
+// 私聊没有群组概念（ChatID 为正数的用户 ID），私聊流程不应调用此校验；
+// 需要按群组区分的数据在私聊中使用 groupID = 0 表示全局
 func GroupID(id int64) error {
 	// Telegram 群组 ID 通常是负数
 	if id >= 0 {
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	group "telegram-bot/internal/domain/group"
 
@@ -41,73 +42,87 @@ func (m *MockGroupRepository) EXPECT() *MockGroupRepositoryMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockGroupRepository) Delete(id int64) error {
+func (m *MockGroupRepository) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockGroupRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockGroupRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGroupRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGroupRepository)(nil).Delete), ctx, id)
 }
 
 // FindAll mocks base method.
-func (m *MockGroupRepository) FindAll() ([]*group.Group, error) {
+func (m *MockGroupRepository) FindAll(ctx context.Context) ([]*group.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].([]*group.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockGroupRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockGroupRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGroupRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGroupRepository)(nil).FindAll), ctx)
 }
 
 // FindByID mocks base method.
-func (m *MockGroupRepository) FindByID(id int64) (*group.Group, error) {
+func (m *MockGroupRepository) FindByID(ctx context.Context, id int64) (*group.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
 	ret0, _ := ret[0].(*group.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockGroupRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+func (mr *MockGroupRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGroupRepository)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGroupRepository)(nil).FindByID), ctx, id)
 }
 
 // Save mocks base method.
-func (m *MockGroupRepository) Save(arg0 *group.Group) error {
+func (m *MockGroupRepository) Save(ctx context.Context, arg1 *group.Group) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0)
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockGroupRepositoryMockRecorder) Save(arg0 any) *gomock.Call {
+func (mr *MockGroupRepositoryMockRecorder) Save(ctx, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGroupRepository)(nil).Save), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGroupRepository)(nil).Save), ctx, arg1)
+}
+
+// UnsetCommands mocks base method.
+func (m *MockGroupRepository) UnsetCommands(ctx context.Context, id int64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsetCommands", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsetCommands indicates an expected call of UnsetCommands.
+func (mr *MockGroupRepositoryMockRecorder) UnsetCommands(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetCommands", reflect.TypeOf((*MockGroupRepository)(nil).UnsetCommands), ctx, id, names)
 }
 
 // Update mocks base method.
-func (m *MockGroupRepository) Update(arg0 *group.Group) error {
+func (m *MockGroupRepository) Update(ctx context.Context, arg1 *group.Group) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	ret := m.ctrl.Call(m, "Update", ctx, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockGroupRepositoryMockRecorder) Update(arg0 any) *gomock.Call {
+func (mr *MockGroupRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepository)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepository)(nil).Update), ctx, arg1)
 }
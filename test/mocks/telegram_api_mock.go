@@ -11,8 +11,11 @@ package mocks
 
 import (
 	reflect "reflect"
+	group "telegram-bot/internal/domain/group"
+	handler "telegram-bot/internal/handler"
 	time "time"
 
+	models "github.com/go-telegram/bot/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +43,20 @@ func (m *MockTelegramAPI) EXPECT() *MockTelegramAPIMockRecorder {
 	return m.recorder
 }
 
+// AnswerCallbackQuery mocks base method.
+func (m *MockTelegramAPI) AnswerCallbackQuery(queryID, text string, showAlert bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerCallbackQuery", queryID, text, showAlert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnswerCallbackQuery indicates an expected call of AnswerCallbackQuery.
+func (mr *MockTelegramAPIMockRecorder) AnswerCallbackQuery(queryID, text, showAlert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerCallbackQuery", reflect.TypeOf((*MockTelegramAPI)(nil).AnswerCallbackQuery), queryID, text, showAlert)
+}
+
 // BanChatMember mocks base method.
 func (m *MockTelegramAPI) BanChatMember(chatID, userID int64) error {
 	m.ctrl.T.Helper()
@@ -68,16 +85,105 @@ func (mr *MockTelegramAPIMockRecorder) BanChatMemberWithDuration(chatID, userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanChatMemberWithDuration", reflect.TypeOf((*MockTelegramAPI)(nil).BanChatMemberWithDuration), chatID, userID, until)
 }
 
-// SendMessage mocks base method.
-func (m *MockTelegramAPI) SendMessage(chatID int64, text string) error {
+// EditMessageWithButtons mocks base method.
+func (m *MockTelegramAPI) EditMessageWithButtons(chatID int64, messageID int, text string, buttons [][]handler.Button) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendMessage", chatID, text)
+	ret := m.ctrl.Call(m, "EditMessageWithButtons", chatID, messageID, text, buttons)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// EditMessageWithButtons indicates an expected call of EditMessageWithButtons.
+func (mr *MockTelegramAPIMockRecorder) EditMessageWithButtons(chatID, messageID, text, buttons any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditMessageWithButtons", reflect.TypeOf((*MockTelegramAPI)(nil).EditMessageWithButtons), chatID, messageID, text, buttons)
+}
+
+// GetChat mocks base method.
+func (m *MockTelegramAPI) GetChat(chatID int64) (*models.ChatFullInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChat", chatID)
+	ret0, _ := ret[0].(*models.ChatFullInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChat indicates an expected call of GetChat.
+func (mr *MockTelegramAPIMockRecorder) GetChat(chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChat", reflect.TypeOf((*MockTelegramAPI)(nil).GetChat), chatID)
+}
+
+// GetChatAdministrators mocks base method.
+func (m *MockTelegramAPI) GetChatAdministrators(chatID int64) ([]*group.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAdministrators", chatID)
+	ret0, _ := ret[0].([]*group.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAdministrators indicates an expected call of GetChatAdministrators.
+func (mr *MockTelegramAPIMockRecorder) GetChatAdministrators(chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAdministrators", reflect.TypeOf((*MockTelegramAPI)(nil).GetChatAdministrators), chatID)
+}
+
+// GetChatMember mocks base method.
+func (m *MockTelegramAPI) GetChatMember(chatID, userID int64) (*group.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMember", chatID, userID)
+	ret0, _ := ret[0].(*group.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMember indicates an expected call of GetChatMember.
+func (mr *MockTelegramAPIMockRecorder) GetChatMember(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMember", reflect.TypeOf((*MockTelegramAPI)(nil).GetChatMember), chatID, userID)
+}
+
+// SendMessage mocks base method.
+func (m *MockTelegramAPI) SendMessage(chatID int64, text string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", chatID, text)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // SendMessage indicates an expected call of SendMessage.
 func (mr *MockTelegramAPIMockRecorder) SendMessage(chatID, text any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockTelegramAPI)(nil).SendMessage), chatID, text)
 }
+
+// SendMessageWithButtons mocks base method.
+func (m *MockTelegramAPI) SendMessageWithButtons(chatID int64, text string, buttons [][]handler.Button) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageWithButtons", chatID, text, buttons)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessageWithButtons indicates an expected call of SendMessageWithButtons.
+func (mr *MockTelegramAPIMockRecorder) SendMessageWithButtons(chatID, text, buttons any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageWithButtons", reflect.TypeOf((*MockTelegramAPI)(nil).SendMessageWithButtons), chatID, text, buttons)
+}
+
+// SetChatPermissions mocks base method.
+func (m *MockTelegramAPI) SetChatPermissions(chatID int64, permissions models.ChatPermissions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatPermissions", chatID, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatPermissions indicates an expected call of SetChatPermissions.
+func (mr *MockTelegramAPIMockRecorder) SetChatPermissions(chatID, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatPermissions", reflect.TypeOf((*MockTelegramAPI)(nil).SetChatPermissions), chatID, permissions)
+}
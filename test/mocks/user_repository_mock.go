@@ -10,8 +10,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	user "telegram-bot/internal/domain/user"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,89 +42,147 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUserRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepository)(nil).Count), ctx)
+}
+
 // Delete mocks base method.
-func (m *MockUserRepository) Delete(id int64) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
 }
 
 // FindAdminsByGroup mocks base method.
-func (m *MockUserRepository) FindAdminsByGroup(groupID int64) ([]*user.User, error) {
+func (m *MockUserRepository) FindAdminsByGroup(ctx context.Context, groupID int64) ([]*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAdminsByGroup", groupID)
+	ret := m.ctrl.Call(m, "FindAdminsByGroup", ctx, groupID)
 	ret0, _ := ret[0].([]*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAdminsByGroup indicates an expected call of FindAdminsByGroup.
-func (mr *MockUserRepositoryMockRecorder) FindAdminsByGroup(groupID any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindAdminsByGroup(ctx, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdminsByGroup", reflect.TypeOf((*MockUserRepository)(nil).FindAdminsByGroup), groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdminsByGroup", reflect.TypeOf((*MockUserRepository)(nil).FindAdminsByGroup), ctx, groupID)
 }
 
 // FindByID mocks base method.
-func (m *MockUserRepository) FindByID(id int64) (*user.User, error) {
+func (m *MockUserRepository) FindByID(ctx context.Context, id int64) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockUserRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, id)
 }
 
 // FindByUsername mocks base method.
-func (m *MockUserRepository) FindByUsername(username string) (*user.User, error) {
+func (m *MockUserRepository) FindByUsername(ctx context.Context, username string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUsername", username)
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUsername indicates an expected call of FindByUsername.
-func (mr *MockUserRepositoryMockRecorder) FindByUsername(username any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepository)(nil).FindByUsername), ctx, username)
+}
+
+// FindInactive mocks base method.
+func (m *MockUserRepository) FindInactive(ctx context.Context, groupID int64, before time.Time, limit int) ([]*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInactive", ctx, groupID, before, limit)
+	ret0, _ := ret[0].([]*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInactive indicates an expected call of FindInactive.
+func (mr *MockUserRepositoryMockRecorder) FindInactive(ctx, groupID, before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepository)(nil).FindByUsername), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInactive", reflect.TypeOf((*MockUserRepository)(nil).FindInactive), ctx, groupID, before, limit)
+}
+
+// RecordSeen mocks base method.
+func (m *MockUserRepository) RecordSeen(ctx context.Context, arg1 *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSeen", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSeen indicates an expected call of RecordSeen.
+func (mr *MockUserRepositoryMockRecorder) RecordSeen(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSeen", reflect.TypeOf((*MockUserRepository)(nil).RecordSeen), ctx, arg1)
 }
 
 // Save mocks base method.
-func (m *MockUserRepository) Save(arg0 *user.User) error {
+func (m *MockUserRepository) Save(ctx context.Context, arg1 *user.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0)
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockUserRepositoryMockRecorder) Save(arg0 any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Save(ctx, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), ctx, arg1)
 }
 
 // Update mocks base method.
-func (m *MockUserRepository) Update(arg0 *user.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, arg1 *user.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	ret := m.ctrl.Call(m, "Update", ctx, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserRepositoryMockRecorder) Update(arg0 any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, arg1)
+}
+
+// UpdatePermission mocks base method.
+func (m *MockUserRepository) UpdatePermission(ctx context.Context, userID, groupID int64, perm user.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePermission", ctx, userID, groupID, perm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePermission indicates an expected call of UpdatePermission.
+func (mr *MockUserRepositoryMockRecorder) UpdatePermission(ctx, userID, groupID, perm any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePermission", reflect.TypeOf((*MockUserRepository)(nil).UpdatePermission), ctx, userID, groupID, perm)
 }